@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/assertly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/endly/service/workflow"
+	"github.com/viant/toolbox"
+	"os"
+	"time"
+)
+
+// replayedEvent represents event restored from JSONL event log
+type replayedEvent struct {
+	record   *workflow.EventRecord
+	value    interface{}
+	init     msg.Event
+	loggable bool
+}
+
+func (e *replayedEvent) Type() string {
+	return e.record.Type
+}
+
+func (e *replayedEvent) Package() string {
+	return e.record.Package
+}
+
+func (e *replayedEvent) Value() interface{} {
+	return e.value
+}
+
+func (e *replayedEvent) Timestamp() time.Time {
+	return e.record.Timestamp
+}
+
+func (e *replayedEvent) Init() msg.Event {
+	return e.init
+}
+
+func (e *replayedEvent) SetLoggable(loggable bool) {
+	e.loggable = loggable
+}
+
+func (e *replayedEvent) IsLoggable() bool {
+	return e.loggable
+}
+
+// replayedValue represents recorded event value with its recorded validations and messages
+type replayedValue struct {
+	record *workflow.EventRecord
+}
+
+func (v *replayedValue) Messages() []*msg.Message {
+	return v.record.Messages
+}
+
+// MarshalJSON returns recorded value JSON, used to match failed validation with runner output
+func (v *replayedValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.record.Value)
+}
+
+type replayedInput struct {
+	*replayedValue
+}
+
+func (v *replayedInput) IsInput() bool {
+	return true
+}
+
+type replayedOutput struct {
+	*replayedValue
+}
+
+func (v *replayedOutput) IsOutput() bool {
+	return true
+}
+
+type replayedAssertion struct {
+	*replayedValue
+}
+
+func (v *replayedAssertion) Assertion() []*assertly.Validation {
+	return v.record.Validations
+}
+
+type replayedRepeated struct {
+	*replayedValue
+}
+
+func (v *replayedRepeated) Message(repeated *msg.Repeated) *msg.Message {
+	return v.record.Repeated
+}
+
+// newReplayedValue restores event value, events inspected by runner are restored to their original types
+func newReplayedValue(record *workflow.EventRecord) (interface{}, error) {
+	var target interface{}
+	switch record.Type {
+	case "model_Activity":
+		target = &model.Activity{}
+	case "model_ActivityEndEvent":
+		activity := &model.Activity{}
+		if record.Value == nil {
+			return model.NewActivityEndEvent(activity), nil
+		}
+		if err := toolbox.DefaultConverter.AssignConverted(activity, toolbox.AsMap(record.Value)["Response"]); err != nil {
+			return nil, err
+		}
+		return model.NewActivityEndEvent(activity), nil
+	case "msg_ErrorEvent":
+		target = &msg.ErrorEvent{}
+	case "msg_ResetError":
+		return &msg.ResetError{}, nil
+	case "assertly_Validation":
+		if len(record.Validations) > 0 {
+			return record.Validations[0], nil
+		}
+		target = &assertly.Validation{}
+	}
+	if target != nil {
+		if record.Value == nil {
+			return target, nil
+		}
+		if err := toolbox.DefaultConverter.AssignConverted(target, record.Value); err != nil {
+			return nil, err
+		}
+		return target, nil
+	}
+	value := &replayedValue{record: record}
+	switch {
+	case len(record.Validations) > 0:
+		for _, validation := range record.Validations {
+			if validation.TagID == "" { //runner uses current process activity tag, not available in replay
+				validation.TagID = record.TagID
+			}
+		}
+		return &replayedAssertion{replayedValue: value}, nil
+	case record.Repeated != nil:
+		return &replayedRepeated{replayedValue: value}, nil
+	case record.Input:
+		return &replayedInput{replayedValue: value}, nil
+	case record.Output:
+		return &replayedOutput{replayedValue: value}, nil
+	}
+	return value, nil
+}
+
+// Replay re-renders CLI output, tag summary and reports from supplied JSONL event log without running workflow
+func (r *Runner) Replay(URL string, request *workflow.RunRequest) (err error) {
+	file, err := os.Open(URL)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+	records, err := workflow.ReadEventLog(file)
+	if err != nil {
+		return err
+	}
+	r.request = request
+	r.context = r.manager.NewContext(toolbox.NewContext())
+	defer r.context.Close()
+	r.report = &ReportSummaryEvent{}
+	r.filter = request.EventFilter
+	if len(r.filter) == 0 {
+		r.filter = DefaultFilter()
+	}
+	listener := r.AsListener()
+	var events = make(map[int]msg.Event)
+	for _, record := range records {
+		value, err := newReplayedValue(record)
+		if err != nil {
+			return fmt.Errorf("failed to restore event %v %v: %w", record.Seq, record.Type, err)
+		}
+		if activity, ok := value.(*model.Activity); ok && request.Name == "" {
+			request.Name = activity.Caller
+		}
+		event := &replayedEvent{record: record, value: value, loggable: record.Loggable, init: events[record.InitSeq]}
+		events[record.Seq] = event
+		listener(event)
+	}
+	err = r.onCallerEnd()
+	if r.hasValidationFailures || r.err != nil || err != nil {
+		OnError(1)
+	}
+	return err
+}
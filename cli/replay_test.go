@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"github.com/viant/endly/cli/xunit"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/endly/service/workflow"
+)
+
+type replayAssertion struct {
+	Validation *assertly.Validation
+}
+
+func (r *replayAssertion) Assertion() []*assertly.Validation {
+	return []*assertly.Validation{r.Validation}
+}
+
+func TestRunner_Replay(t *testing.T) {
+	directory := t.TempDir()
+	eventLogURL := path.Join(directory, "events.jsonl")
+	writeReplayEventLog(t, eventLogURL)
+
+	var exitCode = 0
+	onError := OnError
+	OnError = func(code int) { exitCode = code }
+	defer func() { OnError = onError }()
+
+	runner := New()
+	runner.Renderer = NewRenderer(new(bytes.Buffer), 120)
+	reportDirectory := path.Join(directory, "reports")
+	err := runner.Replay(eventLogURL, &workflow.RunRequest{SummaryFormat: "junit,html", SummaryDirectory: reportDirectory})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, 1, exitCode, "validation failure exit code")
+	assert.EqualValues(t, 1, runner.report.TotalTagPassed)
+	assert.EqualValues(t, 1, runner.report.TotalTagFailed, "validation after nested activity end is reported with outer use case")
+	for _, useCase := range runner.runReport.UseCases {
+		assert.True(t, useCase.Elapsed >= 0 && useCase.Elapsed < time.Second, "recorded use case elapsed: %v", useCase.Elapsed)
+	}
+
+	data, err := os.ReadFile(path.Join(reportDirectory, "junit.xml"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	suites := &xunit.JUnitTestsuites{}
+	if !assert.Nil(t, xml.Unmarshal(data, suites)) || !assert.Len(t, suites.Testsuite, 1) {
+		return
+	}
+	suite := suites.Testsuite[0]
+	assert.EqualValues(t, "regression", suite.Name)
+	assert.EqualValues(t, 2, suite.Tests)
+	assert.EqualValues(t, 1, suite.Failures)
+	var failed = map[string]bool{}
+	for _, testcase := range suite.Testcase {
+		failed[testcase.Name] = testcase.Failure != nil
+	}
+	assert.EqualValues(t, map[string]bool{"case1": false, "case2": true}, failed)
+
+	html, err := os.ReadFile(path.Join(reportDirectory, "report.html"))
+	if assert.Nil(t, err) {
+		assert.Contains(t, string(html), "case2")
+		assert.Contains(t, string(html), "/status")
+	}
+
+	invalid := path.Join(directory, "file")
+	assert.Nil(t, os.WriteFile(invalid, []byte{}, 0644))
+	runner = New()
+	runner.Renderer = NewRenderer(new(bytes.Buffer), 120)
+	err = runner.Replay(eventLogURL, &workflow.RunRequest{SummaryFormat: "junit", SummaryDirectory: invalid})
+	assert.NotNil(t, err, "summary write error")
+}
+
+// writeReplayEventLog writes event log of passed case1 and case2 failed after its nested activity completed
+func writeReplayEventLog(t *testing.T, URL string) {
+	file, err := os.Create(URL)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer file.Close()
+	listener := workflow.NewEventLog(file, nil).AsEventListener()
+
+	case1 := msg.NewEvent(&model.Activity{MetaTag: &model.MetaTag{TagID: "case1"}, Caller: "regression", Task: "test", Service: "validator", Action: "assert"})
+	listener(case1)
+	listener(msg.NewEvent(&replayAssertion{Validation: &assertly.Validation{PassedCount: 1}}))
+	listener(msg.NewEventWithInit(model.NewActivityEndEvent(&model.Activity{Service: "validator", Action: "assert"}), case1))
+
+	case2 := msg.NewEvent(&model.Activity{MetaTag: &model.MetaTag{TagID: "case2"}, Caller: "regression", Task: "test", Service: "workflow", Action: "run"})
+	nested := msg.NewEvent(&model.Activity{MetaTag: &model.MetaTag{TagID: "build"}, Caller: "app", Task: "build", Service: "exec", Action: "run"})
+	listener(case2)
+	listener(nested)
+	listener(msg.NewEventWithInit(model.NewActivityEndEvent(&model.Activity{Service: "exec", Action: "run"}), nested))
+	listener(msg.NewEvent(&replayAssertion{Validation: &assertly.Validation{PassedCount: 1, FailedCount: 1, Failures: []*assertly.Failure{{Path: "/status", Expected: "ok", Actual: "error"}}}}))
+	listener(msg.NewEventWithInit(model.NewActivityEndEvent(&model.Activity{Service: "workflow", Action: "run"}), case2))
+}
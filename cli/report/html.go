@@ -0,0 +1,58 @@
+package report
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+)
+
+//go:embed report.html
+var htmlReport string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"json": asJSON,
+	"diff": diff,
+	"add": func(x, y int) int {
+		return x + y
+	},
+}).Parse(htmlReport))
+
+// HTML writes self contained HTML report
+func (r *Report) HTML(writer io.Writer) error {
+	return htmlTemplate.Execute(writer, r)
+}
+
+func asJSON(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+// diff returns expected and actual values with differing fragment highlighted
+func diff(expected, actual string) []template.HTML {
+	expectedRunes, actualRunes := []rune(expected), []rune(actual)
+	prefix := 0
+	for prefix < len(expectedRunes) && prefix < len(actualRunes) && expectedRunes[prefix] == actualRunes[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(expectedRunes)-prefix && suffix < len(actualRunes)-prefix && expectedRunes[len(expectedRunes)-1-suffix] == actualRunes[len(actualRunes)-1-suffix] {
+		suffix++
+	}
+	highlight := func(value []rune, tag string) template.HTML {
+		middle := html.EscapeString(string(value[prefix : len(value)-suffix]))
+		if middle != "" {
+			middle = "<" + tag + ">" + middle + "</" + tag + ">"
+		}
+		return template.HTML(html.EscapeString(string(value[:prefix])) + middle + html.EscapeString(string(value[len(value)-suffix:])))
+	}
+	return []template.HTML{highlight(expectedRunes, "del"), highlight(actualRunes, "ins")}
+}
@@ -0,0 +1,146 @@
+package report
+
+import (
+	"fmt"
+	"github.com/viant/assertly"
+	"github.com/viant/endly/cli/xunit"
+	"strings"
+	"time"
+)
+
+// Report represents workflow run report
+type Report struct {
+	Name     string
+	Started  time.Time
+	Elapsed  time.Duration
+	Error    string
+	UseCases []*UseCase
+}
+
+// UseCase represents use case (TagID) report
+type UseCase struct {
+	TagID       string
+	Description string
+	Passed      int
+	Failed      int
+	Started     time.Time
+	Elapsed     time.Duration
+	Actions     []*Action
+	Failures    []*Failure
+}
+
+// Action represents service action executed within a use case
+type Action struct {
+	Service     string
+	Action      string
+	Description string
+	Request     interface{}
+	Response    interface{}
+	Error       string
+	Elapsed     time.Duration
+}
+
+// Failure represents validation failure
+type Failure struct {
+	Path     string
+	Expected string
+	Actual   string
+	Reason   string
+	Message  string
+}
+
+// Passed returns number of passed use cases
+func (r *Report) Passed() int {
+	var result = 0
+	for _, useCase := range r.UseCases {
+		if useCase.Failed == 0 {
+			result++
+		}
+	}
+	return result
+}
+
+// Failed returns number of failed use cases
+func (r *Report) Failed() int {
+	return len(r.UseCases) - r.Passed()
+}
+
+// AddUseCase adds use case
+func (r *Report) AddUseCase(useCase *UseCase) {
+	r.UseCases = append(r.UseCases, useCase)
+}
+
+// AddValidation adds validation failures
+func (u *UseCase) AddValidation(validation *assertly.Validation) {
+	for _, failure := range validation.Failures {
+		u.Failures = append(u.Failures, &Failure{
+			Path:     failure.Path,
+			Expected: fmt.Sprintf("%v", failure.Expected),
+			Actual:   fmt.Sprintf("%v", failure.Actual),
+			Reason:   failure.Reason,
+			Message:  failure.Message,
+		})
+	}
+}
+
+// FailureDetail returns failures as text, one failure per line
+func (u *UseCase) FailureDetail() string {
+	var lines = make([]string, 0, len(u.Failures))
+	for _, failure := range u.Failures {
+		lines = append(lines, fmt.Sprintf("%v: expected: %v, actual: %v, %v: %v", failure.Path, failure.Expected, failure.Actual, failure.Reason, failure.Message))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JUnit returns JUnit report with a test case per use case
+func (r *Report) JUnit() *xunit.JUnitTestsuites {
+	suite := &xunit.JUnitTestsuite{
+		Name:     r.Name,
+		Tests:    len(r.UseCases),
+		Failures: r.Failed(),
+		Time:     r.Elapsed.Seconds(),
+		Testcase: make([]*xunit.JUnitTestcase, 0, len(r.UseCases)),
+	}
+	if !r.Started.IsZero() {
+		suite.Timestamp = r.Started.Format("2006-01-02T15:04:05")
+	}
+	for _, useCase := range r.UseCases {
+		testcase := &xunit.JUnitTestcase{
+			Name:      useCase.TagID,
+			Classname: r.Name,
+			Time:      useCase.Elapsed.Seconds(),
+		}
+		if useCase.Failed > 0 {
+			testcase.Failure = &xunit.JUnitFailure{
+				Message: fmt.Sprintf("failed %v/%v", useCase.Failed, useCase.Passed+useCase.Failed),
+				Type:    "validation",
+				Value:   useCase.FailureDetail(),
+			}
+		}
+		testcase.SystemOut = useCase.Description
+		suite.Testcase = append(suite.Testcase, testcase)
+	}
+	if r.Error != "" {
+		suite.Tests++
+		suite.Errors++
+		suite.Testcase = append(suite.Testcase, &xunit.JUnitTestcase{
+			Name:      r.Name,
+			Classname: r.Name,
+			Time:      r.Elapsed.Seconds(),
+			Error:     &xunit.JUnitFailure{Message: r.Error, Type: "error"},
+		})
+	}
+	return &xunit.JUnitTestsuites{
+		Name:      r.Name,
+		Tests:     suite.Tests,
+		Failures:  suite.Failures,
+		Errors:    suite.Errors,
+		Time:      suite.Time,
+		Testsuite: []*xunit.JUnitTestsuite{suite},
+	}
+}
+
+// New creates a report
+func New(name string) *Report {
+	return &Report{Name: name, UseCases: make([]*UseCase, 0)}
+}
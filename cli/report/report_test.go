@@ -0,0 +1,48 @@
+package report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReport_JUnit(t *testing.T) {
+	report := New("app")
+	report.Elapsed = 2 * time.Second
+	report.AddUseCase(&UseCase{TagID: "case1", Passed: 2})
+	failed := &UseCase{TagID: "case2", Passed: 1, Failed: 1}
+	failed.AddValidation(&assertly.Validation{Failures: []*assertly.Failure{{Path: "/status", Expected: "ok", Actual: "error", Reason: "equal"}}})
+	report.AddUseCase(failed)
+	report.Error = "failed to run"
+
+	buf := new(bytes.Buffer)
+	err := xml.NewEncoder(buf).Encode(report.JUnit())
+	if !assert.Nil(t, err) {
+		return
+	}
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, `<testsuites name="app" tests="3" failures="1" errors="1" time="2">`), output)
+	assert.Contains(t, output, `<testcase name="case1" classname="app" time="0"></testcase>`)
+	assert.Contains(t, output, `<failure message="failed 1/2" type="validation">/status: expected: ok, actual: error, equal: </failure>`)
+	assert.Contains(t, output, `<error message="failed to run" type="error"></error>`)
+}
+
+func TestReport_HTML(t *testing.T) {
+	report := New("app")
+	useCase := &UseCase{TagID: "case1", Failed: 1, Actions: []*Action{{Service: "http/runner", Action: "send", Request: map[string]interface{}{"URL": "http://localhost/"}}}}
+	useCase.Failures = []*Failure{{Path: "/body", Expected: "<ok>", Actual: "<error>"}}
+	report.AddUseCase(useCase)
+	buf := new(bytes.Buffer)
+	if !assert.Nil(t, report.HTML(buf)) {
+		return
+	}
+	output := buf.String()
+	assert.Contains(t, output, "http/runner:send")
+	assert.Contains(t, output, "&lt;<del>ok</del>&gt;")
+	assert.Contains(t, output, "&lt;<ins>error</ins>&gt;")
+	assert.Contains(t, output, "&#34;URL&#34;: &#34;http://localhost/&#34;")
+}
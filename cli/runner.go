@@ -8,6 +8,7 @@ import (
 	"github.com/lunixbochs/vtclean"
 	"github.com/viant/assertly"
 	"github.com/viant/endly"
+	"github.com/viant/endly/cli/report"
 	"github.com/viant/endly/cli/xunit"
 	"github.com/viant/endly/model"
 	"github.com/viant/endly/model/msg"
@@ -18,7 +19,6 @@ import (
 	"github.com/viant/toolbox/data"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
-	"log"
 	"os"
 	"path"
 	"strings"
@@ -35,6 +35,11 @@ const (
 	messageTypeTagDescription
 )
 
+// defaultSummaryFormat represents summary format used in place of unsupported one
+const defaultSummaryFormat = "xml"
+
+var supportedSummaryFormats = map[string]bool{"xml": true, "yaml": true, "json": true, "junit": true, "html": true}
+
 // ReportSummaryEvent represents event xUnitSummary
 type ReportSummaryEvent struct {
 	ElapsedMs      int
@@ -50,6 +55,7 @@ type Runner struct {
 	*Events
 	request               *workflow.RunRequest
 	xUnitSummary          *xunit.Testsuite
+	runReport             *report.Report
 	context               *endly.Context
 	filter                map[string]bool
 	manager               endly.Manager
@@ -511,6 +517,8 @@ func (r *Runner) reportTagSummary() {
 		}
 		useCase := xunit.NewTestCase()
 		r.xUnitSummary.TestCase = append(r.xUnitSummary.TestCase, useCase)
+		reportUseCase := newReportUseCase(tag)
+		r.runReport.AddUseCase(reportUseCase)
 		useCase.Label = tag.TagID
 		description := strings.Split(tag.Description, "\n")[0]
 		if description == "" {
@@ -542,6 +550,7 @@ func (r *Runner) reportTagSummary() {
 						failureLog = runnerLog
 					}
 					offset = i + 1
+					reportUseCase.AddValidation(validation)
 					nodes := xunit.NewNodes()
 					useCase.Nodes = nodes
 					nodes.Expected = "/"
@@ -584,6 +593,60 @@ func (r *Runner) reportTagSummary() {
 			_, r.xUnitSummary.Name = path.Split(workflowPath)
 		}
 	}
+	r.runReport.Name = r.xUnitSummary.Name
+	if r.runReport.Name == "" && r.request != nil {
+		r.runReport.Name = r.request.Name
+	}
+	r.runReport.Elapsed = time.Duration(r.report.ElapsedMs) * time.Millisecond
+	r.runReport.Error = r.xUnitSummary.ErrorsDetail
+}
+
+// newReportUseCase creates use case report with executed service actions
+func newReportUseCase(tag *Event) *report.UseCase {
+	description := strings.Split(tag.Description, "\n")[0]
+	var result = &report.UseCase{
+		TagID:       tag.TagID,
+		Description: description,
+		Passed:      tag.PassedCount,
+		Failed:      tag.FailedCount,
+		Actions:     make([]*report.Action, 0),
+	}
+	var ended time.Time
+	for _, event := range tag.Events {
+		if _, ok := event.Value().(*assertly.Validation); ok { //validation event is re-created by runner, i.e. at replay time
+			continue
+		}
+		if result.Started.IsZero() || event.Timestamp().Before(result.Started) {
+			result.Started = event.Timestamp()
+		}
+		if event.Timestamp().After(ended) {
+			ended = event.Timestamp()
+		}
+	}
+	result.Elapsed = ended.Sub(result.Started)
+	for _, event := range tag.Events {
+		endEvent, ok := event.Value().(*model.ActivityEndEvent)
+		if !ok {
+			continue
+		}
+		activity, ok := endEvent.Response.(*model.Activity)
+		if !ok {
+			continue
+		}
+		action := &report.Action{
+			Service:     activity.Service,
+			Action:      activity.Action,
+			Description: activity.Description,
+			Request:     activity.Request,
+			Response:    activity.Response,
+			Error:       activity.Error,
+		}
+		if startEvent := event.Init(); startEvent != nil {
+			action.Elapsed = event.Timestamp().Sub(startEvent.Timestamp())
+		}
+		result.Actions = append(result.Actions, action)
+	}
+	return result
 }
 
 func (r *Runner) reportEvent(context *endly.Context, event msg.Event, filter map[string]bool) error {
@@ -598,6 +661,7 @@ func (r *Runner) AsListener() msg.Listener {
 	return func(event msg.Event) {
 		if firstEvent == nil {
 			firstEvent = event
+			r.runReport.Started = event.Timestamp()
 		} else {
 			lastEvent = event
 			r.report.ElapsedMs = int(lastEvent.Timestamp().UnixNano()-firstEvent.Timestamp().UnixNano()) / int(time.Millisecond)
@@ -628,20 +692,61 @@ func (r *Runner) onCallerStart() {
 	}
 }
 
-func (r *Runner) onCallerEnd() {
+func (r *Runner) onCallerEnd() error {
 	r.processEventTags()
 	r.reportSummaryEvent()
-	r.printSummary()
+	return r.printSummary()
 }
 
-func (r *Runner) printSummary() {
-
+// printSummary writes summary and report files for each comma separated summary format
+func (r *Runner) printSummary() error {
 	if r.request == nil || r.request.SummaryFormat == "" {
-		return
+		return nil
 	}
+	directory := r.request.SummaryDirectory
+	if directory != "" {
+		if err := os.MkdirAll(directory, 0755); err != nil {
+			return fmt.Errorf("failed to create summary directory %v, %w", directory, err)
+		}
+	}
+	for _, format := range r.summaryFormats() {
+		buf := new(bytes.Buffer)
+		filename, err := r.encodeSummary(format, buf)
+		if err == nil {
+			err = ioutil.WriteFile(path.Join(directory, filename), buf.Bytes(), 0644)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write %v summary, %w", format, err)
+		}
+	}
+	return nil
+}
+
+// summaryFormats returns requested summary formats, unsupported format falls back to the default one
+func (r *Runner) summaryFormats() []string {
+	var result = make([]string, 0)
+	var unique = make(map[string]bool)
+	for _, format := range strings.Split(r.request.SummaryFormat, ",") {
+		format = strings.TrimSpace(format)
+		if format == "" {
+			continue
+		}
+		if !supportedSummaryFormats[format] {
+			r.printError(fmt.Sprintf("unsupported summary format: %v, using %v", format, defaultSummaryFormat))
+			format = defaultSummaryFormat
+		}
+		if unique[format] {
+			continue
+		}
+		unique[format] = true
+		result = append(result, format)
+	}
+	return result
+}
+
+func (r *Runner) encodeSummary(format string, buf *bytes.Buffer) (string, error) {
 	var err error
-	buf := new(bytes.Buffer)
-	switch r.request.SummaryFormat {
+	switch format {
 	case "xml":
 		encoder := xml.NewEncoder(buf)
 		encoder.Indent("  ", "    ")
@@ -652,14 +757,17 @@ func (r *Runner) printSummary() {
 		encoder := json.NewEncoder(buf)
 		encoder.SetIndent("  ", "    ")
 		err = encoder.Encode(r.xUnitSummary)
+	case "junit":
+		buf.WriteString(xml.Header)
+		encoder := xml.NewEncoder(buf)
+		encoder.Indent("", "  ")
+		return "junit.xml", encoder.Encode(r.runReport.JUnit())
+	case "html":
+		return "report.html", r.runReport.HTML(buf)
+	default:
+		return "", fmt.Errorf("unsupported summary format: %v", format)
 	}
-	if err == nil {
-		err = ioutil.WriteFile(fmt.Sprintf("summary.%v", r.request.SummaryFormat), buf.Bytes(), 0644)
-	}
-	if err != nil {
-		log.Fatal(err)
-	}
-
+	return fmt.Sprintf("summary.%v", format), err
 }
 
 // Run run Caller for the supplied run request and runner options.
@@ -677,10 +785,13 @@ func (r *Runner) Run(request *workflow.RunRequest) (err error) {
 		r.filter = DefaultFilter()
 	}
 	defer func() {
-		r.onCallerEnd()
+		summaryErr := r.onCallerEnd()
 		if r.err != nil {
 			err = r.err
 		}
+		if err == nil {
+			err = summaryErr
+		}
 		if !request.Interactive {
 			r.context.Close()
 		}
@@ -730,6 +841,7 @@ func New() *Runner {
 		Renderer:     NewRenderer(os.Stdout, 120),
 		group:        &MessageGroup{},
 		xUnitSummary: xunit.NewTestsuite(),
+		runReport:    report.New(""),
 		Style:        NewStyle(),
 	}
 }
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly/service/workflow"
+)
+
+func TestRunner_PrintSummary(t *testing.T) {
+	var useCases = []struct {
+		description string
+		format      string
+		expect      []string
+		warning     string
+	}{
+		{description: "requested formats", format: "json, junit", expect: []string{"summary.json", "junit.xml"}},
+		{description: "unsupported format fallback", format: "pdf,xml", expect: []string{"summary.xml"}, warning: "unsupported summary format: pdf"},
+	}
+	for _, useCase := range useCases {
+		directory := t.TempDir()
+		runner := New()
+		output := new(bytes.Buffer)
+		runner.Renderer = NewRenderer(output, 120)
+		runner.request = &workflow.RunRequest{SummaryFormat: useCase.format, SummaryDirectory: directory}
+		if !assert.Nil(t, runner.printSummary(), useCase.description) {
+			continue
+		}
+		entries, err := os.ReadDir(directory)
+		assert.Nil(t, err)
+		var actual = make([]string, 0)
+		for _, entry := range entries {
+			actual = append(actual, entry.Name())
+		}
+		assert.ElementsMatch(t, useCase.expect, actual, useCase.description)
+		if useCase.warning != "" {
+			assert.Contains(t, output.String(), useCase.warning, useCase.description)
+		}
+	}
+
+	runner := New()
+	runner.Renderer = NewRenderer(new(bytes.Buffer), 120)
+	directory := path.Join(t.TempDir(), "file")
+	assert.Nil(t, os.WriteFile(directory, []byte{}, 0644))
+	runner.request = &workflow.RunRequest{SummaryFormat: "xml", SummaryDirectory: directory}
+	assert.NotNil(t, runner.printSummary(), "invalid directory")
+}
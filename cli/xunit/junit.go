@@ -0,0 +1,44 @@
+package xunit
+
+import "encoding/xml"
+
+// JUnitTestsuites represents JUnit report root node
+type JUnitTestsuites struct {
+	XMLName   xml.Name          `xml:"testsuites" yaml:"-" json:"-"`
+	Name      string            `xml:"name,attr,omitempty" yaml:"name,omitempty" json:"name,omitempty"`
+	Tests     int               `xml:"tests,attr" yaml:"tests" json:"tests"`
+	Failures  int               `xml:"failures,attr" yaml:"failures" json:"failures"`
+	Errors    int               `xml:"errors,attr" yaml:"errors" json:"errors"`
+	Time      float64           `xml:"time,attr" yaml:"time" json:"time"`
+	Testsuite []*JUnitTestsuite `xml:"testsuite" yaml:"testsuite,omitempty" json:"testsuite,omitempty"`
+}
+
+// JUnitTestsuite represents JUnit test suite node
+type JUnitTestsuite struct {
+	Name      string           `xml:"name,attr" yaml:"name" json:"name"`
+	Tests     int              `xml:"tests,attr" yaml:"tests" json:"tests"`
+	Failures  int              `xml:"failures,attr" yaml:"failures" json:"failures"`
+	Errors    int              `xml:"errors,attr" yaml:"errors" json:"errors"`
+	Skipped   int              `xml:"skipped,attr" yaml:"skipped" json:"skipped"`
+	Time      float64          `xml:"time,attr" yaml:"time" json:"time"`
+	Timestamp string           `xml:"timestamp,attr,omitempty" yaml:"timestamp,omitempty" json:"timestamp,omitempty"`
+	Testcase  []*JUnitTestcase `xml:"testcase" yaml:"testcase,omitempty" json:"testcase,omitempty"`
+	SystemErr string           `xml:"system-err,omitempty" yaml:"system-err,omitempty" json:"system-err,omitempty"`
+}
+
+// JUnitTestcase represents JUnit test case node
+type JUnitTestcase struct {
+	Name      string        `xml:"name,attr" yaml:"name" json:"name"`
+	Classname string        `xml:"classname,attr" yaml:"classname" json:"classname"`
+	Time      float64       `xml:"time,attr" yaml:"time" json:"time"`
+	Failure   *JUnitFailure `xml:"failure,omitempty" yaml:"failure,omitempty" json:"failure,omitempty"`
+	Error     *JUnitFailure `xml:"error,omitempty" yaml:"error,omitempty" json:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty" yaml:"system-out,omitempty" json:"system-out,omitempty"`
+}
+
+// JUnitFailure represents JUnit failure or error node
+type JUnitFailure struct {
+	Message string `xml:"message,attr,omitempty" yaml:"message,omitempty" json:"message,omitempty"`
+	Type    string `xml:"type,attr,omitempty" yaml:"type,omitempty" json:"type,omitempty"`
+	Value   string `xml:",chardata" yaml:"value,omitempty" json:"value,omitempty"`
+}
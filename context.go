@@ -6,6 +6,7 @@ import (
 	uuid "github.com/satori/go.uuid"
 	"github.com/viant/afs/url"
 	"github.com/viant/endly/internal/debug"
+	"github.com/viant/endly/internal/tracing"
 	"github.com/viant/endly/model/location"
 	"github.com/viant/endly/model/msg"
 	"github.com/viant/scy/cred/secret"
@@ -13,6 +14,8 @@ import (
 	"github.com/viant/toolbox/data"
 	tudf "github.com/viant/toolbox/data/udf"
 	"github.com/viant/toolbox/storage"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"os"
 	"os/exec"
 	"path"
@@ -40,6 +43,7 @@ type Context struct {
 	Listener        msg.Listener
 	Source          *location.Resource
 	Debugger        *debug.Debugger
+	DryRun          bool
 
 	state   data.Map
 	udfs    data.Map
@@ -85,11 +89,52 @@ func (c *Context) SetListener(listener msg.Listener) {
 	c.Listener = listener
 }
 
-// IsClosed returns true if it is closed.
+// EnableTracing sets tracer provider used to start root span, spans are exported once context is closed
+func (c *Context) EnableTracing(provider *sdktrace.TracerProvider) {
+	c.context = tracing.WithProvider(c.Background(), provider)
+	c.Deffer(func() {
+		_ = provider.Shutdown(context.Background())
+	})
+}
+
+// StartSpan starts span as a child of the background context span, returned function ends the span and restores background context
+func (c *Context) StartSpan(name string, attributes ...attribute.KeyValue) func(err error) {
+	previous := c.Background()
+	ctx, span := tracing.Start(previous, name, attributes...)
+	if !span.IsRecording() {
+		return func(err error) {}
+	}
+	c.context = ctx
+	return func(err error) {
+		tracing.End(span, err)
+		c.context = previous
+	}
+}
+
+// IsClosed returns true if it is closed or its background context has been canceled.
 func (c *Context) IsClosed() bool {
+	if c.context != nil && c.context.Err() != nil {
+		return true
+	}
 	return atomic.LoadInt32(&c.closed) == 1
 }
 
+// WithCancel returns cloned context with cancelable background context
+func (c *Context) WithCancel() (*Context, context.CancelFunc) {
+	result := c.Clone()
+	var cancel context.CancelFunc
+	result.context, cancel = context.WithCancel(c.Background())
+	return result, cancel
+}
+
+// WithTimeout returns cloned context with background context canceled after supplied timeout
+func (c *Context) WithTimeout(timeout time.Duration) (*Context, context.CancelFunc) {
+	result := c.Clone()
+	var cancel context.CancelFunc
+	result.context, cancel = context.WithTimeout(c.Background(), timeout)
+	return result, cancel
+}
+
 // Clone clones the context.
 func (c *Context) Clone() *Context {
 	if len(c.cloned) == 0 {
@@ -98,12 +143,15 @@ func (c *Context) Clone() *Context {
 	result := &Context{}
 	result.Wait = &sync.WaitGroup{}
 	result.Context = c.Context.Clone()
+	result.context = c.context
 	result.state = NewDefaultState(c)
 	result.state.Apply(c.state)
 	result.SessionID = c.SessionID
 	result.Listener = c.Listener
 	result.CLIEnabled = c.CLIEnabled
 	result.Secrets = c.Secrets
+	result.Debugger = c.Debugger
+	result.DryRun = c.DryRun
 	result.AsyncUnsafeKeys = make(map[interface{}]bool)
 	for k, v := range c.AsyncUnsafeKeys {
 		result.AsyncUnsafeKeys[k] = v
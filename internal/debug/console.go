@@ -0,0 +1,186 @@
+package debug
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"io"
+	"sort"
+	"strings"
+)
+
+const consoleHelp = `debugger commands:
+	c, continue          resume execution till next breakpoint
+	n, next              step over to the next node at the same level
+	s, step              step into the next node
+	o, out               step out to the parent level
+	p, print [expr]      print state keys, state key value or $expression
+	set key = value      set state key with JSON or text value
+	e, eval criteria     evaluate criteria expression with current state
+	r, request           print current node request
+	bt, frames           print execution frames
+	b, break step        add workflow/task/tagID breakpoint
+	d, delete step       remove workflow/task/tagID breakpoint
+	bl, breakpoints      list breakpoints
+	q, quit              terminate execution
+`
+
+// Console represents interactive terminal debugging session
+type Console struct {
+	debugger *Debugger
+	reader   *bufio.Reader
+	writer   io.Writer
+}
+
+// Paused handles user commands till resume command is entered
+func (c *Console) Paused(frame *Frame) (Command, error) {
+	c.printf("paused at %v %v\n", frame.Kind, frame.Step)
+	for {
+		c.printf("debug> ")
+		line, err := c.reader.ReadString('\n')
+		if err != nil && line == "" {
+			if err == io.EOF {
+				return CommandContinue, nil
+			}
+			return CommandContinue, err
+		}
+		command, args := splitCommand(line)
+		switch command {
+		case "":
+			continue
+		case "c", "continue":
+			return CommandContinue, nil
+		case "n", "next":
+			return CommandStepOver, nil
+		case "s", "step":
+			return CommandStepInto, nil
+		case "o", "out":
+			return CommandStepOut, nil
+		case "q", "quit":
+			return CommandTerminate, nil
+		case "p", "print":
+			c.print(frame, args)
+		case "set":
+			c.set(frame, args)
+		case "e", "eval":
+			c.eval(frame, args)
+		case "r", "request":
+			c.printValue(frame.Request)
+		case "bt", "frames":
+			for _, candidate := range c.debugger.Frames() {
+				c.printf("\t%v %v\n", candidate.Kind, candidate.Step)
+			}
+		case "b", "break":
+			c.debugger.SetBreakpoint(ParseStep(args))
+		case "d", "delete":
+			c.debugger.RemoveBreakpoint(ParseStep(args))
+		case "bl", "breakpoints":
+			c.listBreakpoints()
+		default:
+			c.printf(consoleHelp)
+		}
+	}
+}
+
+func (c *Console) print(frame *Frame, expression string) {
+	if expression == "" {
+		c.printf("%v\n", strings.Join(stateKeys(frame.State), ", "))
+		return
+	}
+	if strings.Contains(expression, "$") {
+		c.printValue(frame.State.Expand(expression))
+		return
+	}
+	value, has := frame.State.GetValue(expression)
+	if !has {
+		c.printf("undefined: %v\n", expression)
+		return
+	}
+	c.printValue(value)
+}
+
+func (c *Console) set(frame *Frame, assignment string) {
+	index := strings.Index(assignment, "=")
+	if index == -1 {
+		c.printf("invalid assignment: %v, expected key = value\n", assignment)
+		return
+	}
+	key := strings.TrimSpace(assignment[:index])
+	text := strings.TrimSpace(assignment[index+1:])
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		value = frame.State.ExpandAsText(text)
+	}
+	frame.State.SetValue(key, value)
+}
+
+func (c *Console) eval(frame *Frame, criteria string) {
+	if frame.Evaluate == nil {
+		c.printf("criteria evaluation is not supported\n")
+		return
+	}
+	result, err := frame.Evaluate(criteria)
+	if err != nil {
+		c.printf("failed to evaluate: %v, %v\n", criteria, err)
+		return
+	}
+	c.printf("%v\n", result)
+}
+
+func (c *Console) listBreakpoints() {
+	c.debugger.mux.Lock()
+	var breakpoints = make([]string, 0, len(c.debugger.Breakpoints))
+	for breakpoint := range c.debugger.Breakpoints {
+		breakpoints = append(breakpoints, breakpoint.String())
+	}
+	c.debugger.mux.Unlock()
+	sort.Strings(breakpoints)
+	for _, breakpoint := range breakpoints {
+		c.printf("\t%v\n", breakpoint)
+	}
+}
+
+func (c *Console) printValue(value interface{}) {
+	if toolbox.IsMap(value) || toolbox.IsSlice(value) || toolbox.IsStruct(value) {
+		if text, err := toolbox.AsIndentJSONText(value); err == nil {
+			c.printf("%v\n", text)
+			return
+		}
+	}
+	c.printf("%v\n", value)
+}
+
+func (c *Console) printf(template string, args ...interface{}) {
+	_, _ = fmt.Fprintf(c.writer, template, args...)
+}
+
+func splitCommand(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if index := strings.Index(line, " "); index != -1 {
+		return line[:index], strings.TrimSpace(line[index+1:])
+	}
+	return line, ""
+}
+
+func stateKeys(state data.Map) []string {
+	var result = make([]string, 0, len(state))
+	for k, v := range state {
+		if k == data.UDFKey || toolbox.IsFunc(v) {
+			continue
+		}
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// NewConsole creates a new console debugging session
+func NewConsole(debugger *Debugger, reader io.Reader, writer io.Writer) *Console {
+	return &Console{
+		debugger: debugger,
+		reader:   bufio.NewReader(reader),
+		writer:   writer,
+	}
+}
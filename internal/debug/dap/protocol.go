@@ -0,0 +1,153 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const contentLengthHeader = "Content-Length:"
+
+type (
+	//Request represents debug adapter protocol request
+	Request struct {
+		Seq       int             `json:"seq"`
+		Type      string          `json:"type"`
+		Command   string          `json:"command"`
+		Arguments json.RawMessage `json:"arguments,omitempty"`
+	}
+
+	//Response represents debug adapter protocol response
+	Response struct {
+		Seq        int         `json:"seq"`
+		Type       string      `json:"type"`
+		RequestSeq int         `json:"request_seq"`
+		Command    string      `json:"command"`
+		Success    bool        `json:"success"`
+		Message    string      `json:"message,omitempty"`
+		Body       interface{} `json:"body,omitempty"`
+	}
+
+	//Event represents debug adapter protocol event
+	Event struct {
+		Seq   int         `json:"seq"`
+		Type  string      `json:"type"`
+		Event string      `json:"event"`
+		Body  interface{} `json:"body,omitempty"`
+	}
+
+	//Source represents source reference
+	Source struct {
+		Name string `json:"name,omitempty"`
+		Path string `json:"path,omitempty"`
+	}
+
+	//Breakpoint represents source breakpoint
+	Breakpoint struct {
+		Verified bool   `json:"verified"`
+		Line     int    `json:"line,omitempty"`
+		Message  string `json:"message,omitempty"`
+	}
+
+	//StackFrame represents stack frame
+	StackFrame struct {
+		ID     int     `json:"id"`
+		Name   string  `json:"name"`
+		Source *Source `json:"source,omitempty"`
+		Line   int     `json:"line"`
+		Column int     `json:"column"`
+	}
+
+	//Scope represents variables scope
+	Scope struct {
+		Name               string `json:"name"`
+		VariablesReference int    `json:"variablesReference"`
+		Expensive          bool   `json:"expensive"`
+	}
+
+	//Variable represents variable
+	Variable struct {
+		Name               string `json:"name"`
+		Value              string `json:"value"`
+		Type               string `json:"type,omitempty"`
+		VariablesReference int    `json:"variablesReference"`
+	}
+
+	//Thread represents thread
+	Thread struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	setBreakpointsArguments struct {
+		Source      Source `json:"source"`
+		Breakpoints []struct {
+			Line int `json:"line"`
+		} `json:"breakpoints"`
+	}
+
+	stackTraceArguments struct {
+		ThreadID int `json:"threadId"`
+	}
+
+	scopesArguments struct {
+		FrameID int `json:"frameId"`
+	}
+
+	variablesArguments struct {
+		VariablesReference int `json:"variablesReference"`
+	}
+
+	setVariableArguments struct {
+		VariablesReference int    `json:"variablesReference"`
+		Name               string `json:"name"`
+		Value              string `json:"value"`
+	}
+
+	evaluateArguments struct {
+		Expression string `json:"expression"`
+		FrameID    int    `json:"frameId"`
+	}
+)
+
+// readMessage reads Content-Length framed message
+func readMessage(reader *bufio.Reader) ([]byte, error) {
+	contentLength := -1
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if contentLength == -1 {
+				continue
+			}
+			break
+		}
+		if strings.HasPrefix(line, contentLengthHeader) {
+			if contentLength, err = strconv.Atoi(strings.TrimSpace(line[len(contentLengthHeader):])); err != nil {
+				return nil, fmt.Errorf("invalid header: %v, %w", line, err)
+			}
+		}
+	}
+	data := make([]byte, contentLength)
+	_, err := io.ReadFull(reader, data)
+	return data, err
+}
+
+// writeMessage writes Content-Length framed message
+func writeMessage(writer io.Writer, message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(writer, "%v %d\r\n\r\n", contentLengthHeader, len(data)); err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
@@ -0,0 +1,393 @@
+package dap
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+	"github.com/viant/endly/internal/debug"
+	"github.com/viant/endly/model/graph"
+	"log"
+	"net"
+	"path"
+	"strings"
+	"sync"
+)
+
+const threadID = 1
+
+// Server represents debug adapter protocol server, it implements debug.Session
+type Server struct {
+	address     string
+	debugger    *debug.Debugger
+	listener    net.Listener
+	conn        net.Conn
+	mux         sync.Mutex
+	writeMux    sync.Mutex
+	seq         int
+	configured  chan bool
+	configOnce  sync.Once
+	commands    chan debug.Command
+	paused      *debug.Frame
+	closed      bool
+	variables   *variables
+	breakpoints map[string][]debug.Step
+	sources     map[string]*source
+}
+
+type source struct {
+	path     string
+	workflow *graph.Node
+}
+
+// Start starts listening and waits for a client to complete configuration
+func (s *Server) Start() (err error) {
+	if s.listener, err = net.Listen("tcp", s.address); err != nil {
+		return err
+	}
+	log.Printf("waiting for debug adapter client on %v ...\n", s.listener.Addr())
+	if s.conn, err = s.listener.Accept(); err != nil {
+		return err
+	}
+	go s.serve(bufio.NewReader(s.conn))
+	<-s.configured
+	return nil
+}
+
+// Addr returns listening address
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Paused notifies client with stopped event and waits for resume command
+func (s *Server) Paused(frame *debug.Frame) (debug.Command, error) {
+	s.mux.Lock()
+	if s.closed {
+		s.mux.Unlock()
+		return debug.CommandTerminate, nil
+	}
+	s.paused = frame
+	s.variables = newVariables()
+	s.mux.Unlock()
+	reason := "step"
+	if s.debugger.HasBreakpoint(frame.Step) {
+		reason = "breakpoint"
+	}
+	s.sendEvent("stopped", map[string]interface{}{
+		"reason":            reason,
+		"description":       fmt.Sprintf("%v %v", frame.Kind, frame.Step),
+		"threadId":          threadID,
+		"allThreadsStopped": true,
+	})
+	command := <-s.commands
+	s.mux.Lock()
+	s.paused = nil
+	s.mux.Unlock()
+	return command, nil
+}
+
+// Close notifies client about termination and closes connection
+func (s *Server) Close() error {
+	s.mux.Lock()
+	s.closed = true
+	s.mux.Unlock()
+	s.sendEvent("terminated", nil)
+	s.sendEvent("exited", map[string]interface{}{"exitCode": 0})
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	if s.listener != nil {
+		return s.listener.Close()
+	}
+	return nil
+}
+
+func (s *Server) serve(reader *bufio.Reader) {
+	defer s.disconnect()
+	for {
+		data, err := readMessage(reader)
+		if err != nil {
+			return
+		}
+		request := &Request{}
+		if err = json.Unmarshal(data, request); err != nil {
+			log.Printf("invalid debug adapter request: %s, %v\n", data, err)
+			continue
+		}
+		body, err := s.handle(request)
+		response := &Response{Type: "response", RequestSeq: request.Seq, Command: request.Command, Success: err == nil, Body: body}
+		if err != nil {
+			response.Message = err.Error()
+		}
+		s.send(response)
+		switch request.Command {
+		case "initialize":
+			s.sendEvent("initialized", nil)
+		case "configurationDone":
+			s.configOnce.Do(func() { close(s.configured) })
+		case "disconnect", "terminate":
+			return
+		}
+	}
+}
+
+func (s *Server) handle(request *Request) (interface{}, error) {
+	switch request.Command {
+	case "initialize":
+		return map[string]interface{}{
+			"supportsConfigurationDoneRequest": true,
+			"supportsEvaluateForHovers":        true,
+			"supportsSetVariable":              true,
+			"supportsTerminateRequest":         true,
+		}, nil
+	case "launch", "attach", "configurationDone", "setExceptionBreakpoints":
+		return nil, nil
+	case "setBreakpoints":
+		args := &setBreakpointsArguments{}
+		if err := json.Unmarshal(request.Arguments, args); err != nil {
+			return nil, err
+		}
+		return s.setBreakpoints(args)
+	case "threads":
+		return map[string]interface{}{"threads": []*Thread{{ID: threadID, Name: "workflow"}}}, nil
+	case "stackTrace":
+		frames := s.stackFrames()
+		return map[string]interface{}{"stackFrames": frames, "totalFrames": len(frames)}, nil
+	case "scopes":
+		args := &scopesArguments{}
+		if err := json.Unmarshal(request.Arguments, args); err != nil {
+			return nil, err
+		}
+		return s.scopes(args.FrameID)
+	case "variables":
+		args := &variablesArguments{}
+		if err := json.Unmarshal(request.Arguments, args); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"variables": s.currentVariables().list(args.VariablesReference)}, nil
+	case "setVariable":
+		args := &setVariableArguments{}
+		if err := json.Unmarshal(request.Arguments, args); err != nil {
+			return nil, err
+		}
+		return s.currentVariables().set(args.VariablesReference, args.Name, args.Value)
+	case "evaluate":
+		args := &evaluateArguments{}
+		if err := json.Unmarshal(request.Arguments, args); err != nil {
+			return nil, err
+		}
+		return s.evaluate(args)
+	case "continue":
+		s.resume(debug.CommandContinue)
+		return map[string]interface{}{"allThreadsContinued": true}, nil
+	case "next":
+		s.resume(debug.CommandStepOver)
+	case "stepIn":
+		s.resume(debug.CommandStepInto)
+	case "stepOut":
+		s.resume(debug.CommandStepOut)
+	case "pause":
+		s.debugger.Pause()
+	case "disconnect", "terminate":
+		s.disconnect()
+	default:
+		return nil, fmt.Errorf("unsupported command: %v", request.Command)
+	}
+	return nil, nil
+}
+
+func (s *Server) setBreakpoints(args *setBreakpointsArguments) (interface{}, error) {
+	src, err := s.loadSource(args.Source.Path)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range s.breakpoints[src.path] {
+		s.debugger.RemoveBreakpoint(step)
+	}
+	s.breakpoints[src.path] = nil
+	var breakpoints = make([]*Breakpoint, 0, len(args.Breakpoints))
+	for _, candidate := range args.Breakpoints {
+		breakpoint := &Breakpoint{Line: candidate.Line}
+		breakpoints = append(breakpoints, breakpoint)
+		location := src.workflow.Locate(candidate.Line)
+		if location == nil {
+			breakpoint.Message = "no task or action at this line"
+			continue
+		}
+		step := debug.Step{Workflow: src.workflow.Name, TaskName: location.Name()}
+		if location.IsAction {
+			step.TagID = "*"
+		}
+		breakpoint.Verified = true
+		breakpoint.Line = location.Line
+		s.debugger.SetBreakpoint(step)
+		s.breakpoints[src.path] = append(s.breakpoints[src.path], step)
+	}
+	return map[string]interface{}{"breakpoints": breakpoints}, nil
+}
+
+func (s *Server) loadSource(location string) (*source, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if src, ok := s.sources[location]; ok {
+		return src, nil
+	}
+	workflow, err := graph.New().LoadWorkflow(context.Background(), url.Normalize(location, file.Scheme))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow: %v, %w", location, err)
+	}
+	src := &source{path: location, workflow: workflow}
+	s.sources[location] = src
+	return src, nil
+}
+
+func (s *Server) lookupSource(workflow string) *source {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, candidate := range s.sources {
+		if candidate.workflow.Name == workflow {
+			return candidate
+		}
+	}
+	return nil
+}
+
+func (s *Server) stackFrames() []*StackFrame {
+	var result = make([]*StackFrame, 0)
+	for i, frame := range s.debugger.Frames() {
+		stackFrame := &StackFrame{ID: i + 1, Name: fmt.Sprintf("%v %v", frame.Kind, frame.Step), Line: 1, Column: 1}
+		if src := s.lookupSource(frame.Step.Workflow); src != nil {
+			stackFrame.Source = &Source{Name: path.Base(src.path), Path: src.path}
+			for _, location := range src.workflow.Locations() {
+				if location.Name() == frame.Step.TaskName {
+					stackFrame.Line = location.Line
+					break
+				}
+			}
+		}
+		result = append(result, stackFrame)
+	}
+	return result
+}
+
+func (s *Server) frame(frameID int) (*debug.Frame, error) {
+	frames := s.debugger.Frames()
+	if frameID < 1 || frameID > len(frames) {
+		return nil, fmt.Errorf("invalid frame id: %v", frameID)
+	}
+	return frames[frameID-1], nil
+}
+
+func (s *Server) scopes(frameID int) (interface{}, error) {
+	frame, err := s.frame(frameID)
+	if err != nil {
+		return nil, err
+	}
+	vars := s.currentVariables()
+	var scopes = []*Scope{{Name: "State", VariablesReference: vars.reference(frame.State)}}
+	if frame.Request != nil {
+		scopes = append(scopes, &Scope{Name: "Request", VariablesReference: vars.reference(frame.Request)})
+	}
+	if frame.Response != nil {
+		scopes = append(scopes, &Scope{Name: "Response", VariablesReference: vars.reference(frame.Response)})
+	}
+	return map[string]interface{}{"scopes": scopes}, nil
+}
+
+func (s *Server) evaluate(args *evaluateArguments) (interface{}, error) {
+	frameID := args.FrameID
+	if frameID == 0 {
+		frameID = 1
+	}
+	frame, err := s.frame(frameID)
+	if err != nil {
+		return nil, err
+	}
+	expression := strings.TrimSpace(args.Expression)
+	var value interface{}
+	if strings.Contains(expression, "$") {
+		value = frame.State.Expand(expression)
+	} else if stateValue, has := frame.State.GetValue(expression); has {
+		value = stateValue
+	} else if frame.Evaluate != nil {
+		if value, err = frame.Evaluate(expression); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("undefined: %v", expression)
+	}
+	variable := s.currentVariables().asVariable(expression, value)
+	return map[string]interface{}{"result": variable.Value, "type": variable.Type, "variablesReference": variable.VariablesReference}, nil
+}
+
+func (s *Server) currentVariables() *variables {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.variables == nil {
+		s.variables = newVariables()
+	}
+	return s.variables
+}
+
+func (s *Server) resume(command debug.Command) {
+	s.mux.Lock()
+	isPaused := s.paused != nil
+	s.mux.Unlock()
+	if isPaused {
+		s.commands <- command
+	}
+	s.sendEvent("continued", map[string]interface{}{"threadId": threadID, "allThreadsContinued": true})
+}
+
+func (s *Server) disconnect() {
+	s.mux.Lock()
+	if s.closed {
+		s.mux.Unlock()
+		return
+	}
+	s.closed = true
+	isPaused := s.paused != nil
+	s.mux.Unlock()
+	s.configOnce.Do(func() { close(s.configured) })
+	if isPaused {
+		s.commands <- debug.CommandTerminate
+		return
+	}
+	s.debugger.Pause()
+}
+
+func (s *Server) send(response *Response) {
+	s.writeMux.Lock()
+	defer s.writeMux.Unlock()
+	s.seq++
+	response.Seq = s.seq
+	if err := writeMessage(s.conn, response); err != nil {
+		log.Printf("failed to send debug adapter response: %v\n", err)
+	}
+}
+
+func (s *Server) sendEvent(name string, body interface{}) {
+	if s.conn == nil {
+		return
+	}
+	s.writeMux.Lock()
+	defer s.writeMux.Unlock()
+	s.seq++
+	_ = writeMessage(s.conn, &Event{Seq: s.seq, Type: "event", Event: name, Body: body})
+}
+
+// New creates debug adapter protocol server for supplied address and debugger
+func New(address string, debugger *debug.Debugger) *Server {
+	result := &Server{
+		address:     address,
+		debugger:    debugger,
+		configured:  make(chan bool),
+		commands:    make(chan debug.Command, 1),
+		breakpoints: make(map[string][]debug.Step),
+		sources:     make(map[string]*source),
+	}
+	debugger.Session = result
+	return result
+}
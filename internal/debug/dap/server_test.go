@@ -0,0 +1,123 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly/internal/debug"
+	"github.com/viant/toolbox/data"
+	"net"
+	"os"
+	"path"
+	"testing"
+)
+
+type testClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	seq    int
+}
+
+func (c *testClient) request(t *testing.T, command string, arguments interface{}) *Response {
+	c.seq++
+	args, _ := json.Marshal(arguments)
+	assert.Nil(t, writeMessage(c.conn, &Request{Seq: c.seq, Type: "request", Command: command, Arguments: args}))
+	for {
+		message := c.read(t)
+		if message["type"] == "response" && message["command"] == command {
+			response := &Response{}
+			encoded, _ := json.Marshal(message)
+			_ = json.Unmarshal(encoded, response)
+			return response
+		}
+	}
+}
+
+func (c *testClient) waitEvent(t *testing.T, event string) map[string]interface{} {
+	for {
+		message := c.read(t)
+		if message["type"] == "event" && message["event"] == event {
+			return message
+		}
+	}
+}
+
+func (c *testClient) read(t *testing.T) map[string]interface{} {
+	data, err := readMessage(c.reader)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	var result = map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(data, &result))
+	return result
+}
+
+func TestServer_Paused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.Nil(t, err) {
+		return
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	debugger := &debug.Debugger{Breakpoints: make(map[debug.Step]struct{})}
+	server := New(address, debugger)
+	started := make(chan error, 1)
+	go func() { started <- server.Start() }()
+
+	var conn net.Conn
+	for conn == nil {
+		conn, _ = net.Dial("tcp", address)
+	}
+	client := &testClient{conn: conn, reader: bufio.NewReader(conn)}
+	response := client.request(t, "initialize", map[string]interface{}{"adapterID": "endly"})
+	assert.True(t, response.Success)
+
+	wd, _ := os.Getwd()
+	response = client.request(t, "setBreakpoints", map[string]interface{}{
+		"source":      map[string]interface{}{"path": path.Join(wd, "testdata/regression.yaml")},
+		"breakpoints": []map[string]interface{}{{"line": 8}, {"line": 2}},
+	})
+	assert.True(t, response.Success)
+	breakpoints := response.Body.(map[string]interface{})["breakpoints"].([]interface{})
+	assert.EqualValues(t, true, breakpoints[0].(map[string]interface{})["verified"])
+	assert.EqualValues(t, 6, breakpoints[0].(map[string]interface{})["line"])
+	assert.EqualValues(t, false, breakpoints[1].(map[string]interface{})["verified"])
+	assert.True(t, debugger.HasBreakpoint(debug.Step{Workflow: "regression", TaskName: "setup", TagID: "setup"}))
+
+	client.request(t, "configurationDone", nil)
+	assert.Nil(t, <-started)
+
+	state := data.NewMap()
+	state.Put("appName", "myapp")
+	frame := &debug.Frame{Kind: debug.KindAction, Step: debug.Step{Workflow: "regression", TaskName: "setup", TagID: "setup"}, State: state}
+	resumed := make(chan error, 1)
+	go func() { resumed <- debugger.Before(frame) }()
+
+	stopped := client.waitEvent(t, "stopped")
+	assert.EqualValues(t, "breakpoint", stopped["body"].(map[string]interface{})["reason"])
+
+	response = client.request(t, "stackTrace", map[string]interface{}{"threadId": threadID})
+	frames := response.Body.(map[string]interface{})["stackFrames"].([]interface{})
+	assert.EqualValues(t, 6, frames[0].(map[string]interface{})["line"])
+
+	response = client.request(t, "scopes", map[string]interface{}{"frameId": 1})
+	scopes := response.Body.(map[string]interface{})["scopes"].([]interface{})
+	reference := scopes[0].(map[string]interface{})["variablesReference"]
+
+	response = client.request(t, "setVariable", map[string]interface{}{"variablesReference": reference, "name": "counter", "value": "3"})
+	assert.True(t, response.Success)
+	assert.EqualValues(t, 3, state.GetInt("counter"))
+
+	response = client.request(t, "evaluate", map[string]interface{}{"expression": "${appName}-${counter}", "frameId": 1})
+	assert.EqualValues(t, "myapp-3", response.Body.(map[string]interface{})["result"])
+
+	response = client.request(t, "variables", map[string]interface{}{"variablesReference": reference})
+	variables := response.Body.(map[string]interface{})["variables"].([]interface{})
+	assert.Equal(t, 2, len(variables))
+
+	client.request(t, "continue", map[string]interface{}{"threadId": threadID})
+	assert.Nil(t, <-resumed)
+	debugger.After(frame)
+	assert.Nil(t, server.Close())
+}
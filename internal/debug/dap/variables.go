@@ -0,0 +1,97 @@
+package dap
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"sort"
+)
+
+// variables represents variable references valid while execution is paused
+type variables struct {
+	references map[int]interface{}
+}
+
+func (v *variables) reference(value interface{}) int {
+	ref := len(v.references) + 1
+	v.references[ref] = value
+	return ref
+}
+
+func (v *variables) list(ref int) []*Variable {
+	var result = make([]*Variable, 0)
+	value, ok := v.references[ref]
+	if !ok {
+		return result
+	}
+	if toolbox.IsSlice(value) {
+		for i, item := range toolbox.AsSlice(value) {
+			result = append(result, v.asVariable(fmt.Sprintf("[%d]", i), item))
+		}
+		return result
+	}
+	aMap := asMap(value)
+	keys := make([]string, 0, len(aMap))
+	for k, item := range aMap {
+		if k == data.UDFKey || toolbox.IsFunc(item) {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		result = append(result, v.asVariable(k, aMap[k]))
+	}
+	return result
+}
+
+func (v *variables) set(ref int, name, text string) (interface{}, error) {
+	value, ok := v.references[ref]
+	if !ok {
+		return nil, fmt.Errorf("invalid variables reference: %v", ref)
+	}
+	var newValue interface{}
+	if err := json.Unmarshal([]byte(text), &newValue); err != nil {
+		newValue = text
+	}
+	switch actual := value.(type) {
+	case data.Map:
+		actual.Put(name, newValue)
+	case map[string]interface{}:
+		actual[name] = newValue
+	default:
+		return nil, fmt.Errorf("unsupported variable container: %T", value)
+	}
+	return v.asVariable(name, newValue), nil
+}
+
+func (v *variables) asVariable(name string, value interface{}) *Variable {
+	result := &Variable{Name: name, Type: fmt.Sprintf("%T", value)}
+	switch {
+	case value == nil:
+		result.Value = "null"
+	case toolbox.IsSlice(value):
+		result.Value = fmt.Sprintf("[%d]", len(toolbox.AsSlice(value)))
+		result.VariablesReference = v.reference(value)
+	case toolbox.IsMap(value) || toolbox.IsStruct(value):
+		result.Value = fmt.Sprintf("{%d}", len(asMap(value)))
+		result.VariablesReference = v.reference(value)
+	default:
+		result.Value = toolbox.AsString(value)
+	}
+	return result
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	if toolbox.IsMap(value) {
+		return toolbox.AsMap(value)
+	}
+	var result = make(map[string]interface{})
+	_ = toolbox.DefaultConverter.AssignConverted(&result, value)
+	return result
+}
+
+func newVariables() *variables {
+	return &variables{references: make(map[int]interface{})}
+}
@@ -1,70 +1,165 @@
 package debug
 
 import (
-	"fmt"
+	"errors"
+	"io"
+	"os"
 	"sync"
 )
 
+// ErrTerminated represents error returned when debugging session terminated execution
+var ErrTerminated = errors.New("execution terminated by debugger")
+
+// Command represents resume command
+type Command int
+
+const (
+	//CommandContinue resumes execution till next breakpoint
+	CommandContinue = Command(iota)
+	//CommandStepOver pauses on the next node at the same or upper level
+	CommandStepOver
+	//CommandStepInto pauses on the next node
+	CommandStepInto
+	//CommandStepOut pauses on the next node at the upper level
+	CommandStepOut
+	//CommandTerminate terminates execution
+	CommandTerminate
+)
+
+// Session represents paused execution handler, it returns command to resume execution
+type Session interface {
+	Paused(frame *Frame) (Command, error)
+}
+
 // Debugger is responsible for debugging Endly workflows.
 type Debugger struct {
-	mux          sync.Mutex
-	Breakpoints  map[Step]struct{} // Task names where the debugger will pause execution
-	StepMode     bool              // Flag to step through the workflow one task at a time
-	continueExec chan bool         // Channel used to control execution flow
+	mux         sync.Mutex
+	Breakpoints map[Step]struct{} // Steps where the debugger will pause execution
+	StepMode    bool              // Flag to step through the workflow one node at a time
+	Session     Session
+	command     Command
+	stepDepth   int
+	frames      []*Frame
 }
 
-// NewDebugger creates a new debugger instance with initialized channels.
+// NewDebugger creates a new debugger instance with console session.
 func NewDebugger() *Debugger {
-	return &Debugger{
-		Breakpoints:  make(map[Step]struct{}),
-		continueExec: make(chan bool),
+	result := &Debugger{
+		Breakpoints: make(map[Step]struct{}),
 	}
+	result.Session = NewConsole(result, os.Stdin, os.Stdout)
+	return result
 }
 
-// SetBreakpoint sets a breakpoint on a task by its name.
+// SetBreakpoint sets a breakpoint on a workflow step.
 func (d *Debugger) SetBreakpoint(step Step) {
 	d.mux.Lock()
 	defer d.mux.Unlock()
 	d.Breakpoints[step] = struct{}{}
 }
 
-// RemoveBreakpoint removes a breakpoint from a task by its name.
+// RemoveBreakpoint removes a breakpoint from a workflow step.
 func (d *Debugger) RemoveBreakpoint(breakpoint Step) {
 	d.mux.Lock()
 	defer d.mux.Unlock()
 	delete(d.Breakpoints, breakpoint)
 }
 
-// BeforeTaskExecution is modified to pause at breakpoints or in step mode, waiting for channel input to continue.
-func (d *Debugger) BeforeTaskExecution(step Step, request interface{}) {
-	fmt.Printf("Before executing task %s: request = %+v\n", step, request)
-	hasBreakpoint := false
+// ClearBreakpoints removes all breakpoints
+func (d *Debugger) ClearBreakpoints() {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.Breakpoints = make(map[Step]struct{})
+}
+
+// HasBreakpoint returns true if any breakpoint matches supplied step
+func (d *Debugger) HasBreakpoint(step Step) bool {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	for breakpoint := range d.Breakpoints {
+		if breakpoint.Matches(step) {
+			return true
+		}
+	}
+	return false
+}
+
+// Frames returns currently executed frames, the most recent first
+func (d *Debugger) Frames() []*Frame {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	var result = make([]*Frame, 0, len(d.frames))
+	for i := len(d.frames) - 1; i >= 0; i-- {
+		result = append(result, d.frames[i])
+	}
+	return result
+}
+
+// Before is called before executing workflow node, it pauses at breakpoints or according to step command
+func (d *Debugger) Before(frame *Frame) error {
+	d.mux.Lock()
+	frame.Depth = len(d.frames)
+	d.frames = append(d.frames, frame)
+	d.mux.Unlock()
+	if !d.shouldPause(frame) {
+		return nil
+	}
+	command, err := d.Session.Paused(frame)
+	if err != nil {
+		return err
+	}
+	if command == CommandTerminate {
+		return ErrTerminated
+	}
 	d.mux.Lock()
-	_, hasBreakpoint = d.Breakpoints[step]
+	d.command = command
+	d.stepDepth = frame.Depth
 	d.mux.Unlock()
+	return nil
+}
 
-	if hasBreakpoint || d.StepMode {
-		fmt.Println("Execution paused. Press enter to continue...")
-		go func() {
-			fmt.Scanln()           // Wait for user input
-			d.continueExec <- true // Signal to continue execution
-		}()
-		<-d.continueExec // Wait for signal to continue
+// After is called once workflow node completed
+func (d *Debugger) After(frame *Frame) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	for i := len(d.frames) - 1; i >= 0; i-- {
+		if d.frames[i] == frame {
+			d.frames = append(d.frames[:i], d.frames[i+1:]...)
+			break
+		}
 	}
 }
 
-// AfterTaskExecution logs task results, similar to the previous implementation.
-func (d *Debugger) AfterTaskExecution(step Step, result interface{}) {
-	fmt.Printf("After executing task %s: result = %+v\n", step, result)
-	if d.StepMode {
-		// In step mode, pause after each task execution.
-		fmt.Println("Step execution paused. Press enter to continue to the next task...")
-		go func() {
-			fmt.Scanln()           // Wait for user input
-			d.continueExec <- true // Signal to continue execution
-		}()
-		<-d.continueExec // Wait for signal to continue
+func (d *Debugger) shouldPause(frame *Frame) bool {
+	if d.StepMode || d.HasBreakpoint(frame.Step) {
+		return true
+	}
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	switch d.command {
+	case CommandStepInto:
+		return true
+	case CommandStepOver:
+		return frame.Depth <= d.stepDepth
+	case CommandStepOut:
+		return frame.Depth < d.stepDepth
+	}
+	return false
+}
+
+// Pause requests execution pause on the next workflow node
+func (d *Debugger) Pause() {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.command = CommandStepInto
+}
+
+// Close closes debugging session if it is closable
+func (d *Debugger) Close() error {
+	if closer, ok := d.Session.(io.Closer); ok {
+		return closer.Close()
 	}
+	return nil
 }
 
 // EnableStepMode enables or disables step mode.
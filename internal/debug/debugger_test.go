@@ -0,0 +1,84 @@
+package debug
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/toolbox/data"
+	"strings"
+	"testing"
+)
+
+func TestStep_Matches(t *testing.T) {
+	useCases := []struct {
+		Description string
+		Breakpoint  string
+		Step        Step
+		Expected    bool
+	}{
+		{
+			Description: "exact match",
+			Breakpoint:  "regression/test/Test1",
+			Step:        Step{Workflow: "regression", TaskName: "test", TagID: "Test1"},
+			Expected:    true,
+		},
+		{
+			Description: "task wildcard",
+			Breakpoint:  "regression/*/Test1",
+			Step:        Step{Workflow: "regression", TaskName: "prepare", TagID: "Test1"},
+			Expected:    true,
+		},
+		{
+			Description: "workflow breakpoint",
+			Breakpoint:  "regression",
+			Step:        Step{Workflow: "regression"},
+			Expected:    true,
+		},
+		{
+			Description: "workflow breakpoint on task",
+			Breakpoint:  "regression",
+			Step:        Step{Workflow: "regression", TaskName: "prepare"},
+			Expected:    false,
+		},
+		{
+			Description: "tag mismatch",
+			Breakpoint:  "regression/test/Test1",
+			Step:        Step{Workflow: "regression", TaskName: "test", TagID: "Test2"},
+			Expected:    false,
+		},
+	}
+	for _, useCase := range useCases {
+		assert.Equal(t, useCase.Expected, ParseStep(useCase.Breakpoint).Matches(useCase.Step), useCase.Description)
+	}
+}
+
+func TestDebugger_Before(t *testing.T) {
+	debugger := &Debugger{Breakpoints: make(map[Step]struct{})}
+	input := strings.NewReader("set counter = 3\np counter\ne $counter > 2\ns\nc\n")
+	output := new(bytes.Buffer)
+	debugger.Session = NewConsole(debugger, input, output)
+	debugger.SetBreakpoint(ParseStep("regression/test"))
+	state := data.NewMap()
+
+	workflowFrame := &Frame{Kind: KindWorkflow, Step: Step{Workflow: "regression"}, State: state}
+	assert.Nil(t, debugger.Before(workflowFrame))
+	assert.Equal(t, "", output.String())
+
+	taskFrame := &Frame{Kind: KindTask, Step: Step{Workflow: "regression", TaskName: "test"}, State: state,
+		Evaluate: func(expression string) (bool, error) {
+			return state.GetInt("counter") > 2, nil
+		}}
+	assert.Nil(t, debugger.Before(taskFrame))
+	assert.EqualValues(t, 3, state.GetInt("counter"))
+	assert.True(t, strings.Contains(output.String(), "paused at task regression/test"))
+	assert.True(t, strings.Contains(output.String(), "true"))
+
+	actionFrame := &Frame{Kind: KindAction, Step: Step{Workflow: "regression", TaskName: "test", TagID: "Test1"}, State: state}
+	assert.Nil(t, debugger.Before(actionFrame))
+	debugger.After(actionFrame)
+	assert.Equal(t, 2, len(debugger.Frames()))
+	assert.True(t, strings.Contains(output.String(), "paused at action regression/test/Test1"))
+
+	debugger.After(taskFrame)
+	debugger.After(workflowFrame)
+	assert.Equal(t, 0, len(debugger.Frames()))
+}
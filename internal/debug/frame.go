@@ -0,0 +1,23 @@
+package debug
+
+import "github.com/viant/toolbox/data"
+
+const (
+	//KindWorkflow represents workflow frame kind
+	KindWorkflow = "workflow"
+	//KindTask represents task frame kind
+	KindTask = "task"
+	//KindAction represents action frame kind
+	KindAction = "action"
+)
+
+// Frame represents currently executed workflow node
+type Frame struct {
+	Kind     string
+	Step     Step
+	Depth    int
+	State    data.Map
+	Request  interface{}
+	Response interface{}
+	Evaluate func(expression string) (bool, error) `json:"-"`
+}
@@ -1,6 +1,42 @@
 package debug
 
+import (
+	"fmt"
+	"strings"
+)
+
+// Step represents workflow execution step, '*' field matches any value when used as a breakpoint
 type Step struct {
 	Workflow string
 	TaskName string
+	TagID    string
+}
+
+// Matches returns true if supplied step matches this breakpoint step
+func (s Step) Matches(step Step) bool {
+	return matchesField(s.Workflow, step.Workflow) &&
+		matchesField(s.TaskName, step.TaskName) &&
+		matchesField(s.TagID, step.TagID)
+}
+
+// String returns step expression in workflow/task/tagID format
+func (s Step) String() string {
+	return strings.TrimRight(fmt.Sprintf("%v/%v/%v", s.Workflow, s.TaskName, s.TagID), "/")
+}
+
+// ParseStep parses workflow/task/tagID step expression
+func ParseStep(expression string) Step {
+	parts := strings.SplitN(strings.TrimSpace(expression), "/", 3)
+	var result = Step{Workflow: parts[0]}
+	if len(parts) > 1 {
+		result.TaskName = parts[1]
+	}
+	if len(parts) > 2 {
+		result.TagID = parts[2]
+	}
+	return result
+}
+
+func matchesField(expected, actual string) bool {
+	return expected == "*" || expected == actual
 }
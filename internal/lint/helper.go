@@ -0,0 +1,90 @@
+package lint
+
+import (
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/service/meta"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// parseFile returns document root node, nil if document is empty
+func parseFile(URL string) (*yaml.Node, error) {
+	content, err := os.ReadFile(URL)
+	if err != nil {
+		return nil, err
+	}
+	var root = &yaml.Node{}
+	if err = yaml.Unmarshal(content, root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	return resolve(root.Content[0]), nil
+}
+
+func isYAML(URL string) bool {
+	ext := filepath.Ext(URL)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// resolve returns anchor node for alias
+func resolve(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// attributeName returns lower case key without explicit action or request prefix
+func attributeName(key string) string {
+	key = strings.TrimPrefix(key, model.ExplicitActionAttributePrefix)
+	key = strings.TrimPrefix(key, model.ExplicitRequestAttributePrefix)
+	return strings.ToLower(key)
+}
+
+// lookup returns map node value for supplied case insensitive key, explicit action attribute prefix is ignored
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := strings.ToLower(strings.TrimPrefix(node.Content[i].Value, model.ExplicitActionAttributePrefix))
+		if name == key {
+			return resolve(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// hasActionNode returns true if node or any nested node defines action or workflow
+func hasActionNode(node *yaml.Node) bool {
+	if node == nil {
+		return false
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		if lookup(node, actionKey) != nil || lookup(node, workflowKey) != nil {
+			return true
+		}
+		for i := 1; i < len(node.Content); i += 2 {
+			if hasActionNode(resolve(node.Content[i])) {
+				return true
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if hasActionNode(resolve(item)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isClosed returns true if schema defines all allowed properties
+func isClosed(schema *meta.Schema) bool {
+	return schema != nil && len(schema.Properties) > 0 && schema.AdditionalProperties == nil
+}
@@ -0,0 +1,19 @@
+package lint
+
+import "fmt"
+
+// Issue represents workflow lint issue
+type Issue struct {
+	URL     string
+	Line    int
+	Column  int
+	Message string
+}
+
+// String returns issue with file position
+func (i *Issue) String() string {
+	if i.Line == 0 {
+		return fmt.Sprintf("%v: %v", i.URL, i.Message)
+	}
+	return fmt.Sprintf("%v:%v:%v: %v", i.URL, i.Line, i.Column, i.Message)
+}
@@ -0,0 +1,414 @@
+package lint
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/criteria/parser"
+	"github.com/viant/endly/service/meta"
+	"github.com/viant/endly/service/workflow"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	pipelineKey = "pipeline"
+	actionKey   = "action"
+	serviceKey  = "service"
+	workflowKey = "workflow"
+	requestKey  = "request"
+	templateKey = "template"
+	subPathKey  = "subpath"
+	retryKey    = "retry"
+)
+
+// criteriaKeys represents node attributes holding criteria expression
+var criteriaKeys = map[string]bool{"when": true, "skip": true, "exit": true}
+
+// variablesKeys represents node attributes holding state variables
+var variablesKeys = map[string]bool{"init": true, "post": true}
+
+// Linter represents static workflow validator, it checks inline workflow documents against pipeline and service request schemas
+type Linter struct {
+	services   map[string]endly.Service
+	schemas    *meta.Schemas
+	workflow   map[string]bool
+	attributes map[string]bool
+	requests   map[string]*meta.Schema
+	visited    map[string]bool
+	issues     []*Issue
+}
+
+type document struct {
+	URL     string
+	baseURL string
+}
+
+// Lint validates workflow file, or all inline workflow files if location is a directory, sub workflows and request files
+// referenced by relative path are validated too
+func (l *Linter) Lint(location string) ([]*Issue, error) {
+	l.issues = nil
+	l.visited = map[string]bool{}
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		if err = l.lintFile(location); err != nil {
+			return nil, err
+		}
+		return l.sortedIssues(), nil
+	}
+	err = filepath.WalkDir(location, func(URL string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !isYAML(URL) {
+			return err
+		}
+		root, err := parseFile(URL)
+		if err != nil || lookup(root, pipelineKey) == nil {
+			return nil
+		}
+		return l.lintFile(URL)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l.sortedIssues(), nil
+}
+
+func (l *Linter) sortedIssues() []*Issue {
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		if l.issues[i].URL != l.issues[j].URL {
+			return l.issues[i].URL < l.issues[j].URL
+		}
+		return l.issues[i].Line < l.issues[j].Line
+	})
+	return l.issues
+}
+
+func (l *Linter) report(doc *document, node *yaml.Node, format string, args ...interface{}) {
+	issue := &Issue{URL: doc.URL, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		issue.Line = node.Line
+		issue.Column = node.Column
+	}
+	l.issues = append(l.issues, issue)
+}
+
+func (l *Linter) lintFile(URL string) error {
+	if l.visited[URL] {
+		return nil
+	}
+	l.visited[URL] = true
+	doc := &document{URL: URL, baseURL: filepath.Dir(URL)}
+	root, err := parseFile(URL)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return err
+		}
+		l.report(doc, nil, "invalid YAML: %v", err)
+		return nil
+	}
+	if root == nil {
+		return nil
+	}
+	if root.Kind != yaml.MappingNode {
+		l.report(doc, root, "expected workflow document to be a map")
+		return nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], resolve(root.Content[i+1])
+		name := strings.ToLower(key.Value)
+		if !l.workflow[name] {
+			l.report(doc, key, "unknown workflow attribute: %v", key.Value)
+			continue
+		}
+		if name != pipelineKey {
+			continue
+		}
+		if value.Kind != yaml.MappingNode {
+			l.report(doc, value, "expected pipeline to be a map")
+			continue
+		}
+		for j := 0; j+1 < len(value.Content); j += 2 {
+			l.lintNode(doc, value.Content[j], resolve(value.Content[j+1]), false)
+		}
+	}
+	return nil
+}
+
+// lintNode validates pipeline node, node is either an action, a workflow action, a template or a task grouping nested nodes
+func (l *Linter) lintNode(doc *document, key, node *yaml.Node, inTemplate bool) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	if !hasActionNode(node) {
+		l.report(doc, key, "node %v does not define any action or workflow", key.Value)
+		return
+	}
+	if value := lookup(node, actionKey); value != nil {
+		l.lintAction(doc, node, value, inTemplate)
+		return
+	}
+	if value := lookup(node, workflowKey); value != nil {
+		l.lintWorkflowAction(doc, node, value)
+		return
+	}
+	isTemplate := lookup(node, templateKey) != nil && lookup(node, subPathKey) != nil
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		childKey, child := node.Content[i], resolve(node.Content[i+1])
+		name := attributeName(childKey.Value)
+		switch {
+		case isTemplate && name == templateKey:
+			if child.Kind == yaml.MappingNode {
+				for j := 0; j+1 < len(child.Content); j += 2 {
+					l.lintNode(doc, child.Content[j], resolve(child.Content[j+1]), true)
+				}
+			}
+		case child.Kind == yaml.MappingNode && hasActionNode(child):
+			l.lintNode(doc, childKey, child, inTemplate)
+		case variablesKeys[name]:
+		case child.Kind == yaml.MappingNode && !l.attributes[name]:
+			l.lintNode(doc, childKey, child, inTemplate)
+		case !l.attributes[name]:
+			l.report(doc, childKey, "unknown task attribute: %v", childKey.Value)
+		default:
+			l.lintAttribute(doc, name, child)
+		}
+	}
+}
+
+func (l *Linter) lintAction(doc *document, node, selector *yaml.Node, inTemplate bool) {
+	if selector.Kind != yaml.ScalarNode || strings.Contains(selector.Value, "$") {
+		return
+	}
+	actionSelector := model.ActionSelector(selector.Value)
+	serviceID, action := actionSelector.Service(), actionSelector.Action()
+	if !strings.ContainsAny(selector.Value, ".:") {
+		if service := lookup(node, serviceKey); service != nil && service.Kind == yaml.ScalarNode {
+			serviceID = service.Value
+		}
+	}
+	requestSchema, err := l.requestSchema(serviceID, action)
+	if err != nil {
+		l.report(doc, selector, "%v", err)
+	}
+	label := serviceID + ":" + action
+	request := lookup(node, requestKey)
+	hasRequestFile := request != nil && request.Kind == yaml.ScalarNode && strings.HasPrefix(request.Value, "@")
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], resolve(node.Content[i+1])
+		if strings.Contains(key.Value, "$") {
+			continue
+		}
+		name := attributeName(key.Value)
+		isAttribute := l.attributes[name]
+		switch {
+		case strings.HasPrefix(key.Value, model.ExplicitActionAttributePrefix):
+			if !isAttribute {
+				l.report(doc, key, "unknown action attribute: %v", key.Value)
+				continue
+			}
+			l.lintAttribute(doc, name, value)
+		case strings.HasPrefix(key.Value, model.ExplicitRequestAttributePrefix):
+			l.lintField(doc, key, value, requestSchema, label)
+		case name == requestKey:
+			l.lintRequest(doc, value, requestSchema, label, inTemplate)
+		case isAttribute:
+			l.lintAttribute(doc, name, value)
+		case !hasRequestFile: //otherwise node attributes are request file arguments
+			l.lintField(doc, key, value, requestSchema, label)
+		}
+	}
+}
+
+// lintWorkflowAction validates workflow action, node attributes except explicit action attributes are workflow parameters
+func (l *Linter) lintWorkflowAction(doc *document, node, selector *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], resolve(node.Content[i+1])
+		name := attributeName(key.Value)
+		if strings.HasPrefix(key.Value, model.ExplicitActionAttributePrefix) && !l.attributes[name] {
+			l.report(doc, key, "unknown action attribute: %v", key.Value)
+			continue
+		}
+		if l.attributes[name] && !strings.HasPrefix(key.Value, model.ExplicitRequestAttributePrefix) {
+			l.lintAttribute(doc, name, value)
+		}
+	}
+	if selector.Kind != yaml.ScalarNode || strings.Contains(selector.Value, "$") {
+		return
+	}
+	workflowSelector := model.WorkflowSelector(selector.Value)
+	if !workflowSelector.IsRelative() {
+		return
+	}
+	URL := workflowSelector.URL()
+	URL = strings.TrimSuffix(URL, filepath.Ext(URL))
+	for _, ext := range []string{".yaml", ".yml"} {
+		candidate := filepath.Join(doc.baseURL, URL+ext)
+		if _, err := os.Stat(candidate); err == nil {
+			if err = l.lintFile(candidate); err != nil {
+				l.report(doc, selector, "failed to lint workflow %v: %v", candidate, err)
+			}
+			return
+		}
+	}
+}
+
+func (l *Linter) lintAttribute(doc *document, name string, value *yaml.Node) {
+	if criteriaKeys[name] {
+		l.lintCriteria(doc, name, value)
+		return
+	}
+	if name == retryKey && value.Kind == yaml.MappingNode {
+		if when := lookup(value, "when"); when != nil {
+			l.lintCriteria(doc, "retry.when", when)
+		}
+	}
+}
+
+func (l *Linter) lintCriteria(doc *document, name string, value *yaml.Node) {
+	if value.Kind != yaml.ScalarNode || value.Tag == "!!bool" || strings.TrimSpace(value.Value) == "" {
+		return
+	}
+	if _, err := parser.ParseCriteria(value.Value); err != nil {
+		l.report(doc, value, "invalid %v criteria: %v", name, err)
+	}
+}
+
+// lintRequest validates inline request or request file referenced with @ prefix, template request files are resolved
+// relative to expanded use case path, thus are not reported if missing
+func (l *Linter) lintRequest(doc *document, value *yaml.Node, schema *meta.Schema, label string, inTemplate bool) {
+	if value.Kind == yaml.MappingNode {
+		l.lintFields(doc, value, schema, label)
+		return
+	}
+	if value.Kind != yaml.ScalarNode || !strings.HasPrefix(value.Value, "@") || strings.Contains(value.Value, "$") {
+		return
+	}
+	URI := strings.Fields(value.Value[1:])
+	if len(URI) == 0 {
+		return
+	}
+	for _, baseURL := range []string{doc.baseURL, filepath.Join(doc.baseURL, "default")} {
+		for _, ext := range []string{"", ".json", ".yaml", ".yml"} {
+			candidate := filepath.Join(baseURL, URI[0]+ext)
+			if info, err := os.Stat(candidate); err != nil || info.IsDir() {
+				continue
+			}
+			if l.visited[candidate] || !(isYAML(candidate) || filepath.Ext(candidate) == ".json") {
+				return
+			}
+			l.visited[candidate] = true
+			requestDoc := &document{URL: candidate, baseURL: filepath.Dir(candidate)}
+			root, err := parseFile(candidate)
+			if err != nil {
+				l.report(requestDoc, nil, "invalid request: %v", err)
+				return
+			}
+			if root == nil || root.Kind != yaml.MappingNode {
+				return
+			}
+			for i := 0; i+1 < len(root.Content); i += 2 {
+				if key := root.Content[i]; !l.attributes[attributeName(key.Value)] {
+					l.lintField(requestDoc, key, resolve(root.Content[i+1]), schema, label)
+				}
+			}
+			return
+		}
+	}
+	if !inTemplate {
+		l.report(doc, value, "request file not found: %v", URI[0])
+	}
+}
+
+func (l *Linter) lintFields(doc *document, node *yaml.Node, schema *meta.Schema, label string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		l.lintField(doc, node.Content[i], resolve(node.Content[i+1]), schema, label)
+	}
+}
+
+// lintField validates that request field is defined by schema, nested maps and slices of maps are validated recursively
+func (l *Linter) lintField(doc *document, key, value *yaml.Node, schema *meta.Schema, label string) {
+	if !isClosed(schema) || strings.Contains(key.Value, "$") {
+		return
+	}
+	name := strings.TrimPrefix(key.Value, model.ExplicitRequestAttributePrefix)
+	property := l.property(schema, name)
+	if property == nil {
+		l.report(doc, key, "unknown %v request field: %v", label, name)
+		return
+	}
+	switch value.Kind {
+	case yaml.MappingNode:
+		l.lintFields(doc, value, property, label)
+	case yaml.SequenceNode:
+		items := l.schemas.Resolve(property.Items)
+		for _, item := range value.Content {
+			if item = resolve(item); item.Kind == yaml.MappingNode {
+				l.lintFields(doc, item, items, label)
+			}
+		}
+	}
+}
+
+func (l *Linter) property(schema *meta.Schema, name string) *meta.Schema {
+	name = strings.ToLower(name)
+	for candidate, property := range schema.Properties {
+		if strings.ToLower(candidate) == name {
+			return l.schemas.Resolve(property)
+		}
+	}
+	return nil
+}
+
+func (l *Linter) requestSchema(serviceID, action string) (*meta.Schema, error) {
+	key := serviceID + ":" + action
+	if schema, ok := l.requests[key]; ok {
+		return schema, nil
+	}
+	service, ok := l.services[serviceID]
+	if !ok {
+		return nil, fmt.Errorf("unknown service: %v", serviceID)
+	}
+	route, err := service.Route(action)
+	if err != nil {
+		actions := append([]string{}, service.Actions()...)
+		sort.Strings(actions)
+		return nil, fmt.Errorf("unknown action: %v, %v supports: %v", key, serviceID, strings.Join(actions, ","))
+	}
+	var schema *meta.Schema
+	if route.OnRawRequest == nil { //raw request handler consumes attributes outside of request struct, i.e. credentials
+		schema = l.schemas.Resolve(l.schemas.Of(route.RequestProvider()))
+	}
+	l.requests[key] = schema
+	return schema, nil
+}
+
+// New creates a linter
+func New() *Linter {
+	metaService := meta.New()
+	schemas := meta.NewSchemas("")
+	schemas.FieldNames = true
+	var result = &Linter{
+		services:   endly.Services(metaService.Manager),
+		schemas:    schemas,
+		workflow:   map[string]bool{},
+		attributes: map[string]bool{},
+		requests:   map[string]*meta.Schema{},
+	}
+	for name := range schemas.Resolve(schemas.Of(&workflow.RunRequest{})).Properties {
+		result.workflow[strings.ToLower(name)] = true
+	}
+	pipelineSchema := metaService.PipelineSchema()
+	for name := range pipelineSchema.Properties {
+		result.workflow[strings.ToLower(name)] = true
+	}
+	for name := range pipelineSchema.Definitions[meta.NodeDefinition].Properties {
+		result.attributes[strings.ToLower(name)] = true
+	}
+	return result
+}
@@ -0,0 +1,45 @@
+package lint
+
+import (
+	"github.com/stretchr/testify/assert"
+	_ "github.com/viant/endly/service/system/exec"
+	_ "github.com/viant/endly/service/testing/validator"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLinter_Lint(t *testing.T) {
+	issues, err := New().Lint(filepath.Join("testdata", "workflow.yaml"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var useCases = []struct {
+		description string
+		URL         string
+		line        int
+		expect      string
+	}{
+		{description: "unknown request field", URL: "workflow.yaml", line: 9, expect: "unknown exec:run request field: comands"},
+		{description: "invalid criteria", URL: "workflow.yaml", line: 12, expect: "invalid when criteria"},
+		{description: "unknown nested action request field", URL: "workflow.yaml", line: 16, expect: "unknown workflow:print request field: colour"},
+		{description: "unknown action", URL: "workflow.yaml", line: 18, expect: "unknown action: exec:runn"},
+		{description: "node without action", URL: "workflow.yaml", line: 21, expect: "node notify does not define any action or workflow"},
+		{description: "missing request file", URL: "workflow.yaml", line: 29, expect: "request file not found: req/missing"},
+		{description: "unknown action attribute", URL: "workflow.yaml", line: 32, expect: "unknown action attribute: :skipp"},
+		{description: "unknown workflow attribute", URL: "workflow.yaml", line: 33, expect: "unknown workflow attribute: unknown"},
+		{description: "request file field", URL: "assert.yaml", line: 2, expect: "unknown validator:assert request field: expected"},
+		{description: "sub workflow criteria", URL: "other.yaml", line: 5, expect: "invalid when criteria"},
+	}
+	assert.Equal(t, len(useCases), len(issues))
+	for _, useCase := range useCases {
+		found := false
+		for _, issue := range issues {
+			if strings.HasSuffix(issue.URL, useCase.URL) && issue.Line == useCase.line && strings.Contains(issue.Message, useCase.expect) {
+				found = true
+				break
+			}
+		}
+		assert.True(t, found, useCase.description)
+	}
+}
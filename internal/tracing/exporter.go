@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Span represents span exported as JSON line by file exporter
+type Span struct {
+	TraceID      string                 `json:"traceId"`
+	SpanID       string                 `json:"spanId"`
+	ParentSpanID string                 `json:"parentSpanId,omitempty"`
+	Name         string                 `json:"name"`
+	Kind         string                 `json:"kind"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	Status       string                 `json:"status"`
+	Description  string                 `json:"description,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// fileExporter represents offline span exporter writing each span as JSON line
+type fileExporter struct {
+	mux    sync.Mutex
+	writer io.WriteCloser
+}
+
+// ExportSpans writes supplied spans
+func (e *fileExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	encoder := json.NewEncoder(e.writer)
+	for _, span := range spans {
+		if err := encoder.Encode(newSpan(span)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown closes underlying writer
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return e.writer.Close()
+}
+
+func newSpan(span sdktrace.ReadOnlySpan) *Span {
+	result := &Span{
+		TraceID:     span.SpanContext().TraceID().String(),
+		SpanID:      span.SpanContext().SpanID().String(),
+		Name:        span.Name(),
+		Kind:        span.SpanKind().String(),
+		Start:       span.StartTime(),
+		End:         span.EndTime(),
+		Status:      span.Status().Code.String(),
+		Description: span.Status().Description,
+	}
+	if parent := span.Parent(); parent.IsValid() {
+		result.ParentSpanID = parent.SpanID().String()
+	}
+	if attributes := span.Attributes(); len(attributes) > 0 {
+		result.Attributes = make(map[string]interface{}, len(attributes))
+		for _, attribute := range attributes {
+			result.Attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+		}
+	}
+	return result
+}
+
+func newFileExporter(writer io.WriteCloser) *fileExporter {
+	return &fileExporter{writer: writer}
+}
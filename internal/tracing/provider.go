@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const serviceName = "endly"
+
+// NewProvider creates tracer provider, endpoint is either OTLP HTTP endpoint (http://localhost:4318) or file path to write spans as JSON lines
+func NewProvider(ctx context.Context, endpoint string) (*sdktrace.TracerProvider, error) {
+	exporter, err := newExporter(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	), nil
+}
+
+func newExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	}
+	URL := strings.TrimPrefix(endpoint, "file://")
+	if parent, _ := path.Split(URL); parent != "" {
+		if err := os.MkdirAll(parent, 0744); err != nil {
+			return nil, err
+		}
+	}
+	file, err := os.OpenFile(URL, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return newFileExporter(file), nil
+}
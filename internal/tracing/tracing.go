@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/viant/endly"
+	//StatusKey represents endly execution status span attribute
+	StatusKey = attribute.Key("endly.status")
+	//ServiceKey represents service id span attribute
+	ServiceKey = attribute.Key("endly.service")
+	//ActionKey represents service action span attribute
+	ActionKey = attribute.Key("endly.action")
+	//TagIDKey represents use case TagID span attribute
+	TagIDKey = attribute.Key("endly.tag_id")
+	//WorkflowKey represents workflow name span attribute
+	WorkflowKey = attribute.Key("endly.workflow")
+	//TaskKey represents task name span attribute
+	TaskKey = attribute.Key("endly.task")
+)
+
+type providerKey struct{}
+
+var propagator = propagation.TraceContext{}
+
+// WithProvider returns context with tracer provider used to start root span
+func WithProvider(ctx context.Context, provider trace.TracerProvider) context.Context {
+	return context.WithValue(ctx, providerKey{}, provider)
+}
+
+// HasProvider returns true if context carries tracer provider or recording span
+func HasProvider(ctx context.Context) bool {
+	if trace.SpanFromContext(ctx).IsRecording() {
+		return true
+	}
+	_, ok := ctx.Value(providerKey{}).(trace.TracerProvider)
+	return ok
+}
+
+// Start starts a child span of the context span, or root span if context carries tracer provider, otherwise it returns non recording span
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	parent := trace.SpanFromContext(ctx)
+	var provider trace.TracerProvider
+	if parent.IsRecording() {
+		provider = parent.TracerProvider()
+	} else if provider, _ = ctx.Value(providerKey{}).(trace.TracerProvider); provider == nil {
+		return ctx, parent
+	}
+	return provider.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// SetAttributes sets attributes on the context span
+func SetAttributes(ctx context.Context, attributes ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).SetAttributes(attributes...)
+}
+
+// End sets span status with supplied error and ends the span
+func End(span trace.Span, err error) {
+	if !span.IsRecording() {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(StatusKey.String("error"))
+	} else {
+		span.SetAttributes(StatusKey.String("ok"))
+	}
+	span.End()
+}
+
+// Inject sets W3C traceparent header for the context span
+func Inject(ctx context.Context, header http.Header) {
+	if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		return
+	}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+}
@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStart(t *testing.T) {
+	ctx := context.Background()
+	_, span := Start(ctx, "noop")
+	assert.False(t, span.IsRecording())
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx = WithProvider(ctx, provider)
+	assert.True(t, HasProvider(ctx))
+
+	workflowCtx, workflowSpan := Start(ctx, "workflow regression", WorkflowKey.String("regression"))
+	actionCtx, actionSpan := Start(workflowCtx, "action", ServiceKey.String("http/runner"))
+	header := http.Header{}
+	Inject(actionCtx, header)
+	assert.Regexp(t, "^00-"+actionSpan.SpanContext().TraceID().String()+"-"+actionSpan.SpanContext().SpanID().String()+"-01$", header.Get("traceparent"))
+	End(actionSpan, errors.New("connection refused"))
+	End(workflowSpan, nil)
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+	assert.EqualValues(t, "action", spans[0].Name())
+	assert.EqualValues(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.EqualValues(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), StatusKey.String("error"))
+	assert.Contains(t, spans[1].Attributes(), StatusKey.String("ok"))
+}
+
+func TestNewProvider(t *testing.T) {
+	location := path.Join(os.TempDir(), "endly_tracing_test", "spans.jsonl")
+	defer os.RemoveAll(path.Dir(location))
+	provider, err := NewProvider(context.Background(), location)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, span := Start(WithProvider(context.Background(), provider), "workflow", WorkflowKey.String("regression"))
+	End(span, nil)
+	assert.Nil(t, provider.Shutdown(context.Background()))
+	data, err := os.ReadFile(location)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Contains(t, string(data), `"name":"workflow"`)
+	assert.Contains(t, string(data), `"endly.workflow":"regression"`)
+}
@@ -9,6 +9,9 @@ type Action struct {
 	*MetaTag        `yaml:",inline"`
 	*Repeater       `yaml:",inline"`
 	Async           bool   `description:"flag to run action async" yaml:",omitempty"`
+	FailFast        bool   `description:"flag to cancel sibling async actions once this async action fails" yaml:",omitempty"`
+	TimeoutMs       int    `description:"action timeout in ms, cancels service call context once elapsed" yaml:",omitempty"`
+	Retry           *Retry `description:"action retry policy" yaml:",omitempty"`
 	Skip            string `description:"criteria to skip current TagID"  yaml:",omitempty"`
 	skipEvan        eval.Compute
 }
@@ -44,6 +47,14 @@ func (a *Action) Init() error {
 	if err := a.Validate(); err != nil {
 		return err
 	}
+	if a.Retry != nil {
+		if err := a.Retry.Init(); err != nil {
+			return err
+		}
+		if err := a.Retry.Validate(); err != nil {
+			return err
+		}
+	}
 
 	a.initSleepTime()
 	return nil
@@ -71,6 +82,9 @@ func (a *Action) Clone() *Action {
 		MetaTag:        &metaTag,
 		Repeater:       &repeater,
 		Async:          a.Async,
+		FailFast:       a.FailFast,
+		TimeoutMs:      a.TimeoutMs,
+		Retry:          a.Retry,
 		Skip:           a.Skip,
 	}
 }
@@ -79,6 +79,16 @@ func (a *Activities) First() *Activity {
 	return a.Activity
 }
 
+// Clone creates activities copy
+func (a *Activities) Clone() *Activities {
+	a.mux.RLock()
+	defer a.mux.RUnlock()
+	var result = NewActivities()
+	result.activities = append(result.activities, a.activities...)
+	result.Activity = a.Activity
+	return result
+}
+
 // NewActivities creates a new activites
 func NewActivities() *Activities {
 	return &Activities{
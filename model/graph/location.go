@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"github.com/viant/endly/model/graph/yml"
+	"gopkg.in/yaml.v3"
+	"math"
+	"strings"
+)
+
+// Location represents workflow task or action node position
+type Location struct {
+	Path     []string
+	IsAction bool
+	Line     int
+	EndLine  int
+}
+
+// Name returns task or action name
+func (l *Location) Name() string {
+	return l.Path[len(l.Path)-1]
+}
+
+// Locations returns pipeline task and action node locations
+func (n *Node) Locations() []*Location {
+	pipeline := n.Node.Lookup("pipeline")
+	if pipeline == nil {
+		return nil
+	}
+	var result []*Location
+	appendLocations(pipeline, nil, math.MaxInt, &result)
+	return result
+}
+
+// Locate returns the most specific task or action location for supplied line
+func (n *Node) Locate(line int) *Location {
+	var result *Location
+	for _, candidate := range n.Locations() {
+		if candidate.Line <= line && line <= candidate.EndLine {
+			if result == nil || len(candidate.Path) > len(result.Path) {
+				result = candidate
+			}
+		}
+	}
+	return result
+}
+
+func appendLocations(holder *yml.Node, parent []string, endLine int, result *[]*Location) {
+	if holder.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(holder.Content); i += 2 {
+		key := holder.Content[i]
+		value := (*yml.Node)(holder.Content[i+1])
+		if value.Kind != yaml.MappingNode || (reservedKeys[strings.ToLower(key.Value)] && !hasActionNode(value)) {
+			continue
+		}
+		location := &Location{Path: append(append([]string{}, parent...), key.Value), Line: key.Line, EndLine: endLine}
+		if i+2 < len(holder.Content) {
+			location.EndLine = holder.Content[i+2].Line - 1
+		}
+		location.IsAction = value.Lookup("action") != nil || value.Lookup("workflow") != nil
+		*result = append(*result, location)
+		if !location.IsAction {
+			appendLocations(value, location.Path, location.EndLine, result)
+		}
+	}
+}
+
+func hasActionNode(node *yml.Node) bool {
+	if node.Kind != yaml.MappingNode {
+		return false
+	}
+	if node.Lookup("action") != nil || node.Lookup("workflow") != nil {
+		return true
+	}
+	for i := 1; i < len(node.Content); i += 2 {
+		if hasActionNode((*yml.Node)(node.Content[i])) {
+			return true
+		}
+	}
+	return false
+}
@@ -51,3 +51,34 @@ func TestService_LoadWorkflow(t *testing.T) {
 	}
 	assert.Equal(t, []string{"checkSkip", "test"}, templateTask)
 }
+
+func TestNode_Locate(t *testing.T) {
+	srv := New()
+	URL := "embed:///testdata/appx_regression.yaml"
+	workflow, err := srv.LoadWorkflow(context.Background(), URL, &embeddFs)
+	if !assert.Nil(t, err) {
+		return
+	}
+	useCases := []struct {
+		description string
+		line        int
+		expectPath  []string
+		expectFound bool
+	}{
+		{description: "top level action", line: 9, expectPath: []string{"updateArch"}, expectFound: true},
+		{description: "task", line: 18, expectPath: []string{"init"}, expectFound: true},
+		{description: "nested action", line: 30, expectPath: []string{"init", "siteAggregator"}, expectFound: true},
+		{description: "workflow init", line: 2},
+	}
+	for _, useCase := range useCases {
+		location := workflow.Locate(useCase.line)
+		if !useCase.expectFound {
+			assert.Nil(t, location, useCase.description)
+			continue
+		}
+		if !assert.NotNil(t, location, useCase.description) {
+			continue
+		}
+		assert.Equal(t, useCase.expectPath, location.Path, useCase.description)
+	}
+}
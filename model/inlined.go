@@ -32,6 +32,7 @@ const (
 	postKey        = "post"
 	exitKey        = "exit"
 	tagKey         = "tag"
+	parallelKey    = "parallel"
 	defaultPath    = "default"
 )
 
@@ -464,7 +465,7 @@ func (p *Inlined) buildWorkflowNodes(name string, source interface{}, parentTask
 		if isTemplateNode && "template" == textKey {
 			return true
 		}
-		if textKey == loggingKey || textKey == whenKey || textKey == descriptionKey || textKey == failKey { //abstract node attributes
+		if textKey == loggingKey || textKey == whenKey || textKey == descriptionKey || textKey == failKey || textKey == parallelKey { //abstract node attributes
 			nodeAttributes[textKey] = value
 		}
 		flagAsMultiActionIfMatched(textKey, task, value)
@@ -496,6 +497,7 @@ func (p *Inlined) buildWorkflowNodes(name string, source interface{}, parentTask
 						task.Logging = tempTask.Logging
 						task.Description = tempTask.Description
 					}
+					task.Parallel = tempTask.Parallel
 				}
 			}
 		}
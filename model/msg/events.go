@@ -17,6 +17,15 @@ func (e *Events) AsListener() Listener {
 	}
 }
 
+// Drain returns collected events and resets them
+func (e *Events) Drain() []Event {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	result := e.Events
+	e.Events = make([]Event, 0)
+	return result
+}
+
 // NewEvents creates a new events
 func NewEvents() *Events {
 	return &Events{
@@ -63,6 +63,15 @@ func (p *Process) AddTagIDs(tagIDs ...string) {
 	}
 }
 
+// Clone creates a process copy with its own state, activity stack and execution error
+func (p *Process) Clone() *Process {
+	var result = *p
+	result.State = p.State.Clone()
+	result.Activities = p.Activities.Clone()
+	result.ExecutionError = &ExecutionError{}
+	return &result
+}
+
 // NewProcess creates a new workflow, pipeline process
 func NewProcess(source *location.Resource, workflow *Workflow, upstream *Process) *Process {
 	var process = &Process{
@@ -166,6 +175,15 @@ func (p *Processes) First() *Process {
 	return nil
 }
 
+// Clone creates processes stack copy
+func (p *Processes) Clone() *Processes {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+	var result = NewProcesses()
+	result.processes = append(result.processes, p.processes...)
+	return result
+}
+
 // NewProcesses creates a new processes
 func NewProcesses() *Processes {
 	return &Processes{
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/criteria"
+	"github.com/viant/endly/model/criteria/eval"
+	"math/rand"
+	"regexp"
+	"time"
+)
+
+const (
+	defaultRetryAttempts   = 3
+	defaultRetryDelayMs    = 1000
+	defaultRetryMultiplier = 2.0
+)
+
+// Retry represents action retry policy
+type Retry struct {
+	MaxAttempts int     `description:"max number of attempts including the first one, default 3" yaml:",omitempty"`
+	DelayMs     int     `description:"delay before the first retry in ms, default 1000" yaml:",omitempty"`
+	MaxDelayMs  int     `description:"max delay between attempts in ms" yaml:",omitempty"`
+	Multiplier  float64 `description:"delay multiplier applied after each attempt, default 2 (exponential backoff), 1 for constant delay" yaml:",omitempty"`
+	Jitter      float64 `description:"delay randomization factor between 0 and 1, i.e 0.2 randomizes delay by +/-20%" yaml:",omitempty"`
+	OnError     string  `description:"regular expression matching error to retry on, any error is retried if empty" yaml:",omitempty"`
+	When        string  `description:"criteria to retry on, evaluated with $error and $response" yaml:",omitempty"`
+	onError     *regexp.Regexp
+	whenEval    eval.Compute
+}
+
+// Init initializes retry policy
+func (r *Retry) Init() error {
+	if r.MaxAttempts == 0 {
+		r.MaxAttempts = defaultRetryAttempts
+	}
+	if r.DelayMs == 0 {
+		r.DelayMs = defaultRetryDelayMs
+	}
+	if r.Multiplier == 0 {
+		r.Multiplier = defaultRetryMultiplier
+	}
+	if r.OnError != "" {
+		var err error
+		if r.onError, err = regexp.Compile(r.OnError); err != nil {
+			return fmt.Errorf("invalid retry onError expression: %v, %w", r.OnError, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks if retry policy is valid
+func (r *Retry) Validate() error {
+	if r.MaxAttempts < 0 {
+		return fmt.Errorf("invalid retry maxAttempts: %v", r.MaxAttempts)
+	}
+	if r.Jitter < 0 || r.Jitter > 1 {
+		return fmt.Errorf("invalid retry jitter: %v, expected value between 0 and 1", r.Jitter)
+	}
+	return nil
+}
+
+// Delay returns delay before next attempt, attempt starts with 1
+func (r *Retry) Delay(attempt int) time.Duration {
+	delay := float64(r.DelayMs)
+	for i := 1; i < attempt; i++ {
+		delay *= r.Multiplier
+		if r.MaxDelayMs > 0 && delay >= float64(r.MaxDelayMs) {
+			break
+		}
+	}
+	if r.MaxDelayMs > 0 && delay > float64(r.MaxDelayMs) {
+		delay = float64(r.MaxDelayMs)
+	}
+	if r.Jitter > 0 {
+		delay += delay * r.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay) * time.Millisecond
+}
+
+// ShouldRetry returns true if action outcome matches retry policy, attempt starts with 1
+func (r *Retry) ShouldRetry(context *endly.Context, attempt int, response interface{}, err error) (bool, error) {
+	if attempt >= r.MaxAttempts {
+		return false, nil
+	}
+	return r.Matches(context, response, err)
+}
+
+// Matches returns true if action outcome matches retry condition
+func (r *Retry) Matches(context *endly.Context, response interface{}, err error) (bool, error) {
+	if err != nil && r.onError != nil && !r.onError.MatchString(err.Error()) {
+		return false, nil
+	}
+	if r.When == "" {
+		return err != nil, nil
+	}
+	var state = context.State()
+	state = state.Clone()
+	state.Put("response", response)
+	state.Put("error", "")
+	if err != nil {
+		state.Put("error", err.Error())
+	}
+	return criteria.Evaluate(context, state, r.When, &r.whenEval, "Retry.When", false)
+}
@@ -0,0 +1,76 @@
+package model_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox"
+	"testing"
+	"time"
+)
+
+func TestRetry_Delay(t *testing.T) {
+	var useCases = []struct {
+		description string
+		retry       *model.Retry
+		expect      []time.Duration
+	}{
+		{
+			description: "exponential backoff",
+			retry:       &model.Retry{MaxAttempts: 5, DelayMs: 100},
+			expect:      []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond},
+		},
+		{
+			description: "max delay",
+			retry:       &model.Retry{MaxAttempts: 5, DelayMs: 100, MaxDelayMs: 300},
+			expect:      []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond},
+		},
+		{
+			description: "constant delay",
+			retry:       &model.Retry{MaxAttempts: 3, DelayMs: 50, Multiplier: 1},
+			expect:      []time.Duration{50 * time.Millisecond, 50 * time.Millisecond},
+		},
+	}
+	for _, useCase := range useCases {
+		assert.Nil(t, useCase.retry.Init(), useCase.description)
+		for i, expect := range useCase.expect {
+			assert.EqualValues(t, expect, useCase.retry.Delay(i+1), useCase.description)
+		}
+	}
+}
+
+func TestRetry_ShouldRetry(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	var useCases = []struct {
+		description string
+		retry       *model.Retry
+		attempt     int
+		response    interface{}
+		err         error
+		expect      bool
+	}{
+		{description: "error", retry: &model.Retry{MaxAttempts: 3}, attempt: 1, err: errors.New("connection refused"), expect: true},
+		{description: "no error", retry: &model.Retry{MaxAttempts: 3}, attempt: 1},
+		{description: "max attempts", retry: &model.Retry{MaxAttempts: 3}, attempt: 3, err: errors.New("connection refused")},
+		{description: "error matched", retry: &model.Retry{MaxAttempts: 3, OnError: "refused"}, attempt: 1, err: errors.New("connection refused"), expect: true},
+		{description: "error not matched", retry: &model.Retry{MaxAttempts: 3, OnError: "refused"}, attempt: 1, err: errors.New("not found")},
+		{description: "criteria matched", retry: &model.Retry{MaxAttempts: 3, When: "$response.Status:503"}, attempt: 1, response: map[string]interface{}{"Status": 503}, expect: true},
+		{description: "criteria not matched", retry: &model.Retry{MaxAttempts: 3, When: "$response.Status:503"}, attempt: 1, response: map[string]interface{}{"Status": 200}},
+	}
+	for _, useCase := range useCases {
+		assert.Nil(t, useCase.retry.Init(), useCase.description)
+		actual, err := useCase.retry.ShouldRetry(context, useCase.attempt, useCase.response, useCase.err)
+		assert.Nil(t, err, useCase.description)
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
+
+func TestRetry_Init(t *testing.T) {
+	retry := &model.Retry{}
+	assert.Nil(t, retry.Init())
+	assert.EqualValues(t, 3, retry.MaxAttempts)
+	assert.EqualValues(t, 1000, retry.DelayMs)
+	assert.EqualValues(t, 2.0, retry.Multiplier)
+}
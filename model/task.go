@@ -7,6 +7,7 @@ type Task struct {
 	*TasksNode    ` yaml:",inline"`
 	Fail          bool      ` yaml:",omitempty"` //controls if return fail status workflow on catch task
 	Template      *Template ` yaml:",omitempty"`
+	Parallel      int       ` yaml:",omitempty"` //max number of use cases (actions grouped by TagID) running concurrently
 	//internal only for inline workflow meta data
 
 	multiAction bool //flag directing grouping actions (otherwise each action has its own task)
@@ -33,6 +33,7 @@ type TransientTemplate struct {
 	Description string            `description:"reference to file containing tagDescription i.e. @use_case,  file reference has to start with @"`
 	Data        map[string]string `description:"map of data references, where key is workflow.data target, and value is a file within expanded dynamically subpath or workflow path fallback. Value has to start with @"`
 	Template    []interface{}     `description:"template to expand"`
+	Parallel    int               `description:"max number of expanded use cases running concurrently"`
 	inline      *Inlined
 }
 
@@ -68,6 +69,7 @@ func (t *TransientTemplate) Expand(task *Task, parentTag string, inline *Inlined
 
 	tag := buildTag(t, inline)
 	task.multiAction = true
+	task.Parallel = t.Parallel
 	iterator := tag.Iterator
 	var workflowData = data.Map(t.inline.Data)
 	for tag.HasActiveIterator() {
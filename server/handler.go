@@ -0,0 +1,292 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/viant/endly/service/meta"
+	"github.com/viant/endly/service/workflow"
+	"github.com/viant/toolbox"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// ServiceURI represents service action route prefix
+	ServiceURI = "/v1/endly/service/"
+	// RunURI represents workflow runs route
+	RunURI = "/v1/endly/runs"
+	// OpenAPIURI represents service actions OpenAPI document route
+	OpenAPIURI = "/v1/endly/openapi"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     sameOrigin,
+}
+
+// sameOrigin returns true if websocket request has no origin (non browser client) or origin matches request host
+func sameOrigin(request *http.Request) bool {
+	origin := request.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(originURL.Host, request.Host)
+}
+
+// ErrorResponse represents error response
+type ErrorResponse struct {
+	Error string
+}
+
+func (s *Server) routes() *http.ServeMux {
+	router := toolbox.NewServiceRouter(
+		toolbox.ServiceRouting{
+			HTTPMethod:     "POST",
+			URI:            ServiceURI + "{service}/{action}/",
+			Handler:        s.requestService,
+			HandlerInvoker: s.routeHandler,
+			Parameters:     []string{"service", "action", "@httpRequest", "@httpResponseWriter"},
+		})
+	mux := http.NewServeMux()
+	mux.HandleFunc(ServiceURI, func(response http.ResponseWriter, request *http.Request) {
+		if err := router.Route(response, request); err != nil {
+			response.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("POST "+RunURI, s.handleStartRun)
+	mux.HandleFunc("GET "+RunURI, s.handleListRuns)
+	mux.HandleFunc("GET "+RunURI+"/{id}", s.handleGetRun)
+	mux.HandleFunc("DELETE "+RunURI+"/{id}", s.handleCancelRun)
+	mux.HandleFunc("POST "+RunURI+"/{id}/cancel", s.handleCancelRun)
+	mux.HandleFunc("GET "+RunURI+"/{id}/events", s.handleEvents)
+	mux.HandleFunc("GET "+OpenAPIURI, s.handleOpenAPI)
+	return mux
+}
+
+// authorize requires bearer token if server token is configured, access_token query parameter is accepted for event streams
+func (s *Server) authorize(handler http.Handler) http.Handler {
+	if s.token == "" {
+		return handler
+	}
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		token := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+		if token == "" && isEventStream(request) {
+			token = request.URL.Query().Get("access_token")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			response.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(response, http.StatusUnauthorized, &ErrorResponse{Error: "unauthorized"})
+			return
+		}
+		handler.ServeHTTP(response, request)
+	})
+}
+
+// isEventStream returns true for run events route, browser event source and websocket clients can not set authorization header
+func isEventStream(request *http.Request) bool {
+	return request.Method == http.MethodGet && strings.HasPrefix(request.URL.Path, RunURI+"/") && strings.HasSuffix(request.URL.Path, "/events")
+}
+
+// validateRemoteRun rejects run options writing files or opening listeners on the server
+func validateRemoteRun(request *workflow.RunRequest) error {
+	var options = []struct {
+		name  string
+		value string
+	}{
+		{"EventLog", request.EventLog},
+		{"CheckpointDirectory", request.CheckpointDirectory},
+		{"TraceEndpoint", request.TraceEndpoint},
+		{"DebugAddress", request.DebugAddress},
+	}
+	for _, option := range options {
+		if option.value != "" {
+			return fmt.Errorf("%v is not supported for remote run", option.name)
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleStartRun(response http.ResponseWriter, request *http.Request) {
+	runRequest := &workflow.RunRequest{}
+	if err := json.NewDecoder(request.Body).Decode(runRequest); err != nil {
+		writeJSON(response, http.StatusBadRequest, &ErrorResponse{Error: fmt.Sprintf("invalid run request: %v", err)})
+		return
+	}
+	err := validateRemoteRun(runRequest)
+	if err == nil {
+		if err = loadInlineWorkflow(runRequest); err == nil { //inline workflow document can define run options too
+			err = validateRemoteRun(runRequest)
+		}
+	}
+	if err != nil {
+		writeJSON(response, http.StatusBadRequest, &ErrorResponse{Error: err.Error()})
+		return
+	}
+	run, err := s.StartRun(runRequest)
+	if errors.Is(err, errShuttingDown) {
+		writeJSON(response, http.StatusServiceUnavailable, &ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeJSON(response, http.StatusBadRequest, &ErrorResponse{Error: err.Error()})
+		return
+	}
+	response.Header().Set("Location", RunURI+"/"+run.ID)
+	writeJSON(response, http.StatusAccepted, run.Snapshot())
+}
+
+func (s *Server) handleListRuns(response http.ResponseWriter, request *http.Request) {
+	var result = make([]*Run, 0)
+	for _, run := range s.runs.list() {
+		result = append(result, run.Snapshot())
+	}
+	writeJSON(response, http.StatusOK, result)
+}
+
+func (s *Server) handleGetRun(response http.ResponseWriter, request *http.Request) {
+	run, ok := s.lookupRun(response, request)
+	if !ok {
+		return
+	}
+	writeJSON(response, http.StatusOK, run.Snapshot())
+}
+
+func (s *Server) handleCancelRun(response http.ResponseWriter, request *http.Request) {
+	run, ok := s.lookupRun(response, request)
+	if !ok {
+		return
+	}
+	if !run.Cancel() {
+		writeJSON(response, http.StatusConflict, &ErrorResponse{Error: fmt.Sprintf("run %v is not running", run.ID)})
+		return
+	}
+	select {
+	case <-run.Done():
+		writeJSON(response, http.StatusOK, run.Snapshot())
+	case <-time.After(s.cancelTimeout): //run still stops, cancel is reported as accepted
+		writeJSON(response, http.StatusAccepted, run.Snapshot())
+	case <-request.Context().Done():
+	}
+}
+
+// handleEvents streams run events as server sent events or over websocket if upgrade was requested
+func (s *Server) handleEvents(response http.ResponseWriter, request *http.Request) {
+	run, ok := s.lookupRun(response, request)
+	if !ok {
+		return
+	}
+	offset := toolbox.AsInt(request.URL.Query().Get("offset"))
+	if lastEventID := request.Header.Get("Last-Event-ID"); lastEventID != "" {
+		offset = toolbox.AsInt(lastEventID)
+	}
+	if websocket.IsWebSocketUpgrade(request) {
+		s.streamWebsocket(run, offset, response, request)
+		return
+	}
+	s.streamSSE(run, offset, response, request)
+}
+
+func (s *Server) streamSSE(run *Run, offset int, response http.ResponseWriter, request *http.Request) {
+	flusher, ok := response.(http.Flusher)
+	if !ok {
+		writeJSON(response, http.StatusInternalServerError, &ErrorResponse{Error: "streaming is not supported"})
+		return
+	}
+	response.Header().Set("Content-Type", "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.WriteHeader(http.StatusOK)
+	err := s.stream(run, offset, request, func(event *Event) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(response, "id: %d\nevent: %v\ndata: %s\n\n", event.Seq, event.Type, data)
+		flusher.Flush()
+		return err
+	})
+	if err == nil {
+		data, _ := json.Marshal(run.Snapshot())
+		_, _ = fmt.Fprintf(response, "event: end\ndata: %s\n\n", data)
+		flusher.Flush()
+	}
+}
+
+func (s *Server) streamWebsocket(run *Run, offset int, response http.ResponseWriter, request *http.Request) {
+	conn, err := upgrader.Upgrade(response, request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	if err = s.stream(run, offset, request, func(event *Event) error {
+		return conn.WriteJSON(event)
+	}); err == nil {
+		_ = conn.WriteJSON(&Event{Type: "end", Value: run.Snapshot()})
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}
+}
+
+// stream writes run events starting from offset until run completes, client disconnects or server shuts down
+func (s *Server) stream(run *Run, offset int, request *http.Request, write func(event *Event) error) error {
+	for {
+		events, changed, completed := run.Events(offset)
+		for _, event := range events {
+			if err := write(event); err != nil {
+				return err
+			}
+		}
+		offset += len(events)
+		if completed {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-request.Context().Done():
+			return request.Context().Err()
+		case <-s.streams.Done():
+			return s.streams.Err()
+		}
+	}
+}
+
+// handleOpenAPI returns OpenAPI document for all service actions, yaml is returned for format=yaml query parameter
+func (s *Server) handleOpenAPI(response http.ResponseWriter, request *http.Request) {
+	service := &meta.Service{Manager: s.manager}
+	document, err := service.OpenAPI(ServiceURI)
+	if err != nil {
+		writeJSON(response, http.StatusInternalServerError, &ErrorResponse{Error: err.Error()})
+		return
+	}
+	if request.URL.Query().Get("format") == "yaml" {
+		response.Header().Set("Content-Type", "application/yaml")
+		_ = yaml.NewEncoder(response).Encode(document)
+		return
+	}
+	writeJSON(response, http.StatusOK, document)
+}
+
+func (s *Server) lookupRun(response http.ResponseWriter, request *http.Request) (*Run, bool) {
+	ID := request.PathValue("id")
+	run, ok := s.runs.get(ID)
+	if !ok {
+		writeJSON(response, http.StatusNotFound, &ErrorResponse{Error: fmt.Sprintf("unknown run: %v", ID)})
+	}
+	return run, ok
+}
+
+func writeJSON(response http.ResponseWriter, status int, value interface{}) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(status)
+	_ = json.NewEncoder(response).Encode(value)
+}
@@ -0,0 +1,34 @@
+package server
+
+import "time"
+
+// Option represents server option
+type Option func(s *Server)
+
+// WithToken sets bearer token required by all API requests
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithShutdownTimeout sets max time graceful shutdown waits for active runs before canceling them
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// WithRunTTL sets how long completed runs are kept for status and event requests
+func WithRunTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.runTTL = ttl
+	}
+}
+
+// WithCancelTimeout sets max time cancel request waits for run completion
+func WithCancelTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.cancelTimeout = timeout
+	}
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/endly/service/workflow"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// RunStatusRunning represents running workflow status
+	RunStatusRunning = "running"
+	// RunStatusSucceeded represents succeeded workflow status
+	RunStatusSucceeded = "succeeded"
+	// RunStatusFailed represents failed workflow status
+	RunStatusFailed = "failed"
+	// RunStatusCanceled represents canceled workflow status
+	RunStatusCanceled = "canceled"
+)
+
+// Event represents streamed run event
+type Event struct {
+	Seq       int
+	Type      string
+	Timestamp time.Time
+	Value     interface{}
+}
+
+// Run represents asynchronous workflow run
+type Run struct {
+	ID        string
+	Status    string
+	Error     string `json:",omitempty"`
+	Started   time.Time
+	Ended     *time.Time             `json:",omitempty"`
+	Data      map[string]interface{} `json:",omitempty"`
+	mux       sync.RWMutex
+	events    []*Event
+	changed   chan bool
+	done      chan bool
+	cancel    func()
+	canceled  bool
+	isRunning bool
+}
+
+// Snapshot returns run copy safe to encode
+func (r *Run) Snapshot() *Run {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	return &Run{ID: r.ID, Status: r.Status, Error: r.Error, Started: r.Started, Ended: r.Ended, Data: r.Data}
+}
+
+// Cancel cancels the run
+func (r *Run) Cancel() bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if !r.isRunning {
+		return false
+	}
+	r.canceled = true
+	r.cancel()
+	return true
+}
+
+// Done returns channel closed once run completes
+func (r *Run) Done() <-chan bool {
+	return r.done
+}
+
+// Events returns events starting from supplied offset and channel notified on new events
+func (r *Run) Events(offset int) ([]*Event, <-chan bool, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	var result []*Event
+	if offset < len(r.events) {
+		result = r.events[offset:]
+	}
+	return result, r.changed, !r.isRunning
+}
+
+func (r *Run) publish(event msg.Event) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.events = append(r.events, &Event{Seq: len(r.events) + 1, Type: event.Type(), Timestamp: event.Timestamp(), Value: encodableValue(event.Value())})
+	r.notify()
+}
+
+// notify wakes up event subscribers, it has to be called with lock held
+func (r *Run) notify() {
+	close(r.changed)
+	r.changed = make(chan bool)
+}
+
+func (r *Run) complete(response *workflow.RunResponse, err error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	now := time.Now()
+	r.Ended = &now
+	r.isRunning = false
+	switch {
+	case r.canceled:
+		r.Status = RunStatusCanceled
+	case err != nil:
+		r.Status = RunStatusFailed
+	default:
+		r.Status = RunStatusSucceeded
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if response != nil {
+		r.Data = encodableMap(response.Data)
+	}
+	r.notify()
+	close(r.done)
+}
+
+func encodableValue(value interface{}) interface{} {
+	if _, err := json.Marshal(value); err == nil {
+		return value
+	}
+	if messenger, ok := value.(msg.Reporter); ok {
+		var result = make([]string, 0)
+		for _, message := range messenger.Messages() {
+			for _, item := range message.Items {
+				result = append(result, item.Text)
+			}
+		}
+		return result
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func encodableMap(source map[string]interface{}) map[string]interface{} {
+	var result = make(map[string]interface{})
+	for k, v := range source {
+		result[k] = encodableValue(v)
+	}
+	return result
+}
+
+// runs represents workflow run registry
+type runs struct {
+	mux      sync.RWMutex
+	registry map[string]*Run
+}
+
+func (r *runs) put(run *Run) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.registry[run.ID] = run
+}
+
+func (r *runs) delete(ID string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	delete(r.registry, ID)
+}
+
+func (r *runs) get(ID string) (*Run, bool) {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	run, ok := r.registry[ID]
+	return run, ok
+}
+
+func (r *runs) list() []*Run {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+	var result = make([]*Run, 0, len(r.registry))
+	for _, run := range r.registry {
+		result = append(result, run)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Started.Before(result[j].Started)
+	})
+	return result
+}
+
+func newRuns() *runs {
+	return &runs{registry: make(map[string]*Run)}
+}
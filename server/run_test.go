@@ -0,0 +1,205 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly/service/workflow"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServer_Runs(t *testing.T) {
+	srv := New("0", WithToken("secret"))
+	if !assert.Nil(t, srv.Listen()) {
+		return
+	}
+	go srv.Start()
+	defer srv.Shutdown(context.Background())
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d%v", srv.Addr().(*net.TCPAddr).Port, RunURI)
+
+	var useCases = []struct {
+		description  string
+		token        string
+		URL          string
+		expectStatus string
+		expectEvent  string
+		cancel       bool
+	}{
+		{description: "unauthorized", token: "invalid", URL: "testdata/print.yaml"},
+		{description: "succeeded", token: "secret", URL: "testdata/print.yaml", expectStatus: RunStatusSucceeded, expectEvent: "hello endly"},
+		{description: "canceled", token: "secret", URL: "testdata/sleep.yaml", expectStatus: RunStatusCanceled, cancel: true},
+	}
+	for _, useCase := range useCases {
+		body := fmt.Sprintf(`{"URL":%q, "Params":{"name":"endly"}}`, useCase.URL)
+		response, err := call(http.MethodPost, baseURL, useCase.token, body)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		if useCase.expectStatus == "" {
+			assert.EqualValues(t, http.StatusUnauthorized, response.StatusCode, useCase.description)
+			continue
+		}
+		if !assert.EqualValues(t, http.StatusAccepted, response.StatusCode, useCase.description) {
+			continue
+		}
+		run := &Run{}
+		_ = json.NewDecoder(response.Body).Decode(run)
+		if useCase.cancel {
+			time.Sleep(200 * time.Millisecond)
+			response, err = call(http.MethodDelete, baseURL+"/"+run.ID, useCase.token, "")
+			assert.Nil(t, err, useCase.description)
+			assert.EqualValues(t, http.StatusOK, response.StatusCode, useCase.description)
+		}
+		response, err = call(http.MethodGet, baseURL+"/"+run.ID+"/events", useCase.token, "")
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		events, _ := io.ReadAll(response.Body)
+		assert.True(t, strings.Contains(string(events), "event: end"), useCase.description)
+		if useCase.expectEvent != "" {
+			assert.True(t, strings.Contains(string(events), useCase.expectEvent), useCase.description)
+		}
+		response, err = call(http.MethodGet, baseURL+"/"+run.ID, useCase.token, "")
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		run = &Run{}
+		_ = json.NewDecoder(response.Body).Decode(run)
+		assert.EqualValues(t, useCase.expectStatus, run.Status, useCase.description)
+		if useCase.expectStatus == RunStatusSucceeded {
+			assert.EqualValues(t, "hello endly", run.Data["greeting"], useCase.description)
+		}
+	}
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	srv := New("0", WithToken("secret"), WithShutdownTimeout(200*time.Millisecond))
+	if !assert.Nil(t, srv.Listen()) {
+		return
+	}
+	started := make(chan error, 1)
+	go func() { started <- srv.Start() }()
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d%v", srv.Addr().(*net.TCPAddr).Port, RunURI)
+
+	response, err := call(http.MethodPost, baseURL, "secret", `{"URL":"testdata/sleep.yaml"}`)
+	if !assert.Nil(t, err) || !assert.EqualValues(t, http.StatusAccepted, response.StatusCode) {
+		return
+	}
+	run := &Run{}
+	_ = json.NewDecoder(response.Body).Decode(run)
+	events, err := call(http.MethodGet, baseURL+"/"+run.ID+"/events", "secret", "")
+	if !assert.Nil(t, err) {
+		return
+	}
+	streamed := make(chan bool)
+	go func() {
+		_, _ = io.ReadAll(events.Body)
+		close(streamed)
+	}()
+
+	go srv.Shutdown(context.Background())
+	select {
+	case err = <-started:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "start did not return after shutdown")
+		return
+	}
+	select {
+	case <-streamed:
+	case <-time.After(time.Second):
+		assert.Fail(t, "event stream was not closed on shutdown")
+	}
+	actual, ok := srv.Run(run.ID)
+	if assert.True(t, ok) {
+		assert.EqualValues(t, RunStatusCanceled, actual.Snapshot().Status)
+	}
+	_, err = srv.StartRun(&workflow.RunRequest{URL: "testdata/print.yaml"})
+	assert.Equal(t, errShuttingDown, err)
+}
+
+func TestServer_RunTTL(t *testing.T) {
+	srv := New("0", WithRunTTL(50*time.Millisecond))
+	run, err := srv.StartRun(&workflow.RunRequest{URL: "testdata/print.yaml", Params: map[string]interface{}{"name": "endly"}})
+	if !assert.Nil(t, err) {
+		return
+	}
+	<-run.Done()
+	_, ok := srv.Run(run.ID)
+	assert.True(t, ok)
+	time.Sleep(200 * time.Millisecond)
+	_, ok = srv.Run(run.ID)
+	assert.False(t, ok)
+}
+
+func TestServer_Security(t *testing.T) {
+	local := New("0")
+	if assert.Nil(t, local.Listen()) {
+		assert.True(t, local.Addr().(*net.TCPAddr).IP.IsLoopback(), "server without token listens on loopback")
+		_ = local.listener.Close()
+	}
+
+	srv := New("0", WithToken("secret"))
+	if !assert.Nil(t, srv.Listen()) {
+		return
+	}
+	go srv.Start()
+	defer srv.Shutdown(context.Background())
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d%v", srv.Addr().(*net.TCPAddr).Port, RunURI)
+
+	response, err := http.Post(baseURL+"?access_token=secret", "application/json", strings.NewReader(`{"URL":"testdata/print.yaml"}`))
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, http.StatusUnauthorized, response.StatusCode, "query token outside event stream")
+	}
+	for _, body := range []string{
+		`{"URL":"testdata/print.yaml", "EventLog":"/tmp/events.jsonl"}`,
+		`{"URL":"testdata/print.yaml", "CheckpointDirectory":"/tmp"}`,
+		`{"URL":"testdata/print.yaml", "TraceEndpoint":"/tmp/spans.jsonl"}`,
+		`{"URL":"testdata/print.yaml", "DebugAddress":":4711"}`,
+	} {
+		response, err = call(http.MethodPost, baseURL, "secret", body)
+		if assert.Nil(t, err, body) {
+			assert.EqualValues(t, http.StatusBadRequest, response.StatusCode, body)
+		}
+	}
+
+	response, err = call(http.MethodPost, baseURL, "secret", `{"URL":"testdata/print.yaml", "Params":{"name":"endly"}}`)
+	if !assert.Nil(t, err) || !assert.EqualValues(t, http.StatusAccepted, response.StatusCode) {
+		return
+	}
+	run := &Run{}
+	_ = json.NewDecoder(response.Body).Decode(run)
+	eventsURL := baseURL + "/" + run.ID + "/events?access_token=secret"
+	response, err = http.Get(eventsURL)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, http.StatusOK, response.StatusCode, "query token for event stream")
+		_, _ = io.ReadAll(response.Body)
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(eventsURL, "http")
+	_, response, err = websocket.DefaultDialer.Dial(wsURL, http.Header{"Origin": []string{"http://evil.example.com"}})
+	if assert.NotNil(t, err, "cross origin websocket") && assert.NotNil(t, response) {
+		assert.EqualValues(t, http.StatusForbidden, response.StatusCode)
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, http.Header{"Origin": []string{"http://" + response.Request.URL.Host}})
+	if assert.Nil(t, err, "same origin websocket") {
+		_ = conn.Close()
+	}
+}
+
+func call(method, URL, token, body string) (*http.Response, error) {
+	request, err := http.NewRequest(method, URL, bytes.NewBufferString(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	return http.DefaultClient.Do(request)
+}
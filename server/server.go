@@ -1,14 +1,32 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/location"
+	"github.com/viant/endly/service/workflow"
 	"github.com/viant/toolbox"
 	"log"
+	"net"
 	"net/http"
+	"sync"
+	"time"
 )
 
+const (
+	defaultShutdownTimeout = 30 * time.Second
+	defaultRunTTL          = time.Hour
+	defaultCancelTimeout   = 10 * time.Second
+	// loopbackHost represents host server binds to when no token is configured
+	loopbackHost = "127.0.0.1"
+)
+
+var errShuttingDown = errors.New("server is shutting down")
+
 // Request represents service request.
 type Request struct {
 	Data           map[string]interface{}
@@ -25,8 +43,23 @@ type Response struct {
 
 // Server represents a server
 type Server struct {
-	port    string
-	manager endly.Manager
+	host            string
+	port            string
+	token           string
+	shutdownTimeout time.Duration
+	runTTL          time.Duration
+	cancelTimeout   time.Duration
+	manager         endly.Manager
+	runs            *runs
+	active          sync.WaitGroup
+	httpServer      *http.Server
+	listener        net.Listener
+	mux             sync.Mutex
+	closing         bool
+	streams         context.Context //canceled on shutdown to end event streams
+	cancelStreams   func()
+	stopped         chan bool
+	stopOnce        sync.Once
 }
 
 func (s *Server) requestService(serviceName, action string, httpRequest *http.Request, httpResponse http.ResponseWriter) (*Response, error) {
@@ -98,33 +131,161 @@ func (s *Server) routeHandler(serviceRouting *toolbox.ServiceRouting, httpReques
 
 }
 
-// Start starts server
-func (s *Server) Start() error {
+// loadInlineWorkflow loads inline workflow pipeline from request URL, request params override workflow document params
+func loadInlineWorkflow(request *workflow.RunRequest) error {
+	if request.URL == "" || request.Inlined != nil {
+		return nil
+	}
+	params := request.Params
+	resource := location.NewResource(request.URL)
+	if err := resource.Decode(request); err != nil {
+		return fmt.Errorf("failed to load workflow: %v, %w", request.URL, err)
+	}
+	if request.Inlined == nil || len(request.Pipeline) == 0 {
+		return nil
+	}
+	request.Source = resource
+	request.AssetURL = resource.URL
+	if request.Name == "" {
+		request.Name = model.WorkflowSelector(resource.URL).Name()
+	}
+	if len(request.Params) == 0 {
+		request.Params = map[string]interface{}{}
+	}
+	for k, v := range params {
+		request.Params[k] = v
+	}
+	return nil
+}
 
-	router := toolbox.NewServiceRouter(
-		toolbox.ServiceRouting{
-			HTTPMethod:     "POST",
-			URI:            "/v1/endly/service/{service}/{action}/",
-			Handler:        s.requestService,
-			HandlerInvoker: s.routeHandler,
-			Parameters:     []string{"service", "action", "@httpRequest", "@httpResponseWriter"},
-		})
+// StartRun starts workflow run asynchronously
+func (s *Server) StartRun(request *workflow.RunRequest) (*Run, error) {
+	if err := loadInlineWorkflow(request); err != nil {
+		return nil, err
+	}
+	if err := request.Init(); err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	s.mux.Lock()
+	if s.closing {
+		s.mux.Unlock()
+		return nil, errShuttingDown
+	}
+	s.active.Add(1)
+	s.mux.Unlock()
+	context, cancel := s.manager.NewContext(toolbox.NewContext()).WithCancel()
+	run := &Run{
+		ID:        context.SessionID,
+		Status:    RunStatusRunning,
+		Started:   time.Now(),
+		changed:   make(chan bool),
+		done:      make(chan bool),
+		cancel:    cancel,
+		isRunning: true,
+	}
+	context.SetListener(run.publish)
+	request.Async = false
+	s.runs.put(run)
+	go func() {
+		defer s.active.Done()
+		defer context.Close()
+		defer cancel()
+		response := &workflow.RunResponse{}
+		err := endly.Run(context, request, response)
+		run.complete(response, err)
+		time.AfterFunc(s.runTTL, func() { s.runs.delete(run.ID) })
+	}()
+	return run, nil
+}
 
-	http.HandleFunc("/v1/", func(response http.ResponseWriter, reader *http.Request) {
-		err := router.Route(response, reader)
-		if err != nil {
-			response.WriteHeader(http.StatusInternalServerError)
+// Run returns run for supplied ID
+func (s *Server) Run(ID string) (*Run, bool) {
+	return s.runs.get(ID)
+}
+
+// Listen opens server listener, it is called by Start if needed, server without token listens on loopback interface only
+func (s *Server) Listen() (err error) {
+	if s.listener != nil {
+		return nil
+	}
+	if s.token == "" {
+		log.Printf("ENDLY_TOKEN is not set, endly server accepts only local connections")
+	}
+	s.listener, err = net.Listen("tcp", net.JoinHostPort(s.host, s.port))
+	return err
+}
+
+// Start starts server, it returns once server is shut down and active runs are drained
+func (s *Server) Start() (err error) {
+	if err = s.Listen(); err != nil {
+		return err
+	}
+	log.Printf("endly server listening on %v\n", s.listener.Addr())
+	if err = s.httpServer.Serve(s.listener); errors.Is(err, http.ErrServerClosed) {
+		<-s.stopped
+		return nil
+	}
+	return err
+}
+
+// Addr returns server listening address
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Shutdown rejects new runs, waits for active runs up to shutdown timeout, then cancels remaining runs,
+// ends event streams and stops HTTP server within shutdown timeout
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mux.Lock()
+	s.closing = true
+	s.mux.Unlock()
+	defer s.stopOnce.Do(func() { close(s.stopped) })
+	ctx, cancel := context.WithTimeout(ctx, s.shutdownTimeout)
+	defer cancel()
+	done := make(chan bool)
+	go func() {
+		s.active.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		for _, run := range s.runs.list() {
+			run.Cancel()
 		}
-	})
-	fmt.Printf("Started test server on port %v\n", s.port)
-	log.Fatal(http.ListenAndServe(":"+s.port, nil))
-	return nil
+		<-done
+	}
+	s.cancelStreams()
+	closeCtx, cancelClose := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancelClose()
+	err := s.httpServer.Shutdown(closeCtx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = s.httpServer.Close()
+	}
+	return err
 }
 
-// New createss a new server for provided port.
-func New(port string) *Server {
-	return &Server{
-		port:    port,
-		manager: endly.New(),
+// New creates a new server for provided port.
+func New(port string, options ...Option) *Server {
+	result := &Server{
+		port:            port,
+		manager:         endly.New(),
+		runs:            newRuns(),
+		shutdownTimeout: defaultShutdownTimeout,
+		runTTL:          defaultRunTTL,
+		cancelTimeout:   defaultCancelTimeout,
+		stopped:         make(chan bool),
+	}
+	result.streams, result.cancelStreams = context.WithCancel(context.Background())
+	for _, option := range options {
+		option(result)
+	}
+	if result.token == "" { //unauthenticated API is not exposed to the network
+		result.host = loopbackHost
 	}
+	result.httpServer = &http.Server{Handler: result.authorize(result.routes())}
+	return result
 }
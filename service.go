@@ -2,6 +2,7 @@ package endly
 
 import (
 	"fmt"
+	"github.com/viant/endly/internal/tracing"
 	_ "github.com/viant/endly/internal/unsafe"
 	"github.com/viant/endly/model/location"
 	"github.com/viant/endly/model/msg"
@@ -76,6 +77,7 @@ func (s *AbstractService) Run(context *Context, request interface{}) (response *
 	response = &ServiceResponse{Status: "ok"}
 	startEvent := s.Begin(context, request)
 	var err error
+	var endSpan = func(err error) {}
 	defer func() {
 		s.End(context)(startEvent, response.Response)
 		if err != nil {
@@ -83,6 +85,7 @@ func (s *AbstractService) Run(context *Context, request interface{}) (response *
 			response.Status = "error"
 			response.Error = fmt.Sprintf("%v", err)
 		}
+		endSpan(response.Err)
 	}()
 	service, ok := s.routeByRequest[reflect.TypeOf(request)]
 	if !ok {
@@ -93,6 +96,7 @@ func (s *AbstractService) Run(context *Context, request interface{}) (response *
 			return response
 		}
 	}
+	endSpan = context.StartSpan(s.ID()+":"+service.Action, tracing.ServiceKey.String(s.ID()), tracing.ActionKey.String(service.Action))
 
 	if initializer, ok := request.(Initializer); ok {
 		if err = initializer.Init(); err != nil {
@@ -130,7 +134,7 @@ func (s *AbstractService) Route(action string) (*Route, error) {
 
 // Sleep sleeps for provided time in ms
 func (s *AbstractService) Sleep(context *Context, sleepTimeMs int) {
-	if sleepTimeMs == 0 {
+	if sleepTimeMs == 0 || context.DryRun {
 		return
 	}
 	sleepTime := time.Millisecond * time.Duration(sleepTimeMs)
@@ -138,7 +142,10 @@ func (s *AbstractService) Sleep(context *Context, sleepTimeMs int) {
 		if context.IsLoggingEnabled() {
 			context.Publish(msg.NewSleepEvent(sleepTimeMs))
 		}
-		time.Sleep(sleepTime)
+		select {
+		case <-time.After(sleepTime):
+		case <-context.Background().Done():
+		}
 		return
 	}
 
@@ -147,7 +154,7 @@ func (s *AbstractService) Sleep(context *Context, sleepTimeMs int) {
 		if context.IsLoggingEnabled() {
 			context.Publish(msg.NewSleepEvent(1000))
 		}
-		if time.Now().Sub(startTime) >= sleepTime {
+		if time.Now().Sub(startTime) >= sleepTime || context.IsClosed() {
 			break
 		}
 		time.Sleep(time.Second)
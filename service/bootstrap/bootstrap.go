@@ -6,6 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"github.com/viant/afs"
+	"github.com/viant/endly/internal/lint"
 	"github.com/viant/endly/internal/webplanner"
 	"github.com/viant/endly/model/location"
 	loader "github.com/viant/endly/model/project/loader"
@@ -50,6 +51,8 @@ import (
 
 	_ "github.com/viant/endly/service/shared" //load external resource like .csv .json files to mem storage
 
+	_ "github.com/viant/endly/service/migration/har"
+	_ "github.com/viant/endly/service/migration/openapi"
 	_ "github.com/viant/endly/service/migration/postman"
 	_ "github.com/viant/endly/service/workflow"
 	_ "github.com/viant/toolbox/storage/gs"
@@ -113,6 +116,7 @@ import (
 	"github.com/viant/endly"
 	"github.com/viant/endly/cli"
 	"github.com/viant/endly/model"
+	"github.com/viant/endly/server"
 	"github.com/viant/endly/service/workflow"
 	"github.com/viant/scy/cred"
 	"github.com/viant/toolbox"
@@ -157,7 +161,8 @@ func init() {
 	flag.String("k", "", "<private key path>,  works only with -c options, i.e -k="+path.Join(os.Getenv("HOME"), ".secret/id_rsa"))
 	flag.String("endpoint", "", "<endpoint for generated secrets credentials>,  works only with -c options, i.e -endpoint=127.0.0.1")
 
-	flag.String("x", "", "xunit summary report format: xml|yaml|json")
+	flag.String("x", "", "coma separated summary report formats: xml|yaml|json|junit|html, junit produces junit.xml, html produces report.html")
+	flag.String("o", "", "<summary report output directory>, default current directory")
 	flag.Bool("g", false, "open test project generator")
 
 	flag.String("u", "", "start HTTP recorder for the supplied URLs (testing/endpoint/http)")
@@ -166,6 +171,18 @@ func init() {
 	flag.String("run", "", "run specified service action it expect valid service:action to run")
 	flag.String("req", "", "optional request URL when run option is specified")
 	flag.String("w", "", "start HTTP webdriver test planner")
+	flag.String("b", "", "<coma separated workflow/task/tagID breakpoints> to pause execution with interactive debugger, b='*' pauses on each node")
+	flag.Bool("checkpoint", false, "persist workflow checkpoint after each task to resume failed run")
+	flag.String("resume", "", "<run ID> resume failed run from checkpoint")
+	flag.Bool("dryrun", false, "print planned service actions with expanded requests without running them")
+	flag.String("debug", "", "<listening address> start debug adapter protocol server, i.e -debug=:4711")
+	flag.String("server", "", "<port> start endly HTTP server, API token is read from ENDLY_TOKEN env variable, without token server listens on 127.0.0.1 only")
+	flag.String("events", "", "<JSONL event log file> write each published event as JSON line")
+	flag.String("trace", "", "<OTLP HTTP endpoint or file> export workflow, task, action and service call spans, i.e. -trace=http://localhost:4318 or -trace=spans.jsonl")
+	flag.String("replay", "", "<JSONL event log file> re-render CLI output, summary and reports from event log without running workflow")
+	flag.Bool("openapi", false, "print OpenAPI 3 document for all service actions, use -f=yaml for YAML output")
+	flag.String("schema", "", "<service:action> print JSON schema for service action request, schema=pipeline prints inline workflow schema")
+	flag.String("lint", "", "<workflow file or directory> validate inline workflows: unknown actions, fields and invalid criteria")
 
 	_ = mysql.SetLogger(&emptyLogger{})
 
@@ -226,6 +243,11 @@ func Bootstrap() {
 		return
 	}
 
+	if port, ok := flagset["server"]; ok {
+		startServer(port)
+		return
+	}
+
 	if toolbox.AsBoolean(flagset["v"]) {
 		printVersion()
 		if shouldQuit {
@@ -247,6 +269,26 @@ func Bootstrap() {
 		return
 	}
 
+	if URL, ok := flagset["replay"]; ok {
+		replayEvents(URL, flagset)
+		return
+	}
+
+	if location, ok := flagset["lint"]; ok {
+		lintWorkflow(location)
+		return
+	}
+
+	if selector, ok := flagset["schema"]; ok {
+		printSchema(selector)
+		return
+	}
+
+	if _, ok := flagset["openapi"]; ok {
+		printOpenAPI()
+		return
+	}
+
 	if _, ok := flagset["a"]; ok {
 		printServiceActionRequest()
 		return
@@ -564,6 +606,43 @@ func printServiceActionRequest() {
 	printStructMeta(renderer, "green", meta.ResponseMeta)
 }
 
+func printOpenAPI() {
+	metaService := meta.New()
+	document, err := metaService.OpenAPI(server.ServiceURI)
+	if err != nil {
+		log.Fatal(err)
+	}
+	printInFormat(document, "failed to print OpenAPI document: %v", false)
+}
+
+func printSchema(selector string) {
+	metaService := meta.New()
+	if selector == "pipeline" {
+		printInFormat(metaService.PipelineSchema(), "failed to print schema: %v", false)
+		return
+	}
+	actionSelector := model.ActionSelector(selector)
+	schema, err := metaService.RequestSchema(actionSelector.Service(), actionSelector.Action())
+	if err != nil {
+		log.Fatal(err)
+	}
+	printInFormat(schema, "failed to print schema: %v", false)
+}
+
+func lintWorkflow(location string) {
+	issues, err := lint.New().Lint(location)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, issue := range issues {
+		fmt.Println(issue.String())
+	}
+	if len(issues) > 0 {
+		fmt.Fprintf(os.Stderr, "%d issue(s) found\n", len(issues))
+		os.Exit(1)
+	}
+}
+
 func printServiceActions() {
 	manager := endly.New()
 	context := manager.NewContext(toolbox.NewContext())
@@ -716,6 +795,17 @@ func getRunRequestURL(ctx context.Context, URL string) (*location.Resource, erro
 	return resource, err
 }
 
+func replayEvents(URL string, flagset map[string]string) {
+	request := &workflow.RunRequest{
+		SummaryFormat:    flagset["x"],
+		SummaryDirectory: flagset["o"],
+		FailureCount:     toolbox.AsInt(flag.Lookup("e").Value.String()),
+	}
+	if err := cli.New().Replay(URL, request); err != nil {
+		log.Fatal(err)
+	}
+}
+
 func getRunRequestWithOptions(flagset map[string]string) (*workflow.RunRequest, error) {
 	var request *workflow.RunRequest
 	var err error
@@ -737,6 +827,9 @@ func getRunRequestWithOptions(flagset map[string]string) (*workflow.RunRequest,
 	if value, ok := flagset["x"]; ok {
 		request.SummaryFormat = value
 	}
+	if value, ok := flagset["o"]; ok {
+		request.SummaryDirectory = value
+	}
 	err = request.Init()
 	if value, ok := flagset["i"]; ok {
 		request.TagIDs = value
@@ -794,11 +887,54 @@ func updateBaseRunWithOptions(request *workflow.RunRequest, flagset map[string]s
 	if value, ok := flagset["e"]; ok {
 		request.FailureCount = toolbox.AsInt(value)
 	}
+	if value, ok := flagset["b"]; ok {
+		if value == "*" {
+			request.StepMode = true
+		} else {
+			request.Breakpoints = strings.Split(value, ",")
+		}
+	}
+	if value, ok := flagset["debug"]; ok {
+		request.DebugAddress = value
+	}
+	if value, ok := flagset["checkpoint"]; ok {
+		request.Checkpoint = toolbox.AsBoolean(value)
+	}
+	if value, ok := flagset["resume"]; ok {
+		request.Resume = value
+	}
+	if value, ok := flagset["events"]; ok {
+		request.EventLog = value
+	}
+	if value, ok := flagset["trace"]; ok {
+		request.TraceEndpoint = value
+	}
+	if value, ok := flagset["dryrun"]; ok {
+		request.DryRun = toolbox.AsBoolean(value)
+	}
 	return nil
 }
 
+func startServer(port string) {
+	srv := server.New(port, server.WithToken(os.Getenv("ENDLY_TOKEN")))
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signalChan
+		log.Printf("shutting down endly server ...")
+		if err := srv.Shutdown(context.Background()); err != nil {
+			log.Printf("failed to shutdown server: %v", err)
+		}
+	}()
+	if err := srv.Start(); err != nil {
+		log.Fatal(err)
+	}
+}
+
 func startRecorder(URLs []string) {
-	rec.StartRecorder(URLs...)
+	if err := rec.StartRecorder(URLs...); err != nil {
+		log.Fatal(err)
+	}
 }
 
 type emptyLogger struct{}
@@ -0,0 +1,88 @@
+package meta
+
+import (
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox"
+	"strings"
+	"unicode"
+)
+
+const (
+	jsonSchemaURI     = "http://json-schema.org/draft-07/schema#"
+	definitionsPrefix = "#/definitions/"
+	//NodeDefinition represents inline workflow pipeline node schema definition name
+	NodeDefinition = "node"
+)
+
+// RequestSchema returns JSON schema for supplied service action request
+func (m *Service) RequestSchema(serviceID, action string) (*Schema, error) {
+	context := m.NewContext(toolbox.NewContext())
+	defer context.Close()
+	service, err := context.Service(serviceID)
+	if err != nil {
+		return nil, err
+	}
+	route, err := service.Route(action)
+	if err != nil {
+		return nil, err
+	}
+	schemas := NewSchemas(definitionsPrefix)
+	return newJSONSchema(serviceID+":"+action, schemas, schemas.Of(route.RequestProvider())), nil
+}
+
+// PipelineSchema returns JSON schema for inline workflow document, pipeline node keys are either node attributes,
+// service request fields or nested nodes, thus additional properties are allowed
+func (m *Service) PipelineSchema() *Schema {
+	schemas := NewSchemas(definitionsPrefix)
+	node := &Schema{Type: "object", Properties: map[string]*Schema{}, AdditionalProperties: &Schema{}}
+	for _, source := range []interface{}{&model.Action{}, &model.TransientTemplate{}} {
+		for name, property := range schemas.Resolve(schemas.Of(source)).Properties {
+			node.Properties[lowerCamel(name)] = property
+		}
+	}
+	variables := &Schema{Description: "state variables: list of 'key = value' expressions, map or @file reference"}
+	node.Properties["action"] = &Schema{Type: "string", Description: "service action selector: service:action"}
+	node.Properties["workflow"] = &Schema{Type: "string", Description: "workflow selector: URL[:tasks], remaining node attributes are passed as workflow parameters"}
+	node.Properties["request"] = &Schema{Description: "service request or @file reference, remaining node attributes are merged into the request"}
+	node.Properties["init"] = variables
+	node.Properties["post"] = variables
+	node.Properties["fail"] = &Schema{Type: "boolean", Description: "flag to fail workflow once catch task completes"}
+	node.Properties["multiAction"] = &Schema{Type: "boolean", Description: "flag to group child actions into one task"}
+	node.Properties["async"] = &Schema{Type: "boolean", Description: "flag to run action async, or to group child actions into one task"}
+	node.Properties["template"] = &Schema{Type: "object", Description: "template nodes expanded for each matched subPath", AdditionalProperties: &Schema{Ref: definitionsPrefix + NodeDefinition}}
+	schemas.Components[NodeDefinition] = node
+
+	result := newJSONSchema("endly inline workflow", schemas, &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"init":     variables,
+			"post":     variables,
+			"logging":  {Type: "boolean", Description: "optional flag to disable logging, enabled by default"},
+			"defaults": {Type: "object", Description: "default attributes merged into each action request", AdditionalProperties: &Schema{}},
+			"data":     {Type: "object", Description: "workflow data", AdditionalProperties: &Schema{}},
+			"params":   {Type: "object", Description: "workflow parameters", AdditionalProperties: &Schema{}},
+			"pipeline": {Type: "object", Description: "ordered workflow nodes", AdditionalProperties: &Schema{Ref: definitionsPrefix + NodeDefinition}},
+		},
+		AdditionalProperties: &Schema{},
+	})
+	return result
+}
+
+func newJSONSchema(title string, schemas *Schemas, schema *Schema) *Schema {
+	var result = *schemas.Resolve(schema)
+	result.SchemaURI = jsonSchemaURI
+	result.Title = title
+	if len(schemas.Components) > 0 {
+		result.Definitions = schemas.Components
+	}
+	return &result
+}
+
+func lowerCamel(name string) string {
+	if name == "" || strings.ToUpper(name) == name {
+		return strings.ToLower(name)
+	}
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,27 @@
+package meta
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestService_RequestSchema(t *testing.T) {
+	meta := New()
+	schema, err := meta.RequestSchema("nop", "nop")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, jsonSchemaURI, schema.SchemaURI)
+	assert.NotNil(t, schema.Properties["In"])
+	_, err = meta.RequestSchema("nop", "abc")
+	assert.NotNil(t, err)
+}
+
+func TestService_PipelineSchema(t *testing.T) {
+	schema := New().PipelineSchema()
+	assert.Equal(t, definitionsPrefix+NodeDefinition, schema.Properties["pipeline"].AdditionalProperties.Ref)
+	node := schema.Definitions[NodeDefinition]
+	for _, attribute := range []string{"action", "when", "skip", "sleepTimeMs", "retry", "subPath", "parallel"} {
+		assert.NotNil(t, node.Properties[attribute], attribute)
+	}
+}
@@ -0,0 +1,153 @@
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/endly"
+	"sort"
+	"strings"
+)
+
+const openAPIVersion = "3.0.3"
+
+type (
+	// Document represents OpenAPI 3 document
+	Document struct {
+		OpenAPI    string               `json:"openapi" yaml:"openapi"`
+		Info       *Info                `json:"info" yaml:"info"`
+		Paths      map[string]*PathItem `json:"paths" yaml:"paths"`
+		Components *Components          `json:"components,omitempty" yaml:"components,omitempty"`
+	}
+
+	// Info represents API info
+	Info struct {
+		Title       string `json:"title" yaml:"title"`
+		Description string `json:"description,omitempty" yaml:"description,omitempty"`
+		Version     string `json:"version" yaml:"version"`
+	}
+
+	// PathItem represents API path operations
+	PathItem struct {
+		Post *Operation `json:"post,omitempty" yaml:"post,omitempty"`
+	}
+
+	// Operation represents API operation
+	Operation struct {
+		OperationID string               `json:"operationId" yaml:"operationId"`
+		Summary     string               `json:"summary,omitempty" yaml:"summary,omitempty"`
+		Description string               `json:"description,omitempty" yaml:"description,omitempty"`
+		Tags        []string             `json:"tags,omitempty" yaml:"tags,omitempty"`
+		RequestBody *Body                `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+		Responses   map[string]*Response `json:"responses" yaml:"responses"`
+	}
+
+	// Body represents operation request body
+	Body struct {
+		Required bool                  `json:"required,omitempty" yaml:"required,omitempty"`
+		Content  map[string]*MediaType `json:"content" yaml:"content"`
+	}
+
+	// Response represents operation response
+	Response struct {
+		Description string                `json:"description" yaml:"description"`
+		Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+	}
+
+	// MediaType represents content schema
+	MediaType struct {
+		Schema  *Schema     `json:"schema" yaml:"schema"`
+		Example interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+	}
+
+	// Components represents reusable schemas
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas" yaml:"schemas"`
+	}
+)
+
+// OpenAPI returns OpenAPI 3 document describing all registered service actions, servicePath is a service action route prefix
+func (m *Service) OpenAPI(servicePath string) (*Document, error) {
+	var result = &Document{
+		OpenAPI: openAPIVersion,
+		Info: &Info{
+			Title:       "endly",
+			Description: "endly service actions",
+			Version:     strings.TrimSpace(endly.GetVersion()),
+		},
+		Paths: map[string]*PathItem{},
+	}
+	schemas := NewSchemas(componentsPrefix)
+	services := endly.Services(m.Manager)
+	var serviceIDs = make([]string, 0, len(services))
+	for serviceID := range services {
+		serviceIDs = append(serviceIDs, serviceID)
+	}
+	sort.Strings(serviceIDs)
+	for _, serviceID := range serviceIDs {
+		service := services[serviceID]
+		for _, action := range service.Actions() {
+			route, err := service.Route(action)
+			if err != nil {
+				return nil, err
+			}
+			URI := servicePath + serviceID + "/" + action + "/"
+			result.Paths[URI] = &PathItem{Post: newOperation(serviceID, route, schemas)}
+		}
+	}
+	result.Components = &Components{Schemas: schemas.Components}
+	return result, nil
+}
+
+func newOperation(serviceID string, route *endly.Route, schemas *Schemas) *Operation {
+	var result = &Operation{
+		OperationID: serviceID + ":" + route.Action,
+		Tags:        []string{serviceID},
+		Responses:   map[string]*Response{},
+	}
+	if route.RequestInfo != nil {
+		result.Summary = route.RequestInfo.Description
+	}
+	if route.ResponseInfo != nil && route.ResponseInfo.Description != "" {
+		result.Description = fmt.Sprintf("response: %v", route.ResponseInfo.Description)
+	}
+	request := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"Data":           {Type: "object", Description: "state data applied before running action", AdditionalProperties: &Schema{}},
+			"ServiceRequest": schemas.Of(route.RequestProvider()),
+		},
+		Required: []string{"ServiceRequest"},
+	}
+	result.RequestBody = &Body{
+		Required: true,
+		Content:  map[string]*MediaType{"application/json": {Schema: request, Example: requestExample(route.RequestInfo)}},
+	}
+	response := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"Status":   {Type: "string", Description: "ok or error"},
+			"Error":    {Type: "string"},
+			"Response": schemas.Of(route.ResponseProvider()),
+			"Data":     {Type: "object", Description: "state data after running action", AdditionalProperties: &Schema{}},
+		},
+	}
+	result.Responses["200"] = &Response{
+		Description: "service action response",
+		Content:     map[string]*MediaType{"application/json": {Schema: response}},
+	}
+	return result
+}
+
+// requestExample returns the first JSON action use case as request body example
+func requestExample(info *endly.ActionInfo) interface{} {
+	if info == nil {
+		return nil
+	}
+	for _, useCase := range info.Examples {
+		var serviceRequest interface{}
+		if err := json.Unmarshal([]byte(useCase.Data), &serviceRequest); err == nil {
+			return map[string]interface{}{"ServiceRequest": serviceRequest}
+		}
+	}
+	return nil
+}
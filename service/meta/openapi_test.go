@@ -0,0 +1,78 @@
+package meta
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"strings"
+	"testing"
+)
+
+func TestService_OpenAPI(t *testing.T) {
+	meta := New()
+	document, err := meta.OpenAPI("/v1/endly/service/")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "3.0.3", document.OpenAPI)
+	for _, service := range endly.Services(meta.Manager) {
+		for _, action := range service.Actions() {
+			pathItem, ok := document.Paths["/v1/endly/service/"+service.ID()+"/"+action+"/"]
+			if !assert.True(t, ok, service.ID()+":"+action) {
+				continue
+			}
+			assert.Equal(t, service.ID()+":"+action, pathItem.Post.OperationID)
+		}
+	}
+	var assertRefs func(schema *Schema)
+	assertRefs = func(schema *Schema) {
+		if schema == nil {
+			return
+		}
+		if schema.Ref != "" {
+			_, ok := document.Components.Schemas[strings.TrimPrefix(schema.Ref, componentsPrefix)]
+			assert.True(t, ok, schema.Ref)
+		}
+		for _, property := range schema.Properties {
+			assertRefs(property)
+		}
+		assertRefs(schema.Items)
+		assertRefs(schema.AdditionalProperties)
+	}
+	for _, schema := range document.Components.Schemas {
+		assertRefs(schema)
+	}
+	for _, pathItem := range document.Paths {
+		assertRefs(pathItem.Post.RequestBody.Content["application/json"].Schema)
+	}
+}
+
+func TestSchemas_Of(t *testing.T) {
+	type Inner struct {
+		Name string `description:"inner name" required:"true"`
+	}
+	type Embedded struct {
+		ID int
+	}
+	type Outer struct {
+		Embedded
+		Inner    *Inner
+		Items    []string
+		Attrs    map[string]int `json:"attrs,omitempty"`
+		Skip     string         `json:"-"`
+		internal string
+	}
+	schemas := NewSchemas(componentsPrefix)
+	schema := schemas.Of(&Outer{})
+	if !assert.NotEmpty(t, schema.Ref) {
+		return
+	}
+	outer := schemas.Components[strings.TrimPrefix(schema.Ref, componentsPrefix)]
+	assert.Equal(t, "integer", outer.Properties["ID"].Type)
+	assert.Equal(t, "array", outer.Properties["Items"].Type)
+	assert.Equal(t, "integer", outer.Properties["attrs"].AdditionalProperties.Type)
+	assert.Nil(t, outer.Properties["Skip"])
+	assert.Nil(t, outer.Properties["internal"])
+	inner := schemas.Components[strings.TrimPrefix(outer.Properties["Inner"].Ref, componentsPrefix)]
+	assert.Equal(t, []string{"Name"}, inner.Required)
+	assert.Equal(t, "inner name", inner.Properties["Name"].Description)
+}
@@ -0,0 +1,190 @@
+package meta
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const componentsPrefix = "#/components/schemas/"
+
+// Schema represents JSON schema, compatible with OpenAPI 3 schema object
+type Schema struct {
+	SchemaURI            string             `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty" yaml:"title,omitempty"`
+	Ref                  string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Description          string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+	Example              interface{}        `json:"example,omitempty" yaml:"example,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty" yaml:"definitions,omitempty"`
+}
+
+// Schemas represents reflection based schema generator, named struct types are registered as reusable components
+type Schemas struct {
+	Components map[string]*Schema
+	// FieldNames uses struct field names ignoring json tags, the way workflow requests are converted
+	FieldNames bool
+	refPrefix  string
+	names      map[reflect.Type]string
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+var durationType = reflect.TypeOf(time.Duration(0))
+var bytesType = reflect.TypeOf([]byte{})
+
+// Of returns schema for supplied value type
+func (s *Schemas) Of(value interface{}) *Schema {
+	if value == nil {
+		return &Schema{}
+	}
+	return s.schema(reflect.TypeOf(value))
+}
+
+func (s *Schemas) schema(aType reflect.Type) *Schema {
+	for aType.Kind() == reflect.Ptr {
+		aType = aType.Elem()
+	}
+	switch aType {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &Schema{Type: "integer", Format: "int64"}
+	case bytesType:
+		return &Schema{Type: "string", Format: "byte"}
+	}
+	switch aType.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: s.schema(aType.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(aType.Elem())}
+	case reflect.Struct:
+		if aType.Name() == "" {
+			return s.structSchema(aType)
+		}
+		return s.ref(aType)
+	}
+	return &Schema{}
+}
+
+// ref registers struct type component and returns its reference
+func (s *Schemas) ref(aType reflect.Type) *Schema {
+	name, ok := s.names[aType]
+	if !ok {
+		name = s.componentName(aType)
+		s.names[aType] = name
+		s.Components[name] = &Schema{}
+		*s.Components[name] = *s.structSchema(aType)
+	}
+	return &Schema{Ref: s.refPrefix + name}
+}
+
+func (s *Schemas) componentName(aType reflect.Type) string {
+	pkgPath := strings.TrimPrefix(aType.PkgPath(), "github.com/viant/endly/service/")
+	pkgPath = strings.TrimPrefix(pkgPath, "github.com/")
+	name := strings.Replace(pkgPath, "/", ".", -1) + "." + aType.Name()
+	name = strings.Trim(strings.Map(func(r rune) rune {
+		if r == '.' || r == '_' || r == '-' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name), ".")
+	return name
+}
+
+func (s *Schemas) structSchema(aType reflect.Type) *Schema {
+	result := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	s.addFields(result, aType)
+	sort.Strings(result.Required)
+	return result
+}
+
+func (s *Schemas) addFields(result *Schema, aType reflect.Type) {
+	for i := 0; i < aType.NumField(); i++ {
+		field := aType.Field(i)
+		name, skip := fieldName(field)
+		if s.FieldNames {
+			name, skip = field.Name, false
+		}
+		if skip {
+			continue
+		}
+		if field.Anonymous {
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct && (s.FieldNames || strings.Split(field.Tag.Get("json"), ",")[0] == "") {
+				s.addFields(result, fieldType)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			continue
+		}
+		property := s.schema(field.Type)
+		if description := field.Tag.Get("description"); description != "" {
+			if property.Ref != "" {
+				property = &Schema{Ref: property.Ref}
+			}
+			property.Description = description
+		}
+		if example := field.Tag.Get("example"); example != "" {
+			property.Example = example
+		}
+		if field.Tag.Get("required") == "true" {
+			result.Required = append(result.Required, name)
+		}
+		result.Properties[name] = property
+	}
+}
+
+func fieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, false
+	}
+	return field.Name, false
+}
+
+// Resolve returns referenced component schema or supplied schema if it is not a reference
+func (s *Schemas) Resolve(schema *Schema) *Schema {
+	if schema == nil || schema.Ref == "" {
+		return schema
+	}
+	if component, ok := s.Components[strings.TrimPrefix(schema.Ref, s.refPrefix)]; ok {
+		return component
+	}
+	return schema
+}
+
+// NewSchemas creates schema generator, refPrefix is prepended to component references
+func NewSchemas(refPrefix string) *Schemas {
+	return &Schemas{
+		Components: map[string]*Schema{},
+		refPrefix:  refPrefix,
+		names:      map[reflect.Type]string{},
+	}
+}
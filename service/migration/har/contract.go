@@ -0,0 +1,46 @@
+package har
+
+import (
+	"errors"
+	"regexp"
+)
+
+// MigrateHARRequest represents a path to the HAR file
+type MigrateHARRequest struct {
+	HARPath    string   `required:"true" description:"HAR file location"`
+	OutputPath string   `required:"true" description:"output directory for send.json, replay trips and run.yaml"`
+	URLFilter  string   `description:"regular expression, only entries with matching URL are migrated"`
+	BaseURL    string   `description:"replaces recorded scheme and host in send requests, i.e. ${baseURL}"`
+	SkipHeader []string `description:"additional request headers to skip, i.e. Cookie"`
+	Port       int      `description:"replay endpoint port used by generated run.yaml, default 8080"`
+	filter     *regexp.Regexp
+}
+
+// MigrateHARResponse represents migrated HAR summary
+type MigrateHARResponse struct {
+	OutputPath string
+	Requests   int `description:"number of migrated requests"`
+	Skipped    int `description:"number of skipped entries"`
+}
+
+// Init initialises request
+func (r *MigrateHARRequest) Init() (err error) {
+	if r.Port == 0 {
+		r.Port = 8080
+	}
+	if r.URLFilter != "" {
+		r.filter, err = regexp.Compile(r.URLFilter)
+	}
+	return err
+}
+
+// Validate checks if request is valid
+func (r *MigrateHARRequest) Validate() error {
+	if r.HARPath == "" {
+		return errors.New("harPath was empty")
+	}
+	if r.OutputPath == "" {
+		return errors.New("outputPath was empty")
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package har
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/viant/endly"
+	"github.com/viant/endly/internal/util"
+	"github.com/viant/toolbox/bridge"
+)
+
+// ServiceID service to generate http/runner requests and http/endpoint trips from HAR file
+const ServiceID = "migration/har"
+
+const (
+	tripsDirectory   = "trips"
+	requestTemplate  = "%02d-req.json"
+	responseTemplate = "%02d-resp.json"
+)
+
+const migrateServiceHARExample = `{
+  "HARPath": "/path/to/capture.har",
+  "OutputPath": "/path/where/endly/should/write/workflow",
+  "URLFilter": "/api/",
+  "BaseURL": "${baseURL}"
+}`
+
+// RunYaml represents generated workflow replaying trips and sending migrated requests
+const RunYaml = `pipeline:
+  replay:
+    action: http/endpoint:listen
+    port: {{PORT}}
+    baseDirectory: trips
+  send:
+    action: http/runner:send
+    request: '@send.json'
+`
+
+// responseSkipHeaders represents headers invalidated by HAR content decoding
+var responseSkipHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Content-Encoding":  true,
+	"Transfer-Encoding": true,
+}
+
+type sendRequest struct {
+	Method string
+	URL    string
+	Header http.Header            `json:",omitempty"`
+	Body   string                 `json:",omitempty"`
+	Expect map[string]interface{} `json:",omitempty"`
+}
+
+type migratorService struct {
+	*endly.AbstractService
+}
+
+func (s *migratorService) migrateHAR(context *endly.Context, request *MigrateHARRequest) (*MigrateHARResponse, error) {
+	data, err := os.ReadFile(request.HARPath)
+	if err != nil {
+		return nil, err
+	}
+	archive := &HAR{}
+	if err = json.Unmarshal(data, archive); err != nil {
+		return nil, fmt.Errorf("failed to decode HAR %v, %w", request.HARPath, err)
+	}
+	if archive.Log == nil {
+		return nil, fmt.Errorf("invalid HAR %v: log was empty", request.HARPath)
+	}
+	tripsPath := filepath.Join(request.OutputPath, tripsDirectory)
+	if err = os.MkdirAll(tripsPath, 0750); err != nil {
+		return nil, err
+	}
+	var response = &MigrateHARResponse{OutputPath: request.OutputPath}
+	var requests = make([]*sendRequest, 0)
+	var previous *Entry
+	for _, entry := range archive.Log.Entries {
+		if !request.accept(entry) {
+			response.Skipped++
+			continue
+		}
+		tripRequest, tripResponse, err := request.asTrip(entry, previous)
+		if err != nil {
+			return nil, err
+		}
+		previous = entry
+		index := len(requests) + 1
+		if err = writeJSON(filepath.Join(tripsPath, fmt.Sprintf(requestTemplate, index)), tripRequest); err != nil {
+			return nil, err
+		}
+		if err = writeJSON(filepath.Join(tripsPath, fmt.Sprintf(responseTemplate, index)), tripResponse); err != nil {
+			return nil, err
+		}
+		requests = append(requests, request.asSendRequest(entry, tripRequest.Header))
+	}
+	response.Requests = len(requests)
+	send := map[string]interface{}{
+		"Options":  map[string]interface{}{"FollowRedirects": false}, //HAR records each redirect separately
+		"Requests": requests,
+	}
+	if err = writeJSON(filepath.Join(request.OutputPath, "send.json"), send); err != nil {
+		return nil, err
+	}
+	runYaml := strings.Replace(RunYaml, "{{PORT}}", fmt.Sprint(request.Port), 1)
+	if err = os.WriteFile(filepath.Join(request.OutputPath, "run.yaml"), []byte(runYaml), 0644); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// accept returns true if entry has response and matches URL filter
+func (r *MigrateHARRequest) accept(entry *Entry) bool {
+	if entry.Request == nil || entry.Response == nil || entry.Response.Status == 0 {
+		return false
+	}
+	if r.filter != nil && !r.filter.MatchString(entry.Request.URL) {
+		return false
+	}
+	return true
+}
+
+func (r *MigrateHARRequest) skipHeader(name string) bool {
+	if strings.HasPrefix(name, ":") || strings.EqualFold(name, "Content-Length") {
+		return true
+	}
+	for _, candidate := range r.SkipHeader {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// asTrip converts entry into endpoint replay trip
+func (r *MigrateHARRequest) asTrip(entry, previous *Entry) (*bridge.HttpRequest, *bridge.HttpResponse, error) {
+	var tripRequest = &bridge.HttpRequest{
+		Method: entry.Request.Method,
+		URL:    entry.Request.URL,
+		Header: make(http.Header),
+		Body:   util.AsPayload([]byte(entry.Request.PostData.Body())),
+	}
+	for _, header := range entry.Request.Headers {
+		if r.skipHeader(header.Name) {
+			continue
+		}
+		tripRequest.Header.Add(header.Name, header.Value)
+	}
+	if previous != nil {
+		thinkTime := entry.StartedDateTime.Sub(previous.StartedDateTime).Milliseconds() - int64(previous.Time)
+		if thinkTime > 0 {
+			tripRequest.ThinkTimeMs = int(thinkTime)
+		}
+	}
+	var tripResponse = &bridge.HttpResponse{
+		Code:   entry.Response.Status,
+		Header: make(http.Header),
+	}
+	for _, header := range entry.Response.Headers {
+		name := http.CanonicalHeaderKey(header.Name)
+		if strings.HasPrefix(name, ":") || responseSkipHeaders[name] {
+			continue
+		}
+		tripResponse.Header.Add(name, header.Value)
+	}
+	if content := entry.Response.Content; content != nil && content.Text != "" {
+		body := []byte(content.Text)
+		if content.Encoding == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(content.Text)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to decode %v response content, %w", entry.Request.URL, err)
+			}
+			body = decoded
+		}
+		tripResponse.Body = util.AsPayload(body)
+	}
+	return tripRequest, tripResponse, nil
+}
+
+// asSendRequest converts entry into http/runner send request
+func (r *MigrateHARRequest) asSendRequest(entry *Entry, header http.Header) *sendRequest {
+	URL := entry.Request.URL
+	if r.BaseURL != "" {
+		if index := strings.Index(URL, "://"); index != -1 {
+			URL = URL[index+3:]
+			if index = strings.Index(URL, "/"); index != -1 {
+				URL = URL[index:]
+			} else {
+				URL = ""
+			}
+			URL = strings.TrimRight(r.BaseURL, "/") + URL
+		}
+	}
+	var result = &sendRequest{
+		Method: entry.Request.Method,
+		URL:    URL,
+		Body:   entry.Request.PostData.Body(),
+		Expect: map[string]interface{}{"Code": entry.Response.Status},
+	}
+	if len(header) > 0 {
+		result.Header = header
+	}
+	return result
+}
+
+func writeJSON(filename string, source interface{}) error {
+	data, err := json.MarshalIndent(source, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+func (s *migratorService) registerRoutes() {
+	s.Register(&endly.Route{
+		Action: "har",
+		RequestInfo: &endly.ActionInfo{
+			Description: "Migrate HAR file to http/runner:send requests and http/endpoint replay trips",
+			Examples: []*endly.UseCase{
+				{
+					Description: "migrate HAR file",
+					Data:        migrateServiceHARExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &MigrateHARRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &MigrateHARResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*MigrateHARRequest); ok {
+				return s.migrateHAR(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+}
+
+// New creates a new HAR migration service
+func New() endly.Service {
+	var result = &migratorService{
+		AbstractService: endly.NewAbstractService(ServiceID),
+	}
+	result.AbstractService.Service = result
+	result.registerRoutes()
+	return result
+}
@@ -0,0 +1,99 @@
+package har_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/service/migration/har"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/bridge"
+)
+
+func TestMigratorService_HAR(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, err := context.Service(har.ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	outputPath := filepath.Join(os.TempDir(), "endly_har_test")
+	_ = os.RemoveAll(outputPath)
+	response := service.Run(context, &har.MigrateHARRequest{
+		HARPath:    filepath.Join("test", "capture.har"),
+		OutputPath: outputPath,
+		URLFilter:  "/v1/",
+		BaseURL:    "${baseURL}",
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	migrated := response.Response.(*har.MigrateHARResponse)
+	assert.EqualValues(t, 3, migrated.Requests)
+	assert.EqualValues(t, 1, migrated.Skipped)
+
+	trips, err := bridge.ReadRecordedHttpTripsWithTemplate(filepath.Join(outputPath, "trips"), "%02d-req.json", "%02d-resp.json")
+	if !assert.Nil(t, err) || !assert.Len(t, trips, 3) {
+		return
+	}
+	var useCases = []struct {
+		description string
+		trip        *bridge.RecordedHttpTrip
+		URL         string
+		header      map[string]string
+		body        string
+		code        int
+		respBody    string
+	}{
+		{
+			description: "pseudo and encoding headers skipped",
+			trip:        trips[0],
+			URL:         "http://api.example.com/v1/users/1?x=1",
+			header:      map[string]string{":authority": "", "Accept": "application/json"},
+			code:        200,
+			respBody:    `{"id":1}`,
+		},
+		{
+			description: "base64 content",
+			trip:        trips[1],
+			URL:         "http://cdn.example.com/v1/logo.png",
+			code:        200,
+			respBody:    "base64:iVBORw0KGgo=",
+		},
+		{
+			description: "form params",
+			trip:        trips[2],
+			URL:         "http://api.example.com/v1/login",
+			body:        "pass=x+y&user=bob",
+			code:        302,
+		},
+	}
+	for _, useCase := range useCases {
+		assert.EqualValues(t, useCase.URL, useCase.trip.Request.URL, useCase.description)
+		assert.EqualValues(t, useCase.body, useCase.trip.Request.Body, useCase.description)
+		for k, v := range useCase.header {
+			assert.EqualValues(t, v, useCase.trip.Request.Header.Get(k), useCase.description)
+		}
+		assert.EqualValues(t, useCase.code, useCase.trip.Response.Code, useCase.description)
+		assert.EqualValues(t, useCase.respBody, useCase.trip.Response.Body, useCase.description)
+		assert.EqualValues(t, "", useCase.trip.Response.Header.Get("Content-Encoding"), useCase.description)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputPath, "send.json"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	send := struct {
+		Requests []struct {
+			URL    string
+			Expect map[string]interface{}
+		}
+	}{}
+	if assert.Nil(t, json.Unmarshal(data, &send)) && assert.Len(t, send.Requests, 3) {
+		assert.EqualValues(t, "${baseURL}/v1/users/1?x=1", send.Requests[0].URL)
+		assert.EqualValues(t, 302, send.Requests[2].Expect["Code"])
+	}
+}
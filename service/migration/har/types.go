@@ -0,0 +1,75 @@
+package har
+
+import (
+	"net/url"
+	"time"
+)
+
+// HAR represents HTTP archive, see http://www.softwareishard.com/blog/har-12-spec/
+type HAR struct {
+	Log *Log `json:"log"`
+}
+
+// Log represents HAR log
+type Log struct {
+	Entries []*Entry `json:"entries"`
+}
+
+// Entry represents recorded HTTP trip
+type Entry struct {
+	StartedDateTime time.Time `json:"startedDateTime"`
+	Time            float64   `json:"time"`
+	Request         *Request  `json:"request"`
+	Response        *Response `json:"response"`
+}
+
+// NameValue represents header, query or form parameter
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Request represents recorded request
+type Request struct {
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	Headers     []*NameValue `json:"headers"`
+	QueryString []*NameValue `json:"queryString"`
+	PostData    *PostData    `json:"postData"`
+}
+
+// PostData represents recorded request body
+type PostData struct {
+	MimeType string       `json:"mimeType"`
+	Text     string       `json:"text"`
+	Params   []*NameValue `json:"params"`
+}
+
+// Body returns request body, form params are URL encoded if text was not recorded
+func (d *PostData) Body() string {
+	if d == nil {
+		return ""
+	}
+	if d.Text != "" || len(d.Params) == 0 {
+		return d.Text
+	}
+	var values = url.Values{}
+	for _, param := range d.Params {
+		values.Add(param.Name, param.Value)
+	}
+	return values.Encode()
+}
+
+// Response represents recorded response
+type Response struct {
+	Status  int          `json:"status"`
+	Headers []*NameValue `json:"headers"`
+	Content *Content     `json:"content"`
+}
+
+// Content represents recorded response body
+type Content struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding"`
+}
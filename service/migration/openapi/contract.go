@@ -0,0 +1,36 @@
+package openapi
+
+import "errors"
+
+// MigrateOpenAPIRequest represents a path to OpenAPI 3 document
+type MigrateOpenAPIRequest struct {
+	SpecPath   string `required:"true" description:"OpenAPI 3 JSON or YAML document location"`
+	OutputPath string `required:"true" description:"output directory for mock rules and regression use cases"`
+	Port       int    `description:"mock endpoint port, default 8080"`
+}
+
+// MigrateOpenAPIResponse represents generated mock rules and use cases summary
+type MigrateOpenAPIResponse struct {
+	OutputPath string
+	Rules      int `description:"number of generated mock rules"`
+	UseCases   int `description:"number of generated regression use cases"`
+}
+
+// Init initialises request
+func (r *MigrateOpenAPIRequest) Init() error {
+	if r.Port == 0 {
+		r.Port = 8080
+	}
+	return nil
+}
+
+// Validate checks if request is valid
+func (r *MigrateOpenAPIRequest) Validate() error {
+	if r.SpecPath == "" {
+		return errors.New("specPath was empty")
+	}
+	if r.OutputPath == "" {
+		return errors.New("outputPath was empty")
+	}
+	return nil
+}
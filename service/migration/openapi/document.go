@@ -0,0 +1,327 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const maxExampleDepth = 8
+
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+var pathParameter = regexp.MustCompile(`\{([^}]+)\}`)
+
+// document represents generic OpenAPI 3 document with $ref resolution
+type document struct {
+	root map[string]interface{}
+}
+
+// operation represents API operation
+type operation struct {
+	ID         string
+	Method     string
+	Path       string
+	Parameters []map[string]interface{}
+	Body       map[string]interface{}
+	Responses  map[string]interface{}
+}
+
+// resolve returns referenced node for local $ref, i.e. #/components/schemas/User
+func (d *document) resolve(node interface{}) map[string]interface{} {
+	aMap, _ := node.(map[string]interface{})
+	for i := 0; i < maxExampleDepth && aMap != nil; i++ {
+		ref, ok := aMap["$ref"].(string)
+		if !ok {
+			return aMap
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil
+		}
+		var current interface{} = d.root
+		for _, name := range strings.Split(ref[2:], "/") {
+			name = strings.Replace(strings.Replace(name, "~1", "/", -1), "~0", "~", -1)
+			parent, _ := current.(map[string]interface{})
+			current = parent[name]
+		}
+		aMap, _ = current.(map[string]interface{})
+	}
+	return aMap
+}
+
+// basePath returns path of the first server URL
+func (d *document) basePath() string {
+	servers, _ := d.root["servers"].([]interface{})
+	if len(servers) == 0 {
+		return ""
+	}
+	server := d.resolve(servers[0])
+	URL, _ := server["url"].(string)
+	if index := strings.Index(URL, "://"); index != -1 {
+		URL = URL[index+3:]
+		if index = strings.Index(URL, "/"); index == -1 {
+			return ""
+		}
+		URL = URL[index:]
+	}
+	return strings.TrimRight(URL, "/")
+}
+
+// operations returns document operations, literal path segments are ordered before path parameters
+func (d *document) operations() []*operation {
+	paths, _ := d.root["paths"].(map[string]interface{})
+	var result = make([]*operation, 0)
+	for path := range paths {
+		pathItem := d.resolve(paths[path])
+		if pathItem == nil {
+			continue
+		}
+		shared, _ := pathItem["parameters"].([]interface{})
+		for _, method := range methods {
+			source := d.resolve(pathItem[method])
+			if source == nil {
+				continue
+			}
+			op := &operation{Method: strings.ToUpper(method), Path: path}
+			op.ID, _ = source["operationId"].(string)
+			if op.ID == "" {
+				op.ID = operationID(method, path)
+			}
+			parameters, _ := source["parameters"].([]interface{})
+			op.Parameters = d.parameters(append(append([]interface{}{}, shared...), parameters...))
+			op.Body = d.resolve(source["requestBody"])
+			op.Responses, _ = source["responses"].(map[string]interface{})
+			result = append(result, op)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Path == result[j].Path {
+			return false
+		}
+		return comparePaths(result[i].Path, result[j].Path)
+	})
+	return result
+}
+
+// operationID returns camel case operation name, i.e. getUsersId for GET /users/{id}
+func operationID(method, path string) string {
+	var result = strings.ToLower(method)
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		result += strings.ToUpper(segment[:1]) + segment[1:]
+	}
+	return result
+}
+
+// parameters returns resolved parameters, operation parameter overrides path item one with the same name and location
+func (d *document) parameters(source []interface{}) []map[string]interface{} {
+	var result = make([]map[string]interface{}, 0)
+	var index = map[string]int{}
+	for _, item := range source {
+		parameter := d.resolve(item)
+		if parameter == nil {
+			continue
+		}
+		key := fmt.Sprintf("%v:%v", parameter["in"], parameter["name"])
+		if i, ok := index[key]; ok {
+			result[i] = parameter
+			continue
+		}
+		index[key] = len(result)
+		result = append(result, parameter)
+	}
+	return result
+}
+
+// comparePaths orders paths segment by segment, literal segment goes before parameter
+func comparePaths(left, right string) bool {
+	leftSegments := strings.Split(left, "/")
+	rightSegments := strings.Split(right, "/")
+	for i := 0; i < len(leftSegments) && i < len(rightSegments); i++ {
+		if leftSegments[i] == rightSegments[i] {
+			continue
+		}
+		leftParam := strings.HasPrefix(leftSegments[i], "{")
+		rightParam := strings.HasPrefix(rightSegments[i], "{")
+		if leftParam != rightParam {
+			return rightParam
+		}
+		return leftSegments[i] < rightSegments[i]
+	}
+	return len(leftSegments) < len(rightSegments)
+}
+
+// response returns the first success response code and definition
+func (d *document) response(op *operation) (int, map[string]interface{}) {
+	var codes = make([]string, 0)
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	selected := ""
+	for _, code := range codes {
+		if strings.HasPrefix(code, "2") {
+			selected = code
+			break
+		}
+	}
+	if selected == "" {
+		if _, ok := op.Responses["default"]; ok {
+			selected = "default"
+		} else if len(codes) > 0 {
+			selected = codes[0]
+		}
+	}
+	code := 200
+	if selected != "" && selected != "default" {
+		_, _ = fmt.Sscanf(strings.Replace(selected, "X", "0", -1), "%d", &code)
+	}
+	return code, d.resolve(op.Responses[selected])
+}
+
+// content returns preferred content type and example of request body or response
+func (d *document) content(source map[string]interface{}) (string, interface{}, bool) {
+	contents, _ := source["content"].(map[string]interface{})
+	if len(contents) == 0 {
+		return "", nil, false
+	}
+	var contentTypes = make([]string, 0)
+	for contentType := range contents {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	contentType := contentTypes[0]
+	for _, candidate := range contentTypes {
+		if isJSON(candidate) {
+			contentType = candidate
+			break
+		}
+	}
+	mediaType := d.resolve(contents[contentType])
+	if mediaType == nil {
+		return contentType, nil, false
+	}
+	if example, ok := mediaType["example"]; ok {
+		return contentType, example, true
+	}
+	if example, ok := d.namedExample(mediaType); ok {
+		return contentType, example, true
+	}
+	if schema := d.resolve(mediaType["schema"]); schema != nil {
+		return contentType, d.example(schema, 0), true
+	}
+	return contentType, nil, false
+}
+
+// namedExample returns value of the first named example
+func (d *document) namedExample(source map[string]interface{}) (interface{}, bool) {
+	examples, _ := source["examples"].(map[string]interface{})
+	var names = make([]string, 0)
+	for name := range examples {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if example := d.resolve(examples[name]); example != nil {
+			if value, ok := example["value"]; ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// parameterExample returns parameter example value
+func (d *document) parameterExample(parameter map[string]interface{}) interface{} {
+	if example, ok := parameter["example"]; ok {
+		return example
+	}
+	if example, ok := d.namedExample(parameter); ok {
+		return example
+	}
+	return d.example(d.resolve(parameter["schema"]), 0)
+}
+
+// example returns schema example, skeleton value is generated from schema definition if no example is defined
+func (d *document) example(schema map[string]interface{}, depth int) interface{} {
+	if schema == nil || depth > maxExampleDepth {
+		return nil
+	}
+	for _, key := range []string{"example", "default"} {
+		if value, ok := schema[key]; ok {
+			return value
+		}
+	}
+	if enum, _ := schema["enum"].([]interface{}); len(enum) > 0 {
+		return enum[0]
+	}
+	if allOf, _ := schema["allOf"].([]interface{}); len(allOf) > 0 {
+		var result = map[string]interface{}{}
+		for _, item := range allOf {
+			if aMap, ok := d.example(d.resolve(item), depth+1).(map[string]interface{}); ok {
+				for k, v := range aMap {
+					result[k] = v
+				}
+			}
+		}
+		return result
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if candidates, _ := schema[key].([]interface{}); len(candidates) > 0 {
+			return d.example(d.resolve(candidates[0]), depth+1)
+		}
+	}
+	schemaType, _ := schema["type"].(string)
+	if types, ok := schema["type"].([]interface{}); ok && len(types) > 0 {
+		schemaType, _ = types[0].(string)
+	}
+	if schemaType == "" {
+		if _, ok := schema["properties"]; ok {
+			schemaType = "object"
+		} else if _, ok := schema["items"]; ok {
+			schemaType = "array"
+		}
+	}
+	switch schemaType {
+	case "object":
+		var result = map[string]interface{}{}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, property := range properties {
+			if value := d.example(d.resolve(property), depth+1); value != nil {
+				result[name] = value
+			}
+		}
+		return result
+	case "array":
+		var result = make([]interface{}, 0)
+		if item := d.example(d.resolve(schema["items"]), depth+1); item != nil {
+			result = append(result, item)
+		}
+		return result
+	case "integer", "number":
+		return 1
+	case "boolean":
+		return true
+	case "string":
+		switch schema["format"] {
+		case "date-time":
+			return "2024-01-01T00:00:00Z"
+		case "date":
+			return "2024-01-01"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000001"
+		case "email":
+			return "user@example.com"
+		case "uri", "url":
+			return "http://example.com"
+		}
+		return "string"
+	}
+	return nil
+}
+
+func isJSON(contentType string) bool {
+	return strings.Contains(contentType, "json")
+}
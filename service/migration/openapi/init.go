@@ -0,0 +1,9 @@
+package openapi
+
+import "github.com/viant/endly"
+
+func init() {
+	endly.Registry.Register(func() endly.Service {
+		return New()
+	})
+}
@@ -0,0 +1,239 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/viant/endly"
+	"gopkg.in/yaml.v3"
+)
+
+// ServiceID service to generate http/endpoint mock rules and regression use cases from OpenAPI document
+const ServiceID = "migration/openapi"
+
+const migrateServiceOpenAPIExample = `{
+  "SpecPath": "/path/to/openapi.yaml",
+  "OutputPath": "/path/where/endly/should/write/workflow",
+  "Port": 8080
+}`
+
+// RunYaml represents generated workflow starting mock endpoint and running use cases
+const RunYaml = `init:
+  baseURL: http://127.0.0.1:{{PORT}}
+pipeline:
+  mock:
+    action: http/endpoint:listen
+    request: '@mock'
+  test:
+    tag: $pathMatch
+    data:
+      '${tagId}.[]requests': '@request.json'
+    subPath: use_cases/${index}_*
+    range: 1..{{USE_CASES}}
+    template:
+      run:
+        init:
+          tagId: $tagId
+        action: run
+        request: '@send'
+`
+
+// SendYaml represents generated use case workflow, requests are validated with their expect
+const SendYaml = `init:
+  req: ${data.${tagId}.requests}
+pipeline:
+  send:
+    action: 'http/runner:send'
+    requests: $req
+`
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+type migratorService struct {
+	*endly.AbstractService
+}
+
+func (s *migratorService) migrateOpenAPI(context *endly.Context, request *MigrateOpenAPIRequest) (*MigrateOpenAPIResponse, error) {
+	data, err := os.ReadFile(request.SpecPath)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{}
+	if err = yaml.Unmarshal(data, &doc.root); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenAPI document %v, %w", request.SpecPath, err)
+	}
+	if _, ok := doc.root["paths"]; !ok {
+		return nil, fmt.Errorf("invalid OpenAPI document %v: paths were empty", request.SpecPath)
+	}
+	basePath := doc.basePath()
+	operations := doc.operations()
+	var rules = make([]interface{}, 0)
+	useCasesPath := filepath.Join(request.OutputPath, "use_cases")
+	if err = os.MkdirAll(useCasesPath, 0750); err != nil {
+		return nil, err
+	}
+	for i, op := range operations {
+		code, response := doc.response(op)
+		rules = append(rules, doc.rule(op, basePath, code, response))
+		useCasePath := filepath.Join(useCasesPath, fmt.Sprintf("%03d_%v", i+1, strings.Trim(invalidNameCharacters.ReplaceAllString(op.ID, "_"), "_")))
+		if err = os.MkdirAll(useCasePath, 0750); err != nil {
+			return nil, err
+		}
+		useCase, err := json.MarshalIndent(doc.useCase(op, basePath, code), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err = os.WriteFile(filepath.Join(useCasePath, "request.json"), useCase, 0644); err != nil {
+			return nil, err
+		}
+	}
+	mock, err := asYAML(map[string]interface{}{"port": request.Port, "rules": rules})
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(filepath.Join(request.OutputPath, "mock.yaml"), mock, 0644); err != nil {
+		return nil, err
+	}
+	defaultPath := filepath.Join(request.OutputPath, "default")
+	if err = os.MkdirAll(defaultPath, 0750); err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(filepath.Join(defaultPath, "send.yaml"), []byte(SendYaml), 0644); err != nil {
+		return nil, err
+	}
+	runYaml := strings.Replace(RunYaml, "{{PORT}}", fmt.Sprint(request.Port), 1)
+	runYaml = strings.Replace(runYaml, "{{USE_CASES}}", fmt.Sprintf("%03d", len(operations)), 1)
+	if err = os.WriteFile(filepath.Join(request.OutputPath, "run.yaml"), []byte(runYaml), 0644); err != nil {
+		return nil, err
+	}
+	return &MigrateOpenAPIResponse{
+		OutputPath: request.OutputPath,
+		Rules:      len(rules),
+		UseCases:   len(operations),
+	}, nil
+}
+
+// rule returns http/endpoint stub rule responding with operation example
+func (d *document) rule(op *operation, basePath string, code int, response map[string]interface{}) map[string]interface{} {
+	path := pathParameter.ReplaceAllStringFunc(op.Path, func(param string) string {
+		return "{" + strings.Trim(invalidNameCharacters.ReplaceAllString(param, "_"), "_") + "}"
+	})
+	var stub = map[string]interface{}{"code": code}
+	if contentType, example, ok := d.content(response); ok {
+		stub["header"] = map[string]string{"Content-Type": contentType}
+		if isJSON(contentType) {
+			stub["jsonBody"] = example
+		} else if example != nil {
+			stub["body"] = fmt.Sprint(example)
+		}
+	}
+	return map[string]interface{}{
+		"name":     op.ID,
+		"method":   op.Method,
+		"path":     basePath + path,
+		"response": stub,
+	}
+}
+
+// useCase returns http/runner request with example parameters and body, expecting operation success code
+func (d *document) useCase(op *operation, basePath string, code int) map[string]interface{} {
+	var header = http.Header{}
+	var query = url.Values{}
+	path := op.Path
+	for _, parameter := range op.Parameters {
+		name, _ := parameter["name"].(string)
+		required, _ := parameter["required"].(bool)
+		value := d.parameterExample(parameter)
+		if value == nil {
+			value = ""
+		}
+		switch parameter["in"] {
+		case "path":
+			path = strings.Replace(path, "{"+name+"}", url.PathEscape(fmt.Sprint(value)), -1)
+		case "query":
+			if required {
+				query.Set(name, fmt.Sprint(value))
+			}
+		case "header":
+			if required {
+				header.Set(name, fmt.Sprint(value))
+			}
+		}
+	}
+	URL := "${baseURL}" + basePath + path
+	if len(query) > 0 {
+		URL += "?" + query.Encode()
+	}
+	var result = map[string]interface{}{
+		"Method": op.Method,
+		"URL":    URL,
+		"Expect": map[string]interface{}{"Code": code},
+	}
+	if contentType, example, ok := d.content(op.Body); ok {
+		header.Set("Content-Type", contentType)
+		if isJSON(contentType) {
+			result["JSONBody"] = example
+		} else if example != nil {
+			result["Body"] = fmt.Sprint(example)
+		}
+	}
+	if len(header) > 0 {
+		result["Header"] = header
+	}
+	return result
+}
+
+func asYAML(source interface{}) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	encoder := yaml.NewEncoder(buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(source); err != nil {
+		return nil, err
+	}
+	err := encoder.Close()
+	return buffer.Bytes(), err
+}
+
+func (s *migratorService) registerRoutes() {
+	s.Register(&endly.Route{
+		Action: "openapi",
+		RequestInfo: &endly.ActionInfo{
+			Description: "Generate http/endpoint example based mock rules and regression use cases per OpenAPI 3 operation",
+			Examples: []*endly.UseCase{
+				{
+					Description: "migrate OpenAPI document",
+					Data:        migrateServiceOpenAPIExample,
+				},
+			},
+		},
+		RequestProvider: func() interface{} {
+			return &MigrateOpenAPIRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &MigrateOpenAPIResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*MigrateOpenAPIRequest); ok {
+				return s.migrateOpenAPI(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+}
+
+// New creates a new OpenAPI migration service
+func New() endly.Service {
+	var result = &migratorService{
+		AbstractService: endly.NewAbstractService(ServiceID),
+	}
+	result.AbstractService.Service = result
+	result.registerRoutes()
+	return result
+}
@@ -0,0 +1,125 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/service/migration/openapi"
+	"github.com/viant/toolbox"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMigratorService_OpenAPI(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, err := context.Service(openapi.ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	outputPath := filepath.Join(os.TempDir(), "endly_openapi_test")
+	_ = os.RemoveAll(outputPath)
+	response := service.Run(context, &openapi.MigrateOpenAPIRequest{
+		SpecPath:   filepath.Join("test", "spec.yaml"),
+		OutputPath: outputPath,
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	migrated := response.Response.(*openapi.MigrateOpenAPIResponse)
+	assert.EqualValues(t, 4, migrated.Rules)
+	assert.EqualValues(t, 4, migrated.UseCases)
+
+	data, err := os.ReadFile(filepath.Join(outputPath, "mock.yaml"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	mock := struct {
+		Port  int
+		Rules []map[string]interface{}
+	}{}
+	if !assert.Nil(t, yaml.Unmarshal(data, &mock)) || !assert.Len(t, mock.Rules, 4) {
+		return
+	}
+	assert.EqualValues(t, 8080, mock.Port)
+
+	var useCases = []struct {
+		description string
+		name        string
+		method      string
+		path        string
+		code        int
+		body        interface{}
+		useCase     string
+		URL         string
+	}{
+		{
+			description: "generated operation name and schema array skeleton",
+			name:        "getUsers",
+			method:      "GET",
+			path:        "/v1/users",
+			code:        200,
+			body:        []interface{}{map[string]interface{}{"id": 1, "name": "string", "created": "2024-01-01T00:00:00Z", "tags": []interface{}{"a"}}},
+			useCase:     "001_getUsers",
+			URL:         "${baseURL}/v1/users?limit=10",
+		},
+		{
+			description: "named example",
+			name:        "createUser",
+			method:      "POST",
+			path:        "/v1/users",
+			code:        201,
+			body:        map[string]interface{}{"id": 3, "name": "Bob"},
+			useCase:     "002_createUser",
+			URL:         "${baseURL}/v1/users",
+		},
+		{
+			description: "literal path before parameter",
+			name:        "me",
+			method:      "GET",
+			path:        "/v1/users/me",
+			code:        200,
+			body:        map[string]interface{}{"id": 1, "name": "me"},
+			useCase:     "003_me",
+			URL:         "${baseURL}/v1/users/me",
+		},
+		{
+			description: "path parameter",
+			name:        "getUser",
+			method:      "GET",
+			path:        "/v1/users/{user_id}",
+			code:        200,
+			useCase:     "004_getUser",
+			URL:         "${baseURL}/v1/users/12",
+		},
+	}
+	for i, useCase := range useCases {
+		rule := mock.Rules[i]
+		assert.EqualValues(t, useCase.name, rule["name"], useCase.description)
+		assert.EqualValues(t, useCase.method, rule["method"], useCase.description)
+		assert.EqualValues(t, useCase.path, rule["path"], useCase.description)
+		stub := rule["response"].(map[string]interface{})
+		assert.EqualValues(t, useCase.code, stub["code"], useCase.description)
+		if useCase.body != nil {
+			assert.EqualValues(t, useCase.body, stub["jsonBody"], useCase.description)
+		}
+
+		data, err := os.ReadFile(filepath.Join(outputPath, "use_cases", useCase.useCase, "request.json"))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		request := struct {
+			Method string
+			URL    string
+			Expect map[string]interface{}
+		}{}
+		if assert.Nil(t, json.Unmarshal(data, &request), useCase.description) {
+			assert.EqualValues(t, useCase.method, request.Method, useCase.description)
+			assert.EqualValues(t, useCase.URL, request.URL, useCase.description)
+			assert.EqualValues(t, useCase.code, request.Expect["Code"], useCase.description)
+		}
+	}
+}
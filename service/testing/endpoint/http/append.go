@@ -1,24 +1,31 @@
 package http
 
 import (
-	"fmt"
 	"github.com/viant/endly"
 	"github.com/viant/endly/model/location"
 )
 
 func (s *service) append(context *endly.Context, req *AppendRequest) (*AppendResponse, error) {
 	resp := &AppendResponse{}
-	server, ok := s.servers[req.Port]
-	if !ok {
-		return nil, fmt.Errorf("server not started on port: %v", req.Port)
+	server, err := s.server(req.Port)
+	if err != nil {
+		return nil, err
 	}
 	state := context.State()
 	if req.BaseDirectory != "" {
 		req.BaseDirectory = location.NewResource(state.ExpandAsText(req.BaseDirectory)).Path()
 	}
 
+	if len(req.Rules) > 0 {
+		if err := server.AppendRules(state.Clone(), req.Rules...); err != nil {
+			return nil, err
+		}
+	}
+	if req.BaseDirectory == "" {
+		return resp, nil
+	}
 	trips := req.AsHTTPServerTrips(server.rotate, server.indexKeys)
-	err := trips.Init(server.requestTemplate, server.responseTemplate)
+	err = trips.Init(server.requestTemplate, server.responseTemplate)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,105 @@
+package http
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/viant/assertly"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/criteria"
+	"github.com/viant/endly/service/testing/validator"
+)
+
+func (s *service) server(port int) (*Server, error) {
+	s.Mutex().Lock()
+	defer s.Mutex().Unlock()
+	server, ok := s.servers[port]
+	if !ok {
+		return nil, fmt.Errorf("server not started on port: %v", port)
+	}
+	return server, nil
+}
+
+func (s *service) assert(context *endly.Context, request *AssertRequest) (*AssertResponse, error) {
+	server, err := s.server(request.Port)
+	if err != nil {
+		return nil, err
+	}
+	var response = &AssertResponse{
+		Validations: make([]*assertly.Validation, 0),
+	}
+	requests := server.Requests()
+	for _, expect := range request.Expect {
+		matched, err := matchRequests(expect, requests)
+		if err != nil {
+			return nil, err
+		}
+		var expected = map[string]interface{}{}
+		var actual = map[string]interface{}{
+			"Count":    len(matched),
+			"Requests": matched,
+		}
+		if expect.Count != nil {
+			expected["Count"] = *expect.Count
+		}
+		if len(expect.Requests) > 0 {
+			expected["Requests"] = expect.Requests
+		}
+		taggedAssert := &validator.TaggedAssert{
+			TagID:    expect.TagID,
+			Expected: expected,
+			Actual:   actual,
+		}
+		validation, err := criteria.Assert(context, fmt.Sprintf("http(%v)", request.Port), taggedAssert.Expected, taggedAssert.Actual)
+		if err != nil {
+			return nil, err
+		}
+		validation.TagID = expect.TagID
+		validation.Description = expect.Description
+		if validation.Description == "" {
+			validation.Description = fmt.Sprintf("HTTP endpoint :%v requests %v", request.Port, strings.TrimSpace(expect.Method+" "+expect.Path+expect.PathRegex))
+		}
+		context.Publish(taggedAssert)
+		context.Publish(validation)
+		response.Validations = append(response.Validations, validation)
+	}
+	return response, nil
+}
+
+// matchRequests returns map representation of journaled requests matching expectation
+func matchRequests(expect *RequestExpect, requests []*ReceivedRequest) ([]interface{}, error) {
+	var result = make([]interface{}, 0)
+	for _, received := range requests {
+		request, err := received.asHTTPRequest()
+		if err != nil {
+			return nil, err
+		}
+		state := newRequestState(nil, request, []byte(received.Body))
+		ok, err := expect.match(request, state)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, received.AsMap())
+		}
+	}
+	return result, nil
+}
+
+func (s *service) reset(context *endly.Context, request *ResetRequest) (*ResetResponse, error) {
+	server, err := s.server(request.Port)
+	if err != nil {
+		return nil, err
+	}
+	response := &ResetResponse{Requests: len(server.Requests())}
+	server.Reset()
+	return response, nil
+}
+
+func (s *service) fault(context *endly.Context, request *FaultRequest) (*FaultResponse, error) {
+	server, err := s.server(request.Port)
+	if err != nil {
+		return nil, err
+	}
+	return &FaultResponse{}, server.SetFault(request.Rule, request.Fault)
+}
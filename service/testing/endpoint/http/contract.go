@@ -2,6 +2,8 @@ package http
 
 import (
 	"errors"
+	"fmt"
+	"github.com/viant/assertly"
 	"sync"
 )
 
@@ -11,13 +13,19 @@ type ListenRequest struct {
 	Rotate           bool
 	RequestTemplate  string   `description:"request file loading template, default: %02d-req.json"`
 	ResponseTemplate string   `description:"response file loading template, default: %02d-resp.json"`
-	BaseDirectory    string   `required:"true" description:"location with replay files (could be generate by https://github.com/viant/toolbox/blob/master/bridge/http_bridge_recording_util.go#L81"`
+	BaseDirectory    string   `description:"location with replay files (could be generate by https://github.com/viant/toolbox/blob/master/bridge/http_bridge_recording_util.go#L81"`
 	IndexKeys        []string `description:"recorded requests matching keys, by default: Method,URL,Body,Cookie,Content-Type"`
+	Rules            []*Rule  `description:"stub rules matched before recorded trips"`
+	Fault            *Fault   `description:"fault injected into all endpoint responses, unless matched rule defines fault"`
+	TLS              *TLS     `description:"serve HTTPS, certificates are issued by endly managed CA by default"`
+	HTTP2            bool     `description:"enable HTTP/2, negotiated with ALPN for TLS, otherwise cleartext h2c"`
+	JournalSize      int      `description:"max number of journaled requests, the oldest are dropped once reached, default 1000"`
 }
 
 // ListenResponse represents HTTP endpoint listen response with indexed trips
 type ListenResponse struct {
-	Trips map[string]*HTTPResponses
+	Trips      map[string]*HTTPResponses
+	CACertFile string `json:",omitempty" description:"endly managed CA certificate location, used by clients to trust endpoint"`
 }
 
 func (r *ListenRequest) Init() error {
@@ -27,7 +35,12 @@ func (r *ListenRequest) Init() error {
 	if r.ResponseTemplate == "" {
 		r.ResponseTemplate = DefaultResponseTemplate
 	}
-	return nil
+	if r.Fault != nil {
+		if err := r.Fault.Init(); err != nil {
+			return err
+		}
+	}
+	return initRules(r.Rules)
 }
 
 // Validate checks if request is valid.
@@ -35,7 +48,17 @@ func (r ListenRequest) Validate() error {
 	if r.Port == 0 {
 		return errors.New("port was empty")
 	}
-	return nil
+	if r.Fault != nil {
+		if err := r.Fault.Validate(); err != nil {
+			return err
+		}
+	}
+	if r.TLS != nil {
+		if err := r.TLS.Validate(); err != nil {
+			return err
+		}
+	}
+	return validateRules(r.Rules)
 }
 
 // AsHTTPServerTrips return a new HTTP trips.
@@ -59,21 +82,26 @@ type ShutdownRequest struct {
 
 type AppendRequest struct {
 	Port          int
-	BaseDirectory string `required:"true" description:"location with replay files (could be generate by https://github.com/viant/toolbox/blob/master/bridge/http_bridge_recording_util.go#L81"`
+	BaseDirectory string  `description:"location with replay files (could be generate by https://github.com/viant/toolbox/blob/master/bridge/http_bridge_recording_util.go#L81"`
+	Rules         []*Rule `description:"stub rules appended to existing rules"`
+}
+
+func (r *AppendRequest) Init() error {
+	return initRules(r.Rules)
 }
 
 // Validate checks if request is valid.
 func (r AppendRequest) Validate() error {
-	if r.BaseDirectory == "" {
-		return errors.New("baseDirectory was empty")
+	if r.BaseDirectory == "" && len(r.Rules) == 0 {
+		return errors.New("baseDirectory and rules were empty")
 	}
 	if r.Port == 0 {
 		return errors.New("port was empty")
 	}
-	return nil
+	return validateRules(r.Rules)
 }
 
-// ListenResponse represents HTTP endpoint listen response with indexed trips
+// AppendResponse represents HTTP endpoint append response with indexed trips
 type AppendResponse struct {
 	Trips map[string]*HTTPResponses
 }
@@ -89,3 +117,121 @@ func (r AppendRequest) AsHTTPServerTrips(rotate bool, indexKeys []string) *HTTPS
 		Mutex:         &sync.Mutex{},
 	}
 }
+
+// FaultRequest represents fault injection change request for running endpoint
+type FaultRequest struct {
+	Port  int
+	Rule  string `description:"rule name, if empty endpoint fault is changed"`
+	Fault *Fault `description:"injected fault, empty fault disables injection, nil rule fault falls back to endpoint fault"`
+}
+
+func (r *FaultRequest) Init() error {
+	if r.Fault != nil {
+		return r.Fault.Init()
+	}
+	return nil
+}
+
+// Validate checks if request is valid.
+func (r *FaultRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	if r.Fault != nil {
+		return r.Fault.Validate()
+	}
+	return nil
+}
+
+// FaultResponse represents fault injection change response
+type FaultResponse struct{}
+
+func initRules(rules []*Rule) error {
+	for _, rule := range rules {
+		if err := rule.Init(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRules(rules []*Rule) error {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RequestExpect represents expected requests, journaled requests are filtered with matcher conditions
+type RequestExpect struct {
+	TagID       string
+	Description string
+	Matcher
+	Count    *int          `description:"expected number of matching requests"`
+	Requests []interface{} `description:"expected matching requests in received order, i.e. Body, JSONBody, Header, Query, Code, Rule"`
+}
+
+// AssertRequest represents endpoint received requests assert request
+type AssertRequest struct {
+	Port   int
+	Expect []*RequestExpect `required:"true" description:"expected received requests"`
+}
+
+func (r *AssertRequest) Init() error {
+	for _, expect := range r.Expect {
+		if err := expect.Matcher.Init(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks if request is valid.
+func (r *AssertRequest) Validate() error {
+	if r.Port == 0 {
+		return errors.New("port was empty")
+	}
+	if len(r.Expect) == 0 {
+		return errors.New("expect was empty")
+	}
+	for i, expect := range r.Expect {
+		if expect.Count == nil && len(expect.Requests) == 0 {
+			return fmt.Errorf("expect[%d]: count and requests were empty", i)
+		}
+	}
+	return nil
+}
+
+// AssertResponse represents endpoint received requests assert response
+type AssertResponse struct {
+	Validations []*assertly.Validation
+}
+
+// Assertion returns validation slice
+func (r *AssertResponse) Assertion() []*assertly.Validation {
+	return r.Validations
+}
+
+// ResetRequest represents endpoint reset request
+type ResetRequest struct {
+	Port int
+}
+
+// ResetResponse represents endpoint reset response
+type ResetResponse struct {
+	Requests int `description:"number of cleared journaled requests"`
+}
+
+// CARequest represents endly managed CA certificate export request
+type CARequest struct {
+	Directory string `description:"endly managed CA location, default ~/.endly/ca"`
+	Export    string `description:"optional location to export PEM encoded CA certificate to, i.e. /tmp/endly-ca.crt"`
+}
+
+// CAResponse represents endly managed CA certificate
+type CAResponse struct {
+	CertFile string `description:"CA certificate location"`
+	CertPEM  string `description:"PEM encoded CA certificate"`
+}
@@ -0,0 +1,278 @@
+package http
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	latencyFixed       = "fixed"
+	latencyUniform     = "uniform"
+	latencyNormal      = "normal"
+	latencyExponential = "exponential"
+)
+
+// Latency represents injected latency distribution
+type Latency struct {
+	Distribution string  `description:"fixed (default), uniform, normal or exponential"`
+	Ms           float64 `description:"fixed latency, or mean for normal and exponential distribution"`
+	MinMs        float64 `description:"uniform distribution lower bound, lower limit for other distributions"`
+	MaxMs        float64 `description:"uniform distribution upper bound, upper limit for other distributions"`
+	StdDevMs     float64 `description:"normal distribution standard deviation"`
+}
+
+// Validate checks if latency is valid
+func (l *Latency) Validate() error {
+	switch strings.ToLower(l.Distribution) {
+	case "", latencyFixed, latencyNormal, latencyExponential:
+	case latencyUniform:
+		if l.MaxMs < l.MinMs {
+			return fmt.Errorf("invalid uniform latency: maxMs %v is lower than minMs %v", l.MaxMs, l.MinMs)
+		}
+	default:
+		return fmt.Errorf("unsupported latency distribution: %v, supported: %v, %v, %v, %v", l.Distribution, latencyFixed, latencyUniform, latencyNormal, latencyExponential)
+	}
+	return nil
+}
+
+func (l *Latency) duration(random *rand.Rand) time.Duration {
+	var ms float64
+	switch strings.ToLower(l.Distribution) {
+	case latencyUniform:
+		ms = l.MinMs + random.Float64()*(l.MaxMs-l.MinMs)
+	case latencyNormal:
+		ms = l.Ms + random.NormFloat64()*l.StdDevMs
+	case latencyExponential:
+		ms = random.ExpFloat64() * l.Ms
+	default:
+		ms = l.Ms
+	}
+	if l.MaxMs > 0 && ms > l.MaxMs {
+		ms = l.MaxMs
+	}
+	if ms < l.MinMs {
+		ms = l.MinMs
+	}
+	if ms < 0 {
+		ms = 0
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+// Fault represents fault injected into endpoint responses, latency applies to each request, failure mode is applied with probability
+type Fault struct {
+	Latency        *Latency `description:"response latency"`
+	Probability    float64  `description:"probability (0-1] of injecting failure mode, default 1"`
+	Count          int      `description:"max number of injected failures, i.e. 2 to fail first two requests, 0 - unlimited"`
+	Seed           int64    `description:"random generator seed for deterministic fault sequence, default current time"`
+	Code           int      `description:"failure mode: respond with error status code i.e. 503"`
+	Body           string   `description:"error status code response body"`
+	Reset          bool     `description:"failure mode: reset connection without response"`
+	TimeoutMs      int      `description:"failure mode: hold request without response, then close connection"`
+	TruncateBytes  int      `description:"failure mode: write only specified number of response body bytes, then close connection"`
+	DripBytes      int      `description:"failure mode: write response body in chunks with specified number of bytes"`
+	DripIntervalMs int      `description:"slow drip interval between chunks, default 100"`
+	mux            sync.Mutex
+	random         *rand.Rand
+	injected       int
+}
+
+// Init initialises fault
+func (f *Fault) Init() error {
+	if f.Probability == 0 {
+		f.Probability = 1
+	}
+	if f.DripBytes > 0 && f.DripIntervalMs == 0 {
+		f.DripIntervalMs = 100
+	}
+	seed := f.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	f.random = rand.New(rand.NewSource(seed))
+	return nil
+}
+
+// Validate checks if fault is valid
+func (f *Fault) Validate() error {
+	if f.Probability < 0 || f.Probability > 1 {
+		return fmt.Errorf("invalid fault probability: %v, expected (0-1]", f.Probability)
+	}
+	if f.Latency != nil {
+		if err := f.Latency.Validate(); err != nil {
+			return err
+		}
+	}
+	var modes = make([]string, 0)
+	if f.Code > 0 {
+		modes = append(modes, "code")
+	}
+	if f.Reset {
+		modes = append(modes, "reset")
+	}
+	if f.TimeoutMs > 0 {
+		modes = append(modes, "timeoutMs")
+	}
+	if f.TruncateBytes > 0 {
+		modes = append(modes, "truncateBytes")
+	}
+	if f.DripBytes > 0 {
+		modes = append(modes, "dripBytes")
+	}
+	if len(modes) > 1 {
+		return fmt.Errorf("only one fault failure mode can be used, but had: %v", strings.Join(modes, ", "))
+	}
+	return nil
+}
+
+// reset restarts injected failures count and seeded random sequence
+func (f *Fault) reset() {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.injected = 0
+	if f.Seed != 0 {
+		f.random = rand.New(rand.NewSource(f.Seed))
+	}
+}
+
+// next returns latency and flag if failure mode has to be applied
+func (f *Fault) next() (time.Duration, bool) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	var latency time.Duration
+	if f.Latency != nil {
+		latency = f.Latency.duration(f.random)
+	}
+	if f.Count > 0 && f.injected >= f.Count {
+		return latency, false
+	}
+	fail := f.random.Float64() < f.Probability
+	if fail {
+		f.injected++
+	}
+	return latency, fail
+}
+
+// apply serves request with injected latency and failure
+func (f *Fault) apply(writer http.ResponseWriter, request *http.Request, serve func(writer http.ResponseWriter)) {
+	latency, fail := f.next()
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-request.Context().Done():
+			return
+		}
+	}
+	if !fail {
+		serve(writer)
+		return
+	}
+	switch {
+	case f.Code > 0:
+		writer.WriteHeader(f.Code)
+		if f.Body != "" {
+			_, _ = writer.Write([]byte(f.Body))
+		}
+	case f.Reset:
+		closeConnection(writer, true)
+	case f.TimeoutMs > 0:
+		select {
+		case <-time.After(time.Duration(f.TimeoutMs) * time.Millisecond):
+			closeConnection(writer, false)
+		case <-request.Context().Done():
+		}
+	case f.TruncateBytes > 0, f.DripBytes > 0:
+		recorder := httptest.NewRecorder()
+		serve(recorder)
+		body := recorder.Body.Bytes()
+		for k, v := range recorder.Header() {
+			writer.Header()[k] = v
+		}
+		writer.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		writer.WriteHeader(recorder.Code)
+		if f.TruncateBytes > 0 {
+			if f.TruncateBytes < len(body) {
+				_, _ = writer.Write(body[:f.TruncateBytes])
+				closeConnection(writer, false)
+				return
+			}
+			_, _ = writer.Write(body)
+			return
+		}
+		f.drip(writer, request, body)
+	default:
+		serve(writer)
+	}
+}
+
+// drip writes body in chunks
+func (f *Fault) drip(writer http.ResponseWriter, request *http.Request, body []byte) {
+	for offset := 0; offset < len(body); offset += f.DripBytes {
+		end := offset + f.DripBytes
+		if end > len(body) {
+			end = len(body)
+		}
+		if _, err := writer.Write(body[offset:end]); err != nil {
+			return
+		}
+		if flusher, ok := writer.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		if end == len(body) {
+			return
+		}
+		select {
+		case <-time.After(time.Duration(f.DripIntervalMs) * time.Millisecond):
+		case <-request.Context().Done():
+			return
+		}
+	}
+}
+
+// closeConnection closes underlying connection, reset discards unsent data and sends RST
+func closeConnection(writer http.ResponseWriter, reset bool) {
+	if flusher, ok := writer.(http.Flusher); ok && !reset {
+		flusher.Flush()
+	}
+	hijacker, ok := writer.(http.Hijacker)
+	if !ok {
+		return
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	if tlsConn, ok := conn.(*tls.Conn); ok && reset {
+		conn = tlsConn.NetConn()
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok && reset {
+		_ = tcpConn.SetLinger(0)
+	}
+	_ = conn.Close()
+}
+
+// faultHolder represents fault that can be changed while endpoint is running
+type faultHolder struct {
+	mux   sync.RWMutex
+	fault *Fault
+}
+
+func (h *faultHolder) get() *Fault {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+	return h.fault
+}
+
+func (h *faultHolder) set(fault *Fault) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	h.fault = fault
+}
@@ -0,0 +1,79 @@
+package http_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	endpoint "github.com/viant/endly/service/testing/endpoint/http"
+	"github.com/viant/toolbox"
+)
+
+func TestHTTPEndpointService_Fault(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, _ := context.Service(endpoint.ServiceID)
+	response := service.Run(context, &endpoint.ListenRequest{
+		Port:  7720,
+		Fault: &endpoint.Fault{Code: 503, Count: 2},
+		Rules: []*endpoint.Rule{
+			{Name: "ok", Matcher: endpoint.Matcher{Path: "/ok"}, Response: &endpoint.StubResponse{Body: "0123456789"}},
+			{Name: "reset", Matcher: endpoint.Matcher{Path: "/reset"}, Fault: &endpoint.Fault{Reset: true}},
+			{Name: "truncate", Matcher: endpoint.Matcher{Path: "/truncate"}, Response: &endpoint.StubResponse{Body: "0123456789"}, Fault: &endpoint.Fault{TruncateBytes: 4}},
+			{Name: "drip", Matcher: endpoint.Matcher{Path: "/drip"}, Response: &endpoint.StubResponse{Body: "0123456789"}, Fault: &endpoint.Fault{DripBytes: 5, DripIntervalMs: 200}},
+			{Name: "timeout", Matcher: endpoint.Matcher{Path: "/timeout"}, Fault: &endpoint.Fault{TimeoutMs: 2000}},
+			{Name: "slow", Matcher: endpoint.Matcher{Path: "/slow"}, Fault: &endpoint.Fault{Latency: &endpoint.Latency{Distribution: "uniform", MinMs: 150, MaxMs: 200}}},
+		},
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	client := &http.Client{Timeout: 500 * time.Millisecond}
+
+	var useCases = []struct {
+		description string
+		path        string
+		hasError    bool
+		code        int
+		body        string
+		minElapsed  time.Duration
+	}{
+		{description: "first injected 503", path: "/ok", code: 503},
+		{description: "second injected 503", path: "/ok", code: 503},
+		{description: "fault count exhausted", path: "/ok", code: 200, body: "0123456789"},
+		{description: "connection reset", path: "/reset", hasError: true},
+		{description: "truncated body", path: "/truncate", code: 200, hasError: true},
+		{description: "slow drip", path: "/drip", code: 200, body: "0123456789", minElapsed: 200 * time.Millisecond},
+		{description: "timeout", path: "/timeout", hasError: true, minElapsed: 500 * time.Millisecond},
+		{description: "latency", path: "/slow", code: 200, minElapsed: 150 * time.Millisecond},
+	}
+
+	for _, useCase := range useCases {
+		started := time.Now()
+		response, err := client.Get("http://127.0.0.1:7720" + useCase.path)
+		var body []byte
+		if err == nil {
+			assert.EqualValues(t, useCase.code, response.StatusCode, useCase.description)
+			body, err = ioutil.ReadAll(response.Body)
+			_ = response.Body.Close()
+		}
+		assert.EqualValues(t, useCase.hasError, err != nil, useCase.description)
+		if useCase.body != "" {
+			assert.EqualValues(t, useCase.body, string(body), useCase.description)
+		}
+		assert.True(t, time.Since(started) >= useCase.minElapsed, useCase.description)
+	}
+
+	response = service.Run(context, &endpoint.FaultRequest{Port: 7720, Rule: "ok", Fault: &endpoint.Fault{Code: 500, Probability: 1}})
+	if assert.Equal(t, "", response.Error) {
+		response, err := client.Get("http://127.0.0.1:7720/ok")
+		if assert.Nil(t, err) {
+			assert.EqualValues(t, 500, response.StatusCode)
+		}
+	}
+	response = service.Run(context, &endpoint.FaultRequest{Port: 7720, Rule: "missing", Fault: &endpoint.Fault{}})
+	assert.NotEqual(t, "", response.Error)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/viant/endly/internal/util"
 	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
 	"log"
 	"net/http"
 	"strings"
@@ -15,6 +16,9 @@ type httpHandler struct {
 	running   int32
 	handler   func(writer http.ResponseWriter, request *http.Request)
 	thinkTime time.Duration
+	stubs     *stubs
+	journal   *journal
+	faults    faultHolder
 }
 
 const (
@@ -26,7 +30,33 @@ func (h *httpHandler) ServeHTTP(writer http.ResponseWriter, request *http.Reques
 		h.thinkTime = time.Duration(toolbox.AsInt(thinkTime)) * time.Millisecond
 		fmt.Printf("Updated think time: %s\n", h.thinkTime)
 	}
-	h.handler(writer, request)
+	request, entry := h.journal.record(request)
+	writer = &journalWriter{ResponseWriter: writer, journal: h.journal, entry: entry}
+	var rule *Rule
+	var state data.Map
+	if atomic.LoadInt32(&h.running) == 1 {
+		rule, state = h.stubs.match(request)
+	}
+	fault := h.faults.get()
+	serve := func(writer http.ResponseWriter) {
+		h.handler(writer, request)
+	}
+	if rule != nil {
+		h.journal.update(entry, func(entry *ReceivedRequest) {
+			entry.Rule = rule.Name
+		})
+		if ruleFault := rule.faults.get(); ruleFault != nil {
+			fault = ruleFault
+		}
+		serve = func(writer http.ResponseWriter) {
+			writeStubResponse(writer, rule, rule.next(), state)
+		}
+	}
+	if fault == nil {
+		serve(writer)
+		return
+	}
+	fault.apply(writer, request, serve)
 }
 
 func getServerHandler(httpServer *http.Server, httpHandler *httpHandler, trips *HTTPServerTrips) func(writer http.ResponseWriter, request *http.Request) {
@@ -44,6 +74,11 @@ func getServerHandler(httpServer *http.Server, httpHandler *httpHandler, trips *
 		}
 
 		responses, ok := trips.Trips[key]
+		if entry := receivedRequest(request); entry != nil && ok {
+			httpHandler.journal.update(entry, func(entry *ReceivedRequest) {
+				entry.Trip = key
+			})
+		}
 		if !ok {
 			var errorMessage = fmt.Sprintf("key: %v not found, available: \n%v", key, strings.Join(toolbox.MapKeysToStringSlice(trips.Trips), ",\n"))
 			fmt.Println(errorMessage)
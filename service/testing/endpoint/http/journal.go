@@ -0,0 +1,203 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/viant/toolbox"
+)
+
+const defaultJournalSize = 1000
+
+type journalKey struct{}
+
+// ReceivedRequest represents request journaled by endpoint
+type ReceivedRequest struct {
+	Time     time.Time
+	Method   string
+	URL      string
+	Path     string
+	Header   map[string]string
+	Query    map[string]string
+	Body     string
+	JSONBody interface{} `json:",omitempty"`
+	Rule     string      `json:",omitempty" description:"matched stub rule name"`
+	Trip     string      `json:",omitempty" description:"matched recorded trip key"`
+	Code     int
+}
+
+// AsMap returns map representation used for assertion
+func (r *ReceivedRequest) AsMap() map[string]interface{} {
+	var result = map[string]interface{}{
+		"Time":   r.Time,
+		"Method": r.Method,
+		"URL":    r.URL,
+		"Path":   r.Path,
+		"Header": r.Header,
+		"Query":  r.Query,
+		"Body":   r.Body,
+		"Rule":   r.Rule,
+		"Trip":   r.Trip,
+		"Code":   r.Code,
+	}
+	if r.JSONBody != nil {
+		result["JSONBody"] = r.JSONBody
+	}
+	return result
+}
+
+// asHTTPRequest returns HTTP request used for matching
+func (r *ReceivedRequest) asHTTPRequest() (*http.Request, error) {
+	request, err := http.NewRequest(r.Method, r.URL, strings.NewReader(r.Body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.Header {
+		request.Header.Set(k, v)
+	}
+	return request, nil
+}
+
+func newReceivedRequest(request *http.Request, body []byte) *ReceivedRequest {
+	var result = &ReceivedRequest{
+		Time:   time.Now(),
+		Method: request.Method,
+		URL:    request.URL.String(),
+		Path:   request.URL.Path,
+		Header: make(map[string]string),
+		Query:  make(map[string]string),
+		Body:   string(body),
+	}
+	for k, v := range request.Header {
+		result.Header[k] = strings.Join(v, ",")
+	}
+	for k := range request.URL.Query() {
+		result.Query[k] = request.URL.Query().Get(k)
+	}
+	if toolbox.IsStructuredJSON(result.Body) {
+		result.JSONBody, _ = toolbox.JSONToInterface(result.Body)
+	}
+	return result
+}
+
+// receivedRequest returns journal entry of the supplied request
+func receivedRequest(request *http.Request) *ReceivedRequest {
+	if entry, ok := request.Context().Value(journalKey{}).(*ReceivedRequest); ok {
+		return entry
+	}
+	return nil
+}
+
+// journal represents received requests log, once size is reached the oldest requests are dropped
+type journal struct {
+	mux      sync.Mutex
+	size     int
+	requests []*ReceivedRequest
+	next     int //oldest entry index once journal is full
+}
+
+// record journals request, returns request with journal entry in context
+func (j *journal) record(request *http.Request) (*http.Request, *ReceivedRequest) {
+	var body []byte
+	if request.Body != nil {
+		body, _ = ioutil.ReadAll(request.Body)
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	entry := newReceivedRequest(request, body)
+	j.mux.Lock()
+	if len(j.requests) < j.size {
+		j.requests = append(j.requests, entry)
+	} else {
+		j.requests[j.next] = entry
+		j.next = (j.next + 1) % j.size
+	}
+	j.mux.Unlock()
+	return request.WithContext(context.WithValue(request.Context(), journalKey{}, entry)), entry
+}
+
+// update updates journaled entry while request is served
+func (j *journal) update(entry *ReceivedRequest, update func(entry *ReceivedRequest)) {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	update(entry)
+}
+
+// snapshot returns copies of journaled requests in receiving order
+func (j *journal) snapshot() []*ReceivedRequest {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	var result = make([]*ReceivedRequest, 0, len(j.requests))
+	for i := range j.requests {
+		entry := *j.requests[(j.next+i)%len(j.requests)]
+		result = append(result, &entry)
+	}
+	return result
+}
+
+// reset clears journal
+func (j *journal) reset() {
+	j.mux.Lock()
+	defer j.mux.Unlock()
+	j.requests = nil
+	j.next = 0
+}
+
+func newJournal(size int) *journal {
+	if size <= 0 {
+		size = defaultJournalSize
+	}
+	return &journal{size: size}
+}
+
+// journalWriter captures response status code
+type journalWriter struct {
+	http.ResponseWriter
+	journal *journal
+	entry   *ReceivedRequest
+	code    int
+}
+
+// WriteHeader records status code
+func (w *journalWriter) WriteHeader(code int) {
+	w.setCode(code)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write records default status code
+func (w *journalWriter) Write(data []byte) (int, error) {
+	if w.code == 0 {
+		w.setCode(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *journalWriter) setCode(code int) {
+	w.code = code
+	w.journal.update(w.entry, func(entry *ReceivedRequest) {
+		entry.Code = code
+	})
+}
+
+// Flush sends buffered data to the client
+func (w *journalWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets fault injection take over the connection
+func (w *journalWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("hijacking is not supported by %T", w.ResponseWriter)
+	}
+	return hijacker.Hijack()
+}
@@ -0,0 +1,171 @@
+package http_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	endpoint "github.com/viant/endly/service/testing/endpoint/http"
+	"github.com/viant/toolbox"
+)
+
+func TestHTTPEndpointService_Journal(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, _ := context.Service(endpoint.ServiceID)
+
+	response := service.Run(context, &endpoint.ListenRequest{
+		Port: 7725,
+		Rules: []*endpoint.Rule{
+			{
+				Name:     "premium user",
+				Matcher:  endpoint.Matcher{Method: "POST", Path: "/users/{id}/orders", When: "$body.user.tier = premium"},
+				Response: &endpoint.StubResponse{Code: 201},
+			},
+			{
+				Name:     "any user",
+				Matcher:  endpoint.Matcher{Method: "POST", Path: "/users/*"},
+				Response: &endpoint.StubResponse{Code: 201},
+			},
+			{
+				Name:      "search",
+				Matcher:   endpoint.Matcher{Method: "GET", Path: "/search"},
+				Responses: []*endpoint.StubResponse{{Code: 503}, {Body: "found"}},
+			},
+		},
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	var requests = []struct {
+		method string
+		URL    string
+		body   string
+	}{
+		{method: "POST", URL: "http://127.0.0.1:7725/users/12/orders", body: `{"user":{"tier":"premium"},"items":[{"sku":"A-1"}]}`},
+		{method: "POST", URL: "http://127.0.0.1:7725/users/13/orders", body: `{"user":{"tier":"basic"}}`},
+		{method: "GET", URL: "http://127.0.0.1:7725/unknown"},
+		{method: "GET", URL: "http://127.0.0.1:7725/search"},
+		{method: "GET", URL: "http://127.0.0.1:7725/search"},
+	}
+	for _, item := range requests {
+		if !assert.Nil(t, send(item.method, item.URL, item.body), item.URL) {
+			return
+		}
+	}
+
+	once, none := 1, 0
+	response = service.Run(context, &endpoint.AssertRequest{
+		Port: 7725,
+		Expect: []*endpoint.RequestExpect{
+			{
+				TagID:    "orders",
+				Matcher:  endpoint.Matcher{Method: "POST", Path: "/users/{id}/orders", When: "$body.user.tier = premium"},
+				Count:    &once,
+				Requests: []interface{}{map[string]interface{}{"Path": "/users/12/orders", "JSONBody": map[string]interface{}{"items": []interface{}{map[string]interface{}{"sku": "A-1"}}}, "Rule": "premium user", "Code": 201}},
+			},
+			{
+				TagID:    "search",
+				Matcher:  endpoint.Matcher{Path: "/search"},
+				Requests: []interface{}{map[string]interface{}{"Rule": "search", "Code": 503}, map[string]interface{}{"Code": 200}},
+			},
+			{
+				TagID:   "unexpected",
+				Matcher: endpoint.Matcher{Method: "DELETE"},
+				Count:   &none,
+			},
+		},
+	})
+	if assert.Equal(t, "", response.Error) {
+		assertResponse := response.Response.(*endpoint.AssertResponse)
+		if assert.Len(t, assertResponse.Validations, 3) {
+			for _, validation := range assertResponse.Validations {
+				assert.False(t, validation.HasFailure(), validation.Report())
+			}
+		}
+	}
+
+	response = service.Run(context, &endpoint.AssertRequest{
+		Port:   7725,
+		Expect: []*endpoint.RequestExpect{{Matcher: endpoint.Matcher{Path: "/users/*"}, Count: &once}},
+	})
+	if assert.Equal(t, "", response.Error) {
+		assert.True(t, response.Response.(*endpoint.AssertResponse).Validations[0].HasFailure())
+	}
+
+	response = service.Run(context, &endpoint.ResetRequest{Port: 7725})
+	if assert.Equal(t, "", response.Error) {
+		assert.EqualValues(t, len(requests), response.Response.(*endpoint.ResetResponse).Requests)
+	}
+	response = service.Run(context, &endpoint.AssertRequest{
+		Port:   7725,
+		Expect: []*endpoint.RequestExpect{{Matcher: endpoint.Matcher{Path: "/users/*"}, Count: &none}},
+	})
+	if assert.Equal(t, "", response.Error) {
+		assert.False(t, response.Response.(*endpoint.AssertResponse).Validations[0].HasFailure())
+	}
+}
+
+func TestHTTPEndpointService_JournalSize(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, _ := context.Service(endpoint.ServiceID)
+
+	response := service.Run(context, &endpoint.ListenRequest{
+		Port:        7726,
+		JournalSize: 3,
+		Rules: []*endpoint.Rule{
+			{Name: "items", Matcher: endpoint.Matcher{Path: "/items/*"}, Response: &endpoint.StubResponse{Code: 200}},
+		},
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	var waitGroup sync.WaitGroup
+	for i := 0; i < 20; i++ { //concurrent requests with journal reads
+		waitGroup.Add(2)
+		go func(i int) {
+			defer waitGroup.Done()
+			_ = send("GET", fmt.Sprintf("http://127.0.0.1:7726/items/%d", i), "")
+		}(i)
+		go func() {
+			defer waitGroup.Done()
+			service.Run(context, &endpoint.AssertRequest{Port: 7726, Expect: []*endpoint.RequestExpect{{Matcher: endpoint.Matcher{Path: "/items/*"}}}})
+		}()
+	}
+	waitGroup.Wait()
+	for i := 20; i < 25; i++ {
+		if !assert.Nil(t, send("GET", fmt.Sprintf("http://127.0.0.1:7726/items/%d", i), "")) {
+			return
+		}
+	}
+	count := 3
+	response = service.Run(context, &endpoint.AssertRequest{
+		Port: 7726,
+		Expect: []*endpoint.RequestExpect{{
+			Matcher:  endpoint.Matcher{Path: "/items/*"},
+			Count:    &count,
+			Requests: []interface{}{map[string]interface{}{"Path": "/items/22"}, map[string]interface{}{"Path": "/items/23"}, map[string]interface{}{"Path": "/items/24", "Rule": "items", "Code": 200}},
+		}},
+	})
+	if assert.Equal(t, "", response.Error) {
+		validation := response.Response.(*endpoint.AssertResponse).Validations[0]
+		assert.False(t, validation.HasFailure(), validation.Report())
+	}
+}
+
+func send(method, URL, body string) error {
+	request, err := http.NewRequest(method, URL, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	return response.Body.Close()
+}
@@ -56,19 +56,48 @@ func StartRecorder(targetURLs ...string) error {
 			&bridge.HttpBridgeProxyRoute{
 				Pattern:   urlPath,
 				TargetURL: URL,
+				Listener:  bridge.HttpFileRecorder(outputDirectory, false),
 			})
 	}
-	recorderBridge, err := bridge.StartRecordingBridge(port, outputDirectory, routes...)
+	recorderBridge, err := bridge.NewHttpBridge(&bridge.HttpBridgeConfig{
+		Endpoint: &bridge.HttpBridgeEndpointConfig{
+			Port: port,
+		},
+		Proxy: &bridge.HttpBridgeProxyConfig{
+			BufferPoolSize: 2,
+			BufferSize:     8 * 1024,
+		},
+		Routes: routes,
+	}, bridge.NewProxyRecordingHandler)
+	if err != nil {
+		return err
+	}
 	if isSecure {
 		var serverCert = "server.crt"
 		var serverKey = "server.key"
-		if toolbox.FileExists(serverCert) {
-			return fmt.Errorf("SSL server cert file does not exists %v", serverCert)
+		var serverTLS = &TLS{}
+		if toolbox.FileExists(serverCert) || toolbox.FileExists(serverKey) {
+			if !toolbox.FileExists(serverCert) {
+				return fmt.Errorf("SSL server cert file does not exists %v", serverCert)
+			}
+			if !toolbox.FileExists(serverKey) {
+				return fmt.Errorf("SSL server key file does not exists %v", serverKey)
+			}
+			serverTLS.CertFile, serverTLS.KeyFile = serverCert, serverKey
+		}
+		tlsConfig, ca, err := serverTLS.Config(true)
+		if err != nil {
+			return err
 		}
-		if toolbox.FileExists(serverCert) {
-			return fmt.Errorf("SSL server key file does not exists %v", serverKey)
+		if ca != nil {
+			caCert := path.Join(outputDirectory, caCertFile)
+			if err = ca.Export(caCert); err != nil {
+				return err
+			}
+			log.Printf("server certificates are issued by endly CA, trust %v to capture HTTPS traffic", caCert)
 		}
-		return recorderBridge.ListenAndServeTLS(serverCert, serverKey)
+		recorderBridge.Server.TLSConfig = tlsConfig
+		return recorderBridge.Server.ListenAndServeTLS("", "")
 	}
 	return recorderBridge.ListenAndServe()
 }
@@ -1,7 +1,11 @@
 package http
 
 import (
+	"crypto/tls"
 	"fmt"
+	"github.com/viant/toolbox/data"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"net/http"
 	"sync"
 	"sync/atomic"
@@ -39,8 +43,41 @@ func (s *Server) Append(trips *HTTPServerTrips) {
 	s.httpHandler.handler = getServerHandler(&s.Server, s.httpHandler, trips)
 }
 
+// AppendRules adds stub rules, state is used to expand response templates
+func (s *Server) AppendRules(state data.Map, rules ...*Rule) error {
+	return s.httpHandler.stubs.append(state, rules...)
+}
+
+// SetFault changes fault injected into responses of the named rule, or into all responses if rule is empty
+func (s *Server) SetFault(rule string, fault *Fault) error {
+	if rule != "" {
+		return s.httpHandler.stubs.setFault(rule, fault)
+	}
+	s.httpHandler.faults.set(fault)
+	return nil
+}
+
+// Requests returns journaled requests
+func (s *Server) Requests() []*ReceivedRequest {
+	return s.httpHandler.journal.snapshot()
+}
+
+// Reset clears requests journal, rewinds stub rules response sequences and faults
+func (s *Server) Reset() {
+	s.httpHandler.journal.reset()
+	s.httpHandler.stubs.reset()
+	if fault := s.httpHandler.faults.get(); fault != nil {
+		fault.reset()
+	}
+}
+
 // StartServer starts http request, the server has ability to replay recorded  HTTP trips with https://github.com/viant/toolbox/blob/master/bridge/http_bridge_recording_util.go#L82
 func StartServer(port int, trips *HTTPServerTrips, reqTemplate, respTemplate string) (*Server, error) {
+	return startServer(port, trips, reqTemplate, respTemplate, nil, false, defaultJournalSize)
+}
+
+// startServer starts HTTP server, HTTPS if TLS config is supplied, enableHTTP2 enables h2 for TLS or h2c for cleartext
+func startServer(port int, trips *HTTPServerTrips, reqTemplate, respTemplate string, tlsConfig *tls.Config, enableHTTP2 bool, journalSize int) (*Server, error) {
 	err := trips.Init(reqTemplate, respTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start http server :%v, %v", port, err)
@@ -48,6 +85,8 @@ func StartServer(port int, trips *HTTPServerTrips, reqTemplate, respTemplate str
 
 	var httpHandler = &httpHandler{
 		running: 1,
+		stubs:   &stubs{},
+		journal: newJournal(journalSize),
 	}
 
 	server := &Server{
@@ -60,11 +99,24 @@ func StartServer(port int, trips *HTTPServerTrips, reqTemplate, respTemplate str
 		responseTemplate: respTemplate,
 	}
 	httpHandler.handler = getServerHandler(&server.Server, httpHandler, trips)
+	if tlsConfig != nil {
+		server.TLSConfig = tlsConfig
+		if !enableHTTP2 {
+			server.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+		}
+	} else if enableHTTP2 {
+		server.Handler = h2c.NewHandler(httpHandler, &http2.Server{})
+	}
 
 	errorNotification := make(chan bool, 1)
 	go func() {
-		fmt.Printf("Starting server on %v\n", port)
-		err = server.Server.ListenAndServe()
+		if tlsConfig != nil {
+			fmt.Printf("Starting TLS server on %v\n", port)
+			err = server.Server.ListenAndServeTLS("", "")
+		} else {
+			fmt.Printf("Starting server on %v\n", port)
+			err = server.Server.ListenAndServe()
+		}
 		atomic.StoreInt32(&httpHandler.running, 0)
 		errorNotification <- true
 		if err != nil {
@@ -1,9 +1,11 @@
 package http
 
 import (
+	"crypto/tls"
 	"fmt"
 	"github.com/viant/endly"
 	"github.com/viant/endly/model/location"
+	"github.com/viant/toolbox/data"
 	"strconv"
 )
 
@@ -32,7 +34,7 @@ func (s *service) shutdown(context *endly.Context, req *ShutdownRequest) (interf
 func (s *service) listen(context *endly.Context, request *ListenRequest) (*ListenResponse, error) {
 	state := context.State()
 	if request.BaseDirectory != "" {
-		request.BaseDirectory = location.NewResource(state.ExpandAsText(request.BaseDirectory)).Path()
+		request.BaseDirectory = resourcePath(state, request.BaseDirectory)
 	}
 	key := ServiceID + ":" + strconv.Itoa(request.Port)
 	s.Mutex().Lock()
@@ -46,20 +48,65 @@ func (s *service) listen(context *endly.Context, request *ListenRequest) (*Liste
 		}
 	}
 	trips := request.AsHTTPServerTrips()
-
-	server, err := StartServer(request.Port, trips, request.RequestTemplate, request.ResponseTemplate)
+	response = &ListenResponse{}
+	var tlsConfig *tls.Config
+	if request.TLS != nil {
+		request.TLS.CertFile = resourcePath(state, request.TLS.CertFile)
+		request.TLS.KeyFile = resourcePath(state, request.TLS.KeyFile)
+		request.TLS.CADirectory = resourcePath(state, request.TLS.CADirectory)
+		config, ca, err := request.TLS.Config(request.HTTP2)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = config
+		if ca != nil {
+			response.CACertFile = ca.CertFile()
+		}
+	}
+	server, err := startServer(request.Port, trips, request.RequestTemplate, request.ResponseTemplate, tlsConfig, request.HTTP2, request.JournalSize)
 	if err != nil {
 		return nil, err
 	}
 
-	s.servers[request.Port] = server
-	response = &ListenResponse{
-		Trips: trips.Trips,
+	if len(request.Rules) > 0 {
+		if err = server.AppendRules(state.Clone(), request.Rules...); err != nil {
+			_ = server.Close()
+			return nil, err
+		}
+	}
+	if request.Fault != nil {
+		_ = server.SetFault("", request.Fault)
 	}
+	s.servers[request.Port] = server
+	response.Trips = trips.Trips
 	serviceState.Put(key, response)
 	return response, nil
 }
 
+func (s *service) ca(context *endly.Context, request *CARequest) (*CAResponse, error) {
+	state := context.State()
+	ca, err := LoadCA(resourcePath(state, request.Directory))
+	if err != nil {
+		return nil, err
+	}
+	var response = &CAResponse{CertFile: ca.CertFile(), CertPEM: string(ca.CertPEM())}
+	if request.Export != "" {
+		response.CertFile = resourcePath(state, request.Export)
+		if err = ca.Export(response.CertFile); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// resourcePath returns expanded local path or empty string
+func resourcePath(state data.Map, URL string) string {
+	if URL == "" {
+		return ""
+	}
+	return location.NewResource(state.ExpandAsText(URL)).Path()
+}
+
 func (s *service) registerRoutes() {
 	s.Register(&endly.Route{
 		Action: "listen",
@@ -97,6 +144,78 @@ func (s *service) registerRoutes() {
 				return nil, fmt.Errorf("unsupported request type: %T", request)
 			},
 		},
+		&endly.Route{
+			Action: "assert",
+			RequestInfo: &endly.ActionInfo{
+				Description: "assert requests received by HTTP endpoint",
+			},
+			RequestProvider: func() interface{} {
+				return &AssertRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &AssertResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*AssertRequest); ok {
+					return s.assert(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
+		&endly.Route{
+			Action: "reset",
+			RequestInfo: &endly.ActionInfo{
+				Description: "clear requests received by HTTP endpoint and rewind stub response sequences",
+			},
+			RequestProvider: func() interface{} {
+				return &ResetRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &ResetResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*ResetRequest); ok {
+					return s.reset(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
+		&endly.Route{
+			Action: "fault",
+			RequestInfo: &endly.ActionInfo{
+				Description: "change fault injected into HTTP endpoint responses",
+			},
+			RequestProvider: func() interface{} {
+				return &FaultRequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &FaultResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*FaultRequest); ok {
+					return s.fault(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
+		&endly.Route{
+			Action: "ca",
+			RequestInfo: &endly.ActionInfo{
+				Description: "return endly managed CA certificate, optionally exported for HTTPS clients",
+			},
+			RequestProvider: func() interface{} {
+				return &CARequest{}
+			},
+			ResponseProvider: func() interface{} {
+				return &CAResponse{}
+			},
+			Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+				if req, ok := request.(*CARequest); ok {
+					return s.ca(context, req)
+				}
+				return nil, fmt.Errorf("unsupported request type: %T", request)
+			},
+		},
 		&endly.Route{
 			Action: "shutdown",
 			RequestInfo: &endly.ActionInfo{
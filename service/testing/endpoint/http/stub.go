@@ -0,0 +1,357 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/viant/endly/model/criteria"
+	"github.com/viant/endly/model/criteria/compiler"
+	"github.com/viant/endly/model/criteria/eval"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+)
+
+var pathVariable = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// StubResponse represents templated stub response, $request, $path, $query, $header, $body and workflow state are expanded
+type StubResponse struct {
+	Code     int               `description:"HTTP status code, default 200"`
+	Header   map[string]string `description:"response headers"`
+	Body     string            `description:"response body template, i.e. {\"id\":\"$path.id\",\"name\":\"$body.name\"}"`
+	JSONBody interface{}       `description:"response body JSON representation, takes precedence over Body"`
+	DelayMs  int               `description:"delay before response is written"`
+}
+
+// Matcher represents request matching conditions, all specified conditions have to match
+type Matcher struct {
+	Method    string            `description:"HTTP method, any if empty"`
+	Path      string            `description:"request path, * matches any characters, {name} matches path segment available as $path.name, i.e. /v1/users/{id}"`
+	PathRegex string            `description:"request path regular expression, named groups are available as $path.<name>"`
+	Query     map[string]string `description:"query parameters, value is exact match, * wildcard or /regexp/"`
+	Header    map[string]string `description:"request headers, value is exact match, * wildcard or /regexp/"`
+	When      string            `description:"criteria evaluated with request data, i.e. $body.user.id = 101 or $body.name:/^test/"`
+	pathExpr  *regexp.Regexp
+	query     map[string]*regexp.Regexp
+	header    map[string]*regexp.Regexp
+	whenEval  eval.Compute
+}
+
+// Init compiles matching conditions
+func (m *Matcher) Init() (err error) {
+	switch {
+	case m.PathRegex != "":
+		if m.pathExpr, err = regexp.Compile(m.PathRegex); err != nil {
+			return fmt.Errorf("invalid pathRegex: %v, %w", m.PathRegex, err)
+		}
+	case m.Path != "":
+		expr := regexp.QuoteMeta(m.Path)
+		expr = strings.Replace(expr, `\*`, ".*", -1)
+		expr = pathVariable.ReplaceAllString(strings.Replace(strings.Replace(expr, `\{`, "{", -1), `\}`, "}", -1), "(?P<$1>[^/]+)")
+		if m.pathExpr, err = regexp.Compile("^" + expr + "$"); err != nil {
+			return fmt.Errorf("invalid path: %v, %w", m.Path, err)
+		}
+	}
+	if m.query, err = compileValueMatchers(m.Query); err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+	if m.header, err = compileValueMatchers(m.Header); err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	}
+	if m.When != "" {
+		newCompute, err := compiler.Compile(m.When)
+		if err != nil {
+			return fmt.Errorf("invalid when: %v, %w", m.When, err)
+		}
+		if m.whenEval, err = newCompute(); err != nil {
+			return fmt.Errorf("invalid when: %v, %w", m.When, err)
+		}
+	}
+	return nil
+}
+
+// Rule represents stub rule, the first rule matching request responds
+type Rule struct {
+	Name string `description:"rule name"`
+	Matcher
+	DelayMs   int             `description:"delay applied to each response"`
+	Response  *StubResponse   `description:"response returned for each match"`
+	Responses []*StubResponse `description:"response sequence, each match returns next response, the last one is repeated once sequence is exhausted unless Rotate is set"`
+	Rotate    bool            `description:"restart response sequence once exhausted"`
+	Fault     *Fault          `description:"fault injected into rule responses, takes precedence over endpoint fault"`
+	index     uint32
+	faults    faultHolder
+}
+
+// Init initialises rule
+func (r *Rule) Init() error {
+	if err := r.Matcher.Init(); err != nil {
+		return fmt.Errorf("invalid rule %v: %w", r.Name, err)
+	}
+	if r.Fault != nil {
+		if err := r.Fault.Init(); err != nil {
+			return err
+		}
+	}
+	r.faults.set(r.Fault)
+	return nil
+}
+
+// Validate checks if rule is valid
+func (r *Rule) Validate() error {
+	if r.Response != nil && len(r.Responses) > 0 {
+		return fmt.Errorf("rule %v: response and responses are mutually exclusive", r.Name)
+	}
+	if r.Fault != nil {
+		if err := r.Fault.Validate(); err != nil {
+			return fmt.Errorf("invalid rule %v: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// match returns true if request matches, path variables are added to state
+func (m *Matcher) match(request *http.Request, state data.Map) (bool, error) {
+	if m.Method != "" && !strings.EqualFold(m.Method, request.Method) {
+		return false, nil
+	}
+	if m.pathExpr != nil {
+		matched := m.pathExpr.FindStringSubmatch(request.URL.Path)
+		if matched == nil {
+			return false, nil
+		}
+		pathVariables := data.NewMap()
+		for i, name := range m.pathExpr.SubexpNames() {
+			if i > 0 && name != "" {
+				pathVariables.Put(name, matched[i])
+			}
+		}
+		state.Put("path", pathVariables)
+	}
+	query := request.URL.Query()
+	for name, matcher := range m.query {
+		if _, ok := query[name]; !ok || !matcher.MatchString(query.Get(name)) {
+			return false, nil
+		}
+	}
+	for name, matcher := range m.header {
+		if _, ok := request.Header[http.CanonicalHeaderKey(name)]; !ok || !matcher.MatchString(request.Header.Get(name)) {
+			return false, nil
+		}
+	}
+	if m.whenEval == nil {
+		return true, nil
+	}
+	return criteria.Evaluate(nil, state, m.When, &m.whenEval, "", false)
+}
+
+// next returns next response from sequence
+func (r *Rule) next() *StubResponse {
+	if len(r.Responses) == 0 {
+		if r.Response == nil {
+			return &StubResponse{}
+		}
+		return r.Response
+	}
+	index := int(atomic.AddUint32(&r.index, 1) - 1)
+	if index >= len(r.Responses) {
+		if r.Rotate {
+			index = index % len(r.Responses)
+		} else {
+			index = len(r.Responses) - 1
+		}
+	}
+	return r.Responses[index]
+}
+
+// reset rewinds response sequence and fault
+func (r *Rule) reset() {
+	atomic.StoreUint32(&r.index, 0)
+	if fault := r.faults.get(); fault != nil {
+		fault.reset()
+	}
+}
+
+func compileValueMatchers(values map[string]string) (map[string]*regexp.Regexp, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	var result = make(map[string]*regexp.Regexp)
+	for name, value := range values {
+		var expr string
+		switch {
+		case len(value) > 2 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/"):
+			expr = value[1 : len(value)-1]
+		case strings.Contains(value, "*"):
+			expr = "^" + strings.Replace(regexp.QuoteMeta(value), `\*`, ".*", -1) + "$"
+		default:
+			expr = "^" + regexp.QuoteMeta(value) + "$"
+		}
+		matcher, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v matcher: %v, %w", name, value, err)
+		}
+		result[name] = matcher
+	}
+	return result, nil
+}
+
+// stubs represents stub rules evaluated before recorded trips
+type stubs struct {
+	mux   sync.RWMutex
+	rules []*Rule
+	state data.Map
+}
+
+// append adds rules with workflow state used for templates
+func (s *stubs) append(state data.Map, rules ...*Rule) error {
+	if err := initRules(rules); err != nil {
+		return err
+	}
+	if err := validateRules(rules); err != nil {
+		return err
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.rules = append(s.rules, rules...)
+	if state != nil {
+		s.state = state
+	}
+	return nil
+}
+
+// requestState returns template state with request data
+func (s *stubs) requestState(request *http.Request, body []byte) data.Map {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	return newRequestState(s.state, request, body)
+}
+
+// newRequestState returns state with request data: $request, $query, $header and $body
+func newRequestState(state data.Map, request *http.Request, body []byte) data.Map {
+	var result = data.NewMap()
+	for k, v := range state {
+		result[k] = v
+	}
+	query := data.NewMap()
+	for k := range request.URL.Query() {
+		query.Put(k, request.URL.Query().Get(k))
+	}
+	header := data.NewMap()
+	for k := range request.Header {
+		header.Put(k, request.Header.Get(k))
+	}
+	var bodyValue interface{} = string(body)
+	if toolbox.IsStructuredJSON(string(body)) {
+		if structured, err := toolbox.JSONToInterface(string(body)); err == nil {
+			bodyValue = structured
+		}
+	}
+	result.Put("query", query)
+	result.Put("header", header)
+	result.Put("body", bodyValue)
+	result.Put("request", map[string]interface{}{
+		"Method": request.Method,
+		"URL":    request.URL.String(),
+		"Path":   request.URL.Path,
+		"Host":   request.Host,
+		"Header": header,
+		"Query":  query,
+		"Body":   string(body),
+	})
+	return result
+}
+
+// reset rewinds rules response sequences
+func (s *stubs) reset() {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	for _, rule := range s.rules {
+		rule.reset()
+	}
+}
+
+// setFault changes fault of rules with supplied name
+func (s *stubs) setFault(name string, fault *Fault) error {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	var matched = 0
+	for _, rule := range s.rules {
+		if rule.Name == name {
+			rule.faults.set(fault)
+			matched++
+		}
+	}
+	if matched == 0 {
+		return fmt.Errorf("rule %v not found", name)
+	}
+	return nil
+}
+
+// match returns the first rule matching request with request state, nil if no rule matched
+func (s *stubs) match(request *http.Request) (*Rule, data.Map) {
+	s.mux.RLock()
+	rules := s.rules
+	s.mux.RUnlock()
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	var body []byte
+	if request.Body != nil {
+		body, _ = ioutil.ReadAll(request.Body)
+		request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	state := s.requestState(request, body)
+	for _, rule := range rules {
+		state.Put("path", data.NewMap())
+		matched, err := rule.match(request, state)
+		if err != nil {
+			log.Printf("failed to match rule %v: %v", rule.Name, err)
+			continue
+		}
+		if !matched {
+			continue
+		}
+		return rule, state
+	}
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return nil, nil
+}
+
+func writeStubResponse(writer http.ResponseWriter, rule *Rule, response *StubResponse, state data.Map) {
+	var body []byte
+	if response.JSONBody != nil {
+		var err error
+		if body, err = json.Marshal(state.Expand(response.JSONBody)); err != nil {
+			http.Error(writer, fmt.Sprintf("failed to encode rule %v response: %v", rule.Name, err), http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+	} else if response.Body != "" {
+		body = []byte(state.ExpandAsText(response.Body))
+	}
+	for k, v := range response.Header {
+		writer.Header().Set(k, state.ExpandAsText(v))
+	}
+	if delay := rule.DelayMs + response.DelayMs; delay > 0 {
+		time.Sleep(time.Duration(delay) * time.Millisecond)
+	}
+	code := response.Code
+	if code == 0 {
+		code = http.StatusOK
+	}
+	writer.WriteHeader(code)
+	if len(body) > 0 {
+		if _, err := writer.Write(body); err != nil {
+			log.Print(err)
+		}
+	}
+}
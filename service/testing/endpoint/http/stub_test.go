@@ -0,0 +1,141 @@
+package http_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	endpoint "github.com/viant/endly/service/testing/endpoint/http"
+	"github.com/viant/toolbox"
+)
+
+func TestHTTPEndpointService_Rules(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	state := context.State()
+	state.Put("version", "v1")
+	service, _ := context.Service(endpoint.ServiceID)
+
+	response := service.Run(context, &endpoint.ListenRequest{
+		Port: 7719,
+		Rules: []*endpoint.Rule{
+			{
+				Name:    "premium user",
+				Matcher: endpoint.Matcher{Method: "POST", Path: "/users/{id}/orders", When: "$body.user.tier = premium"},
+				Response: &endpoint.StubResponse{
+					Code:     201,
+					Header:   map[string]string{"X-Version": "$version"},
+					JSONBody: map[string]interface{}{"user": "$path.id", "sku": "$body.items[0].sku", "discount": 10},
+				},
+			},
+			{
+				Name:    "any user",
+				Matcher: endpoint.Matcher{Method: "POST", Path: "/users/*"},
+				Response: &endpoint.StubResponse{
+					Code: 201,
+					Body: `{"user":"$body.user.tier"}`,
+				},
+			},
+			{
+				Name: "search",
+				Matcher: endpoint.Matcher{
+					Method: "GET",
+					Path:   "/search",
+					Query:  map[string]string{"q": "/^abc/"},
+					Header: map[string]string{"Authorization": "Bearer *"},
+				},
+				Responses: []*endpoint.StubResponse{
+					{Code: 503},
+					{Body: "$query.q found"},
+				},
+			},
+		},
+	})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+
+	var useCases = []struct {
+		description  string
+		method       string
+		URL          string
+		header       map[string]string
+		body         string
+		expectCode   int
+		expectBody   string
+		expectHeader map[string]string
+	}{
+		{
+			description:  "json body criteria with templated JSON response",
+			method:       "POST",
+			URL:          "http://127.0.0.1:7719/users/12/orders",
+			body:         `{"user":{"tier":"premium","ts":"2024-01-01T10:00:00Z"},"items":[{"sku":"A-1"}]}`,
+			expectCode:   201,
+			expectBody:   `{"discount":10,"sku":"A-1","user":"12"}`,
+			expectHeader: map[string]string{"X-Version": "v1", "Content-Type": "application/json"},
+		},
+		{
+			description: "wildcard path",
+			method:      "POST",
+			URL:         "http://127.0.0.1:7719/users/13/orders",
+			body:        `{"user":{"tier":"basic"}}`,
+			expectCode:  201,
+			expectBody:  `{"user":"basic"}`,
+		},
+		{
+			description: "missing header",
+			method:      "GET",
+			URL:         "http://127.0.0.1:7719/search?q=abcd",
+			expectCode:  404,
+		},
+		{
+			description: "response sequence - first",
+			method:      "GET",
+			URL:         "http://127.0.0.1:7719/search?q=abcd",
+			header:      map[string]string{"Authorization": "Bearer xyz"},
+			expectCode:  503,
+		},
+		{
+			description: "response sequence - second",
+			method:      "GET",
+			URL:         "http://127.0.0.1:7719/search?q=abcd",
+			header:      map[string]string{"Authorization": "Bearer xyz"},
+			expectCode:  200,
+			expectBody:  "abcd found",
+		},
+		{
+			description: "response sequence - last repeated",
+			method:      "GET",
+			URL:         "http://127.0.0.1:7719/search?q=abcd",
+			header:      map[string]string{"Authorization": "Bearer xyz"},
+			expectCode:  200,
+			expectBody:  "abcd found",
+		},
+	}
+
+	for _, useCase := range useCases {
+		request, err := http.NewRequest(useCase.method, useCase.URL, strings.NewReader(useCase.body))
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		for k, v := range useCase.header {
+			request.Header.Set(k, v)
+		}
+		response, err := http.DefaultClient.Do(request)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		body, _ := ioutil.ReadAll(response.Body)
+		_ = response.Body.Close()
+		assert.EqualValues(t, useCase.expectCode, response.StatusCode, useCase.description)
+		if useCase.expectBody != "" {
+			assert.EqualValues(t, useCase.expectBody, string(body), useCase.description)
+		}
+		for k, v := range useCase.expectHeader {
+			assert.EqualValues(t, v, response.Header.Get(k), useCase.description)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package http
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	caCertFile = "ca.crt"
+	caKeyFile  = "ca.key"
+)
+
+var caMux sync.Mutex
+
+// TLS represents endpoint TLS settings, certificates are issued on the fly by endly managed CA unless cert and key files are specified
+type TLS struct {
+	CertFile    string `description:"optional server certificate file, takes precedence over endly managed CA"`
+	KeyFile     string `description:"optional server key file"`
+	CADirectory string `description:"endly managed CA location, default ~/.endly/ca"`
+}
+
+// Validate checks if TLS settings are valid
+func (t *TLS) Validate() error {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return fmt.Errorf("both certFile and keyFile have to be specified")
+	}
+	return nil
+}
+
+// Config returns server TLS config, http2 enables h2 protocol negotiation
+func (t *TLS) Config(http2 bool) (*tls.Config, *CA, error) {
+	var config *tls.Config
+	var ca *CA
+	if t.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load server certificate %v, %w", t.CertFile, err)
+		}
+		config = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	} else {
+		var err error
+		if ca, err = LoadCA(t.CADirectory); err != nil {
+			return nil, nil, err
+		}
+		config = ca.TLSConfig()
+	}
+	config.NextProtos = []string{"http/1.1"}
+	if http2 {
+		config.NextProtos = []string{"h2", "http/1.1"}
+	}
+	return config, ca, nil
+}
+
+// CA represents endly managed local certificate authority issuing per host server certificates
+type CA struct {
+	Directory string
+	cert      *x509.Certificate
+	key       crypto.Signer
+	certPEM   []byte
+	mux       sync.Mutex
+	issued    map[string]*tls.Certificate
+}
+
+// CertFile returns CA certificate location
+func (c *CA) CertFile() string {
+	return filepath.Join(c.Directory, caCertFile)
+}
+
+// CertPEM returns PEM encoded CA certificate
+func (c *CA) CertPEM() []byte {
+	return c.certPEM
+}
+
+// CertPool returns pool with CA certificate, used by clients to trust issued certificates
+func (c *CA) CertPool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.cert)
+	return pool
+}
+
+// Export writes PEM encoded CA certificate to supplied location
+func (c *CA) Export(filename string) error {
+	if parent := filepath.Dir(filename); parent != "" {
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(filename, c.certPEM, 0644)
+}
+
+// TLSConfig returns TLS config issuing certificate for client hello server name
+func (c *CA) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			host := hello.ServerName
+			if host == "" && hello.Conn != nil {
+				host, _, _ = net.SplitHostPort(hello.Conn.LocalAddr().String())
+			}
+			return c.Certificate(host)
+		},
+	}
+}
+
+// Certificate returns cached or newly issued server certificate for supplied host
+func (c *CA) Certificate(host string) (*tls.Certificate, error) {
+	if host == "" {
+		host = "localhost"
+	}
+	host = strings.ToLower(host)
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if certificate, ok := c.issued[host]; ok {
+		return certificate, nil
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: host, Organization: []string{"endly"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{host}
+	}
+	if host == "localhost" {
+		template.IPAddresses = append(template.IPAddresses, net.IPv4(127, 0, 0, 1), net.IPv6loopback)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, c.cert, &key.PublicKey, c.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue %v certificate, %w", host, err)
+	}
+	certificate := &tls.Certificate{
+		Certificate: [][]byte{der, c.cert.Raw},
+		PrivateKey:  key,
+	}
+	c.issued[host] = certificate
+	return certificate, nil
+}
+
+// DefaultCADirectory returns default endly managed CA location
+func DefaultCADirectory() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = os.TempDir()
+	}
+	return filepath.Join(home, ".endly", "ca")
+}
+
+// LoadCA loads CA certificate and key from supplied directory, CA is generated if it does not exist
+func LoadCA(directory string) (*CA, error) {
+	if directory == "" {
+		directory = DefaultCADirectory()
+	}
+	caMux.Lock()
+	defer caMux.Unlock()
+	var ca = &CA{Directory: directory, issued: make(map[string]*tls.Certificate)}
+	certPEM, err := os.ReadFile(ca.CertFile())
+	if os.IsNotExist(err) {
+		if err = generateCA(directory); err != nil {
+			return nil, err
+		}
+		certPEM, err = os.ReadFile(ca.CertFile())
+	}
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(directory, caKeyFile))
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA in %v, %w", directory, err)
+	}
+	if ca.cert, err = x509.ParseCertificate(certificate.Certificate[0]); err != nil {
+		return nil, err
+	}
+	var ok bool
+	if ca.key, ok = certificate.PrivateKey.(crypto.Signer); !ok {
+		return nil, fmt.Errorf("unsupported CA key type: %T", certificate.PrivateKey)
+	}
+	ca.certPEM = certPEM
+	return ca, nil
+}
+
+// generateCA creates self signed CA certificate and key
+func generateCA(directory string) error {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "endly local CA", Organization: []string{"endly"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return fmt.Errorf("failed to generate CA, %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(directory, caKeyFile), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(directory, caCertFile), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)
+}
+
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
@@ -0,0 +1,90 @@
+package http_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	endpoint "github.com/viant/endly/service/testing/endpoint/http"
+	"github.com/viant/toolbox"
+	"golang.org/x/net/http2"
+)
+
+func TestHTTPEndpointService_TLS(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	service, _ := context.Service(endpoint.ServiceID)
+	caDirectory := filepath.Join(os.TempDir(), "endly_test_ca")
+	_ = os.RemoveAll(caDirectory)
+
+	response := service.Run(context, &endpoint.CARequest{Directory: caDirectory, Export: filepath.Join(caDirectory, "export", "ca.crt")})
+	if !assert.Equal(t, "", response.Error) {
+		return
+	}
+	caResponse := response.Response.(*endpoint.CAResponse)
+	caPEM, err := ioutil.ReadFile(caResponse.CertFile)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, caResponse.CertPEM, string(caPEM))
+	roots := x509.NewCertPool()
+	assert.True(t, roots.AppendCertsFromPEM(caPEM))
+
+	rules := []*endpoint.Rule{{Matcher: endpoint.Matcher{Path: "/ping"}, Response: &endpoint.StubResponse{Body: "pong"}}}
+	var useCases = []struct {
+		description string
+		request     *endpoint.ListenRequest
+		client      *http.Client
+		URL         string
+		proto       string
+	}{
+		{
+			description: "HTTPS with HTTP/2",
+			request:     &endpoint.ListenRequest{Port: 7721, Rules: rules, TLS: &endpoint.TLS{CADirectory: caDirectory}, HTTP2: true},
+			client:      &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}, ForceAttemptHTTP2: true}},
+			URL:         "https://localhost:7721/ping",
+			proto:       "HTTP/2.0",
+		},
+		{
+			description: "HTTPS with IP address",
+			request:     &endpoint.ListenRequest{Port: 7722, Rules: rules, TLS: &endpoint.TLS{CADirectory: caDirectory}},
+			client:      &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}, ForceAttemptHTTP2: true}},
+			URL:         "https://127.0.0.1:7722/ping",
+			proto:       "HTTP/1.1",
+		},
+		{
+			description: "cleartext HTTP/2",
+			request:     &endpoint.ListenRequest{Port: 7723, Rules: rules, HTTP2: true},
+			client: &http.Client{Transport: &http2.Transport{AllowHTTP: true, DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			}}},
+			URL:   "http://127.0.0.1:7723/ping",
+			proto: "HTTP/2.0",
+		},
+	}
+
+	for _, useCase := range useCases {
+		response := service.Run(context, useCase.request)
+		if !assert.Equal(t, "", response.Error, useCase.description) {
+			continue
+		}
+		if useCase.request.TLS != nil {
+			assert.EqualValues(t, filepath.Join(caDirectory, "ca.crt"), response.Response.(*endpoint.ListenResponse).CACertFile, useCase.description)
+		}
+		httpResponse, err := useCase.client.Get(useCase.URL)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		body, _ := ioutil.ReadAll(httpResponse.Body)
+		_ = httpResponse.Body.Close()
+		assert.EqualValues(t, "pong", string(body), useCase.description)
+		assert.EqualValues(t, useCase.proto, httpResponse.Proto, useCase.description)
+	}
+}
@@ -0,0 +1,131 @@
+package log
+
+import (
+	"strings"
+)
+
+// defaultMaxRecordLines represents default max number of lines assembled into one record
+const defaultMaxRecordLines = 500
+
+// pendingRecord represents multi line record being assembled
+type pendingRecord struct {
+	lines    []string
+	number   int
+	depth    int
+	inString bool
+	escaped  bool
+}
+
+// scanJSON tracks JSON object nesting depth, brackets within string literals are ignored
+func (r *pendingRecord) scanJSON(line string) {
+	for i := 0; i < len(line); i++ {
+		if r.inString {
+			switch {
+			case r.escaped:
+				r.escaped = false
+			case line[i] == '\\':
+				r.escaped = true
+			case line[i] == '"':
+				r.inString = false
+			}
+			continue
+		}
+		switch line[i] {
+		case '"':
+			r.inString = true
+		case '{', '[':
+			r.depth++
+		case '}', ']':
+			r.depth--
+		}
+	}
+}
+
+// readLine updates processing state and assembles read log line under the file lock
+func (f *File) readLine(data string) {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+	lineIndex := f.ProcessingState.Line + 1
+	f.ProcessingState.Update(len(data), lineIndex)
+	f.appendLine(data, lineIndex)
+}
+
+// flushPending pushes pending multi line record under the file lock
+func (f *File) flushPending() {
+	f.Mutex.Lock()
+	defer f.Mutex.Unlock()
+	f.flushRecord()
+}
+
+// appendLine adds read log line to the current record, complete records are pushed to the validation queue, caller holds the file lock
+func (f *File) appendLine(data string, lineIndex int) {
+	if !f.IsMultiline() {
+		f.pushLine(strings.Trim(data, " \r\n\t"), lineIndex)
+		return
+	}
+	if f.MultilineJSON {
+		f.appendJSONLine(strings.Trim(data, " \r\n\t"), lineIndex)
+		return
+	}
+	line := strings.TrimRight(data, " \r\n\t")
+	if line == "" {
+		return
+	}
+	if expr, err := f.GetRecordStartExpr(); err == nil && expr.MatchString(line) {
+		f.flushRecord()
+	}
+	if f.pending == nil {
+		f.pending = &pendingRecord{number: lineIndex}
+	}
+	f.pending.lines = append(f.pending.lines, line)
+	if len(f.pending.lines) >= f.MaxRecordLines && f.MaxRecordLines > 0 {
+		f.flushRecord()
+	}
+}
+
+// appendJSONLine assembles JSON object spanning multiple lines, lines outside JSON object are pushed as they are
+func (f *File) appendJSONLine(line string, lineIndex int) {
+	if line == "" {
+		return
+	}
+	if f.pending == nil {
+		if !strings.HasPrefix(line, "{") && !strings.HasPrefix(line, "[") {
+			f.pushLine(line, lineIndex)
+			return
+		}
+		f.pending = &pendingRecord{number: lineIndex}
+	}
+	f.pending.lines = append(f.pending.lines, line)
+	f.pending.scanJSON(line)
+	if f.pending.depth <= 0 || (len(f.pending.lines) >= f.MaxRecordLines && f.MaxRecordLines > 0) {
+		f.flushRecord()
+	}
+}
+
+// flushRecord pushes assembled record, called when next record starts, JSON object is closed or log has no more lines, caller holds the file lock
+func (f *File) flushRecord() {
+	if f.pending == nil {
+		return
+	}
+	pending := f.pending
+	f.pending = nil
+	f.pushLine(strings.Join(pending.lines, "\n"), pending.number)
+}
+
+// pushLine pushes non empty record matching inclusion and exclusion fragments, caller holds the file lock
+func (f *File) pushLine(line string, lineIndex int) {
+	if len(line) == 0 {
+		return
+	}
+	if f.Exclusion != "" && strings.Contains(line, f.Exclusion) {
+		return
+	}
+	if f.Inclusion != "" && !strings.Contains(line, f.Inclusion) {
+		return
+	}
+	f.pushLogRecord(&Record{
+		URL:    f.URL,
+		Line:   line,
+		Number: lineIndex,
+	})
+}
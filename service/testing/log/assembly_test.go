@@ -0,0 +1,93 @@
+package log
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_ReadLogRecords(t *testing.T) {
+	var useCases = []struct {
+		description string
+		logType     *Type
+		content     string
+		final       bool
+		expect      []string
+		numbers     []int
+	}{
+		{
+			description: "single line records",
+			logType:     &Type{},
+			content:     "line 1\n\nline 2\nline",
+			expect:      []string{"line 1", "line 2"},
+			numbers:     []int{1, 3},
+		},
+		{
+			description: "stack trace",
+			logType:     &Type{RecordStartRegExpr: `^\d{4}-\d{2}-\d{2}`},
+			content: "2024-01-01 10:00:00 INFO started\n" +
+				"2024-01-01 10:00:01 ERROR failed\n" +
+				"java.lang.IllegalStateException: boom\n" +
+				"\tat app.Main.run(Main.java:10)\n" +
+				"2024-01-01 10:00:02 INFO done\n",
+			final: true,
+			expect: []string{
+				"2024-01-01 10:00:00 INFO started",
+				"2024-01-01 10:00:01 ERROR failed\njava.lang.IllegalStateException: boom\n\tat app.Main.run(Main.java:10)",
+				"2024-01-01 10:00:02 INFO done",
+			},
+			numbers: []int{1, 2, 5},
+		},
+		{
+			description: "stack trace pending till next record",
+			logType:     &Type{RecordStartRegExpr: `^\d{4}-`},
+			content:     "2024-01-01 ERROR failed\n\tat app.Main.run(Main.java:10)\n",
+			expect:      []string{},
+		},
+		{
+			description: "max record lines",
+			logType:     &Type{RecordStartRegExpr: `^start`, MaxRecordLines: 2},
+			content:     "start\n1\n2\n",
+			final:       true,
+			expect:      []string{"start\n1", "2"},
+		},
+		{
+			description: "inclusion applied to assembled record",
+			logType:     &Type{RecordStartRegExpr: `^start`, Inclusion: "Exception"},
+			content:     "start 1\nException\nstart 2\nok\n",
+			final:       true,
+			expect:      []string{"start 1\nException"},
+		},
+		{
+			description: "multi line JSON",
+			logType:     &Type{MultilineJSON: true},
+			content:     "{\"a\":1}\n{\n  \"b\": \"}{\\\"\",\n  \"c\": [1,\n 2]\n}\nplain\n",
+			expect:      []string{`{"a":1}`, "{\n\"b\": \"}{\\\"\",\n\"c\": [1,\n2]\n}", "plain"},
+			numbers:     []int{1, 2, 7},
+		},
+	}
+
+	for _, useCase := range useCases {
+		logFile := &File{
+			Type:            useCase.logType,
+			ProcessingState: &ProcessingState{},
+			Mutex:           &sync.RWMutex{},
+		}
+		err := logFile.readLogRecords(strings.NewReader(useCase.content), useCase.final)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		var actual = make([]string, 0)
+		var numbers = make([]int, 0)
+		for _, record := range logFile.Records {
+			actual = append(actual, record.Line)
+			numbers = append(numbers, record.Number)
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+		if useCase.numbers != nil {
+			assert.EqualValues(t, useCase.numbers, numbers, useCase.description)
+		}
+	}
+}
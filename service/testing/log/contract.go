@@ -6,6 +6,7 @@ import (
 	"github.com/viant/endly/model/location"
 	"github.com/viant/toolbox"
 	"regexp"
+	"strings"
 )
 
 // AssertRequest represents a log assert request
@@ -87,6 +88,35 @@ type Type struct {
 	indexExpr    *regexp.Regexp
 	UDF          string `description:"registered user defined function to transform content file before applying validation"`
 	Debug        bool   `description:"if set, every record appended to validation queue will be listed"`
+
+	RecordStartRegExpr string `description:"if specified, line matching expression starts a new record, other lines are appended to the current record, i.e. stack traces"`
+	recordStartExpr    *regexp.Regexp
+	MultilineJSON      bool   `description:"if set, JSON object spanning multiple lines is assembled into one record"`
+	MaxRecordLines     int    `description:"max number of lines assembled into one record, default 500"`
+	Pattern            string `description:"regular expression with named groups, used to parse record fields with regexp format"`
+	patternExpr        *regexp.Regexp
+}
+
+// Validate checks if log type is valid
+func (t *Type) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("name was empty")
+	}
+	if t.RecordStartRegExpr != "" && t.MultilineJSON {
+		return fmt.Errorf("%v: recordStartRegExpr and multilineJSON are mutually exclusive", t.Name)
+	}
+	if strings.ToLower(t.Format) == FormatRegExpr && t.Pattern == "" {
+		return fmt.Errorf("%v: pattern was empty for %v format", t.Name, t.Format)
+	}
+	for _, expr := range []string{t.IndexRegExpr, t.RecordStartRegExpr, t.Pattern} {
+		if expr == "" {
+			continue
+		}
+		if _, err := regexp.Compile(expr); err != nil {
+			return fmt.Errorf("%v: invalid expression %v, %w", t.Name, expr, err)
+		}
+	}
+	return nil
 }
 
 // ListenRequest represents listen for a logs request.
@@ -96,6 +126,32 @@ type ListenRequest struct {
 	Types       []*Type            `required:"true" description:"log types"`
 }
 
+// Init initialises request
+func (r *ListenRequest) Init() error {
+	for _, logType := range r.Types {
+		if logType.IsMultiline() && logType.MaxRecordLines == 0 {
+			logType.MaxRecordLines = defaultMaxRecordLines
+		}
+	}
+	return nil
+}
+
+// Validate checks if request is valid
+func (r *ListenRequest) Validate() error {
+	if r.Source == nil {
+		return fmt.Errorf("source was empty")
+	}
+	if len(r.Types) == 0 {
+		return fmt.Errorf("types were empty")
+	}
+	for _, logType := range r.Types {
+		if err := logType.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ListenResponse represents a log validation listen response.
 type ListenResponse struct {
 	Meta TypesMeta
@@ -116,6 +172,31 @@ func (t *Type) GetIndexExpr() (*regexp.Regexp, error) {
 	return t.indexExpr, err
 }
 
+// IsMultiline returns true if records can span multiple lines
+func (t *Type) IsMultiline() bool {
+	return t.RecordStartRegExpr != "" || t.MultilineJSON
+}
+
+// GetRecordStartExpr returns record start expression.
+func (t *Type) GetRecordStartExpr() (*regexp.Regexp, error) {
+	if t.recordStartExpr != nil {
+		return t.recordStartExpr, nil
+	}
+	var err error
+	t.recordStartExpr, err = regexp.Compile(t.RecordStartRegExpr)
+	return t.recordStartExpr, err
+}
+
+// GetPatternExpr returns record pattern expression.
+func (t *Type) GetPatternExpr() (*regexp.Regexp, error) {
+	if t.patternExpr != nil {
+		return t.patternExpr, nil
+	}
+	var err error
+	t.patternExpr, err = regexp.Compile(t.Pattern)
+	return t.patternExpr, err
+}
+
 // ResetRequest represents a log reset request
 type ResetRequest struct {
 	LogTypes []string `required:"true" description:"log types to reset"`
@@ -9,17 +9,15 @@ import (
 	"github.com/viant/endly/service/workflow"
 	"github.com/viant/toolbox"
 	"io"
-	"io/ioutil"
-	"strings"
+	"os"
 	"sync"
 	"time"
 )
 
 // File represents a log file
 type File struct {
-	URL     string
-	Content string
-	Name    string
+	URL  string
+	Name string
 	*Type
 	ProcessingState *ProcessingState
 	LastModified    time.Time
@@ -28,6 +26,22 @@ type File struct {
 	IndexedRecords  map[string]*Record
 	Mutex           *sync.RWMutex
 	context         *endly.Context
+	info            os.FileInfo
+	pending         *pendingRecord
+}
+
+// newFile creates a log file for supplied log type
+func newFile(context *endly.Context, logType *Type, name, URL string) *File {
+	return &File{
+		context:         context,
+		Type:            logType,
+		Name:            name,
+		URL:             URL,
+		ProcessingState: &ProcessingState{},
+		Mutex:           &sync.RWMutex{},
+		Records:         make([]*Record, 0),
+		IndexedRecords:  make(map[string]*Record),
+	}
 }
 
 // ShiftLogRecord returns and remove the first log record if present
@@ -86,6 +100,11 @@ func (f *File) ShiftLogRecordByIndex(value string) (*Record, bool) {
 func (f *File) PushLogRecord(record *Record) {
 	f.Mutex.Lock()
 	defer f.Mutex.Unlock()
+	f.pushLogRecord(record)
+}
+
+// pushLogRecord appends provided log record to the records, caller holds the file lock
+func (f *File) pushLogRecord(record *Record) {
 	if len(f.Records) == 0 {
 		f.Records = make([]*Record, 0)
 	}
@@ -129,63 +148,28 @@ func (f *File) HasPendingLogs() bool {
 	return len(f.Records) > 0
 }
 
-func (f *File) readLogRecords(reader io.Reader) error {
-	data, err := ioutil.ReadAll(reader)
-	if err != nil {
-		return err
-	}
-	if f.ProcessingState.Position > len(data) {
-		return nil
-	}
-	var line = ""
-	var startPosition = f.ProcessingState.Position
-	var startLine = f.ProcessingState.Line
-	var lineIndex = startLine
-	var dataProcessed = 0
-
-	r := bufio.NewReader(strings.NewReader(string(data[startPosition:])))
+// readLogRecords reads log records from reader positioned at processing state position,
+// incomplete trailing line is left for the next read unless final flag is set (i.e. rotated file),
+// in which case pending multi line record is pushed too
+func (f *File) readLogRecords(reader io.Reader, final bool) error {
+	r := bufio.NewReaderSize(reader, 64*1024)
 	for {
-		code, size, err := r.ReadRune()
+		data, err := r.ReadString('\n')
 		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return err
-		}
-
-		aChar := string(code)
-		dataProcessed += size
-		if aChar != "\n" && aChar != "\r" {
-			line += aChar
-			continue
-		}
-
-		line = strings.Trim(line, " \r\t")
-		lineIndex++
-		if f.Exclusion != "" {
-			if strings.Contains(line, f.Exclusion) {
-				line, dataProcessed = f.ProcessingState.Update(dataProcessed, lineIndex)
-				continue
+			if !final {
+				return nil
 			}
-		}
-		if f.Inclusion != "" {
-			if !strings.Contains(line, f.Inclusion) {
-				line, dataProcessed = f.ProcessingState.Update(dataProcessed, lineIndex)
-				continue
+			if data == "" {
+				f.flushPending()
+				return nil
 			}
-		}
-
-		if len(line) > 0 {
-			f.PushLogRecord(&Record{
-				URL:    f.URL,
-				Line:   line,
-				Number: lineIndex,
-			})
-		}
-		if err != nil {
+		} else if err != nil {
 			return err
 		}
-		line, dataProcessed = f.ProcessingState.Update(dataProcessed, lineIndex)
+		f.readLine(data)
+		if err == io.EOF {
+			f.flushPending()
+			return nil
+		}
 	}
-	return nil
 }
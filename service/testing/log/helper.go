@@ -18,3 +18,7 @@ func matchLogIndex(expr *regexp.Regexp, input string) string {
 func logTypeMetaKey(name string) string {
 	return fmt.Sprintf("meta_%v", name)
 }
+
+func logStreamKey(URL string) string {
+	return fmt.Sprintf("stream_%v", URL)
+}
@@ -0,0 +1,186 @@
+package log
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FormatJSON represents JSON log record format
+	FormatJSON = "json"
+	// FormatLogfmt represents key=value log record format
+	FormatLogfmt = "logfmt"
+	// FormatSyslog represents RFC 5424 syslog record format
+	FormatSyslog = "syslog"
+	// FormatRegExpr represents record format defined by named group regular expression pattern
+	FormatRegExpr = "regexp"
+)
+
+// Parse returns structured log record representation for the log type format
+func (t *Type) Parse(record *Record) (map[string]interface{}, error) {
+	switch strings.ToLower(t.Format) {
+	case FormatLogfmt:
+		return parseLogfmt(record.Line), nil
+	case FormatSyslog:
+		return parseSyslog(record.Line)
+	case FormatRegExpr:
+		expr, err := t.GetPatternExpr()
+		if err != nil {
+			return nil, err
+		}
+		matches := expr.FindStringSubmatch(record.Line)
+		if matches == nil {
+			return nil, fmt.Errorf("log record %v:%v does not match pattern: %v", record.URL, record.Number, t.Pattern)
+		}
+		var result = make(map[string]interface{})
+		for i, name := range expr.SubexpNames() {
+			if name != "" {
+				result[name] = matches[i]
+			}
+		}
+		return result, nil
+	}
+	return record.AsMap()
+}
+
+// parseLogfmt parses key=value pairs, value can be double quoted, key without value is set to true
+func parseLogfmt(line string) map[string]interface{} {
+	var result = make(map[string]interface{})
+	for i := 0; i < len(line); {
+		for i < len(line) && line[i] == ' ' {
+			i++
+		}
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' {
+			i++
+		}
+		key := line[start:i]
+		if key == "" {
+			i++
+			continue
+		}
+		if i >= len(line) || line[i] != '=' {
+			result[key] = true
+			continue
+		}
+		i++
+		if i < len(line) && line[i] == '"' {
+			var value strings.Builder
+			for i++; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) {
+					i++
+					switch line[i] {
+					case 'n':
+						value.WriteByte('\n')
+						continue
+					case 't':
+						value.WriteByte('\t')
+						continue
+					}
+				}
+				value.WriteByte(line[i])
+			}
+			i++
+			result[key] = value.String()
+			continue
+		}
+		start = i
+		for i < len(line) && line[i] != ' ' {
+			i++
+		}
+		result[key] = line[start:i]
+	}
+	return result
+}
+
+// parseSyslog parses RFC 5424 syslog record: <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]
+func parseSyslog(line string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(line, "<") {
+		return nil, fmt.Errorf("invalid syslog record, missing priority: %v", line)
+	}
+	end := strings.Index(line, ">")
+	if end == -1 {
+		return nil, fmt.Errorf("invalid syslog record, missing priority: %v", line)
+	}
+	priority, err := strconv.Atoi(line[1:end])
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog priority: %v, %w", line[1:end], err)
+	}
+	var result = map[string]interface{}{
+		"priority": priority,
+		"facility": priority / 8,
+		"severity": priority % 8,
+	}
+	fields := strings.SplitN(line[end+1:], " ", 7)
+	if len(fields) < 7 {
+		return nil, fmt.Errorf("invalid syslog record, expected version, timestamp, hostname, app-name, procid, msgid and structured data: %v", line)
+	}
+	for i, key := range []string{"version", "timestamp", "hostname", "appName", "procID", "msgID"} {
+		if fields[i] == "-" {
+			continue
+		}
+		if key == "version" {
+			result[key], _ = strconv.Atoi(fields[i])
+			continue
+		}
+		result[key] = fields[i]
+	}
+	structuredData, message, err := parseStructuredData(fields[6])
+	if err != nil {
+		return nil, err
+	}
+	if len(structuredData) > 0 {
+		result["structuredData"] = structuredData
+	}
+	message = strings.TrimPrefix(message, "\xEF\xBB\xBF")
+	if message != "" {
+		result["message"] = message
+	}
+	return result, nil
+}
+
+// parseStructuredData parses syslog structured data elements, i.e. [exampleSDID@32473 iut="3" eventSource="Application"], returns remaining message
+func parseStructuredData(text string) (map[string]interface{}, string, error) {
+	var result = make(map[string]interface{})
+	if strings.HasPrefix(text, "-") {
+		return result, strings.TrimPrefix(text[1:], " "), nil
+	}
+	i := 0
+	for i < len(text) && text[i] == '[' {
+		i++
+		start := i
+		for i < len(text) && text[i] != ' ' && text[i] != ']' {
+			i++
+		}
+		var params = make(map[string]interface{})
+		result[text[start:i]] = params
+		for i < len(text) && text[i] != ']' {
+			for i < len(text) && text[i] == ' ' {
+				i++
+			}
+			start = i
+			for i < len(text) && text[i] != '=' {
+				i++
+			}
+			name := text[start:i]
+			if i+1 >= len(text) || text[i+1] != '"' {
+				return nil, "", fmt.Errorf("invalid syslog structured data: %v", text)
+			}
+			var value strings.Builder
+			for i += 2; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' && i+1 < len(text) {
+					i++
+				}
+				value.WriteByte(text[i])
+			}
+			i++
+			params[name] = value.String()
+		}
+		if i >= len(text) {
+			return nil, "", fmt.Errorf("invalid syslog structured data: %v", text)
+		}
+		i++
+	}
+	return result, strings.TrimPrefix(text[i:], " "), nil
+}
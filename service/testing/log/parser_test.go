@@ -0,0 +1,94 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestType_Parse(t *testing.T) {
+	var useCases = []struct {
+		description string
+		logType     *Type
+		line        string
+		expect      map[string]interface{}
+		hasError    bool
+	}{
+		{
+			description: "json",
+			logType:     &Type{Format: FormatJSON},
+			line:        `{"level":"info","id":1}`,
+			expect:      map[string]interface{}{"level": "info", "id": float64(1)},
+		},
+		{
+			description: "logfmt",
+			logType:     &Type{Format: FormatLogfmt},
+			line:        `level=warn msg="disk \"sda\" full" path=/tmp retry`,
+			expect:      map[string]interface{}{"level": "warn", "msg": `disk "sda" full`, "path": "/tmp", "retry": true},
+		},
+		{
+			description: "syslog",
+			logType:     &Type{Format: FormatSyslog},
+			line:        `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="Application"] An application event`,
+			expect: map[string]interface{}{
+				"priority":  165,
+				"facility":  20,
+				"severity":  5,
+				"version":   1,
+				"timestamp": "2003-10-11T22:14:15.003Z",
+				"hostname":  "mymachine.example.com",
+				"appName":   "evntslog",
+				"msgID":     "ID47",
+				"structuredData": map[string]interface{}{
+					"exampleSDID@32473": map[string]interface{}{"iut": "3", "eventSource": "Application"},
+				},
+				"message": "An application event",
+			},
+		},
+		{
+			description: "syslog without structured data",
+			logType:     &Type{Format: FormatSyslog},
+			line:        `<34>1 2003-10-11T22:14:15.003Z host su - - - failed for lonvick`,
+			expect: map[string]interface{}{
+				"priority":  34,
+				"facility":  4,
+				"severity":  2,
+				"version":   1,
+				"timestamp": "2003-10-11T22:14:15.003Z",
+				"hostname":  "host",
+				"appName":   "su",
+				"message":   "failed for lonvick",
+			},
+		},
+		{
+			description: "invalid syslog",
+			logType:     &Type{Format: FormatSyslog},
+			line:        `Oct 11 22:14:15 host su: failed`,
+			hasError:    true,
+		},
+		{
+			description: "regexp",
+			logType:     &Type{Format: FormatRegExpr, Pattern: `(?s)^(?P<time>\S+) (?P<level>[A-Z]+) (?P<message>.+)$`},
+			line:        "10:00:01 ERROR failed\njava.lang.IllegalStateException",
+			expect:      map[string]interface{}{"time": "10:00:01", "level": "ERROR", "message": "failed\njava.lang.IllegalStateException"},
+		},
+		{
+			description: "regexp not matched",
+			logType:     &Type{Format: FormatRegExpr, Pattern: `^(?P<level>[A-Z]+):`},
+			line:        "info",
+			hasError:    true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		actual, err := useCase.logType.Parse(&Record{Line: useCase.line})
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
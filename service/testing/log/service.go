@@ -19,7 +19,6 @@ import (
 	"log"
 	"regexp"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -44,11 +43,16 @@ func (s *service) reset(context *endly.Context, request *ResetRequest) (*ResetRe
 		}
 		if logTypeMeta, ok := state.Get(logTypeMetaKey(logTypeName)).(*TypeMeta); ok {
 			for _, logFile := range logTypeMeta.LogFiles {
+				logFile.Mutex.Lock()
 				logFile.ProcessingState = &ProcessingState{
-					Position: logFile.Size,
-					Line:     len(logFile.Records),
+					Position:        logFile.Size,
+					Line:            len(logFile.Records),
+					Fingerprint:     logFile.ProcessingState.Fingerprint,
+					FingerprintSize: logFile.ProcessingState.FingerprintSize,
 				}
 				logFile.Records = make([]*Record, 0)
+				logFile.pending = nil
+				logFile.Mutex.Unlock()
 				response.LogFiles = append(response.LogFiles, logFile.Name)
 			}
 		}
@@ -94,7 +98,7 @@ func (s *service) assert(context *endly.Context, request *AssertRequest) (*Asser
 			}
 			var actualLogRecord interface{} = logRecord.Line
 			if isLogStructured := toolbox.IsMap(expectedRecord); isLogStructured {
-				actualLogRecord, err = logRecord.AsMap()
+				actualLogRecord, err = typeMeta.LogType.Parse(logRecord)
 				if err != nil {
 					return response, err
 				}
@@ -195,7 +199,7 @@ func (s *service) tryReadSnapshot(context *endly.Context, fs afs.Service, object
 	return nil, nil
 }
 
-func (s *service) readLogFile(context *endly.Context, source *location.Resource, fs afs.Service, candidate storage.Object, logType *Type) (*TypeMeta, error) {
+func (s *service) readLogFile(context *endly.Context, source *location.Resource, fs afs.Service, candidate storage.Object, siblings []storage.Object, logType *Type) (*TypeMeta, error) {
 	var result *TypeMeta
 	var key = logTypeMetaKey(logType.Name)
 	s.Mutex().Lock()
@@ -207,6 +211,7 @@ func (s *service) readLogFile(context *endly.Context, source *location.Resource,
 
 	result, ok := state.Get(key).(*TypeMeta)
 	if !ok {
+		s.Mutex().Unlock()
 		return nil, fmt.Errorf("failed to fwtch type meta")
 	}
 
@@ -216,71 +221,44 @@ func (s *service) readLogFile(context *endly.Context, source *location.Resource,
 	fileInfo := candidate
 	if !has {
 		isNewLogFile = true
-		logFile = &File{
-			context:         context,
-			Type:            logType,
-			Name:            name,
-			URL:             candidate.URL(),
-			LastModified:    fileInfo.ModTime(),
-			Size:            int(fileInfo.Size()),
-			ProcessingState: &ProcessingState{},
-			Mutex:           &sync.RWMutex{},
-			Records:         make([]*Record, 0),
-			IndexedRecords:  make(map[string]*Record),
-		}
+		logFile = newFile(context, logType, name, candidate.URL())
+		logFile.LastModified = fileInfo.ModTime()
+		logFile.Size = int(fileInfo.Size())
 		result.LogFiles[name] = logFile
 	}
 	s.Mutex().Unlock()
 
 	if !isNewLogFile && (logFile.Size == int(fileInfo.Size()) && logFile.LastModified.Unix() == fileInfo.ModTime().Unix()) {
+		logFile.flushPending() //no more lines since the last poll, pending multi line record is complete
 		return result, nil
 	}
+	logFile.Size = int(fileInfo.Size())
+	logFile.LastModified = fileInfo.ModTime()
+	if logFile.UDF == "" {
+		return result, logFile.tail(context.Background(), fs, candidate, siblings)
+	}
 
 	reader, err := s.tryReadSnapshot(context, fs, candidate, 3)
 	if err != nil || reader == nil {
 		return nil, err
 	}
-	if logFile.UDF != "" {
-		content, err := ioutil.ReadAll(reader)
-		if err != nil {
-			return nil, err
-		}
-		transformed, err := udf.TransformWithUDF(context, logFile.UDF, logFile.UDF, content)
-		switch payload := transformed.(type) {
-		case string:
-			reader = ioutil.NopCloser(strings.NewReader(payload))
-		case []byte:
-			reader = ioutil.NopCloser(bytes.NewReader(payload))
-		default:
-			return nil, fmt.Errorf("unsupported response type expeced string or []byte but had: %T", transformed)
-		}
-	}
-
-	logContent, err := ioutil.ReadAll(reader)
+	content, err := ioutil.ReadAll(reader)
 	if err != nil {
 		return nil, err
 	}
-	var content = string(logContent)
-	var fileOverridden = false
-	if len(logFile.Content) > len(content) { //log shrink or rolled over case
-		logFile.Reset(candidate)
-		logFile.Content = content
-		fileOverridden = true
-	}
-
-	if !fileOverridden && logFile.Size < int(fileInfo.Size()) && !strings.HasPrefix(content, string(logFile.Content)) {
-		logFile.Reset(candidate)
+	transformed, err := udf.TransformWithUDF(context, logFile.UDF, logFile.UDF, content)
+	if err != nil {
+		return nil, err
 	}
-
-	logFile.Content = content
-	logFile.Size = len(logContent)
-	if len(logContent) > 0 {
-		err = logFile.readLogRecords(bytes.NewReader(logContent))
-		if err != nil {
-			return nil, err
-		}
+	switch payload := transformed.(type) {
+	case string:
+		content = []byte(payload)
+	case []byte:
+		content = payload
+	default:
+		return nil, fmt.Errorf("unsupported response type expeced string or []byte but had: %T", transformed)
 	}
-	return result, nil
+	return result, logFile.tailContent(content)
 }
 
 func (s *service) readLogFiles(context *endly.Context, fs afs.Service, source *location.Resource, logTypes ...*Type) (TypesMeta, error) {
@@ -293,8 +271,22 @@ func (s *service) readLogFiles(context *endly.Context, fs afs.Service, source *l
 	if err != nil {
 		return nil, err
 	}
+	var names = make(map[string]bool)
 	for _, candidate := range candidates {
-		if candidate.IsDir() {
+		names[candidate.Name()] = !candidate.IsDir()
+	}
+	s.Mutex().Lock()
+	var state = s.State()
+	for _, logType := range logTypes {
+		if typeMeta, ok := state.Get(logTypeMetaKey(logType.Name)).(*TypeMeta); ok {
+			for name := range typeMeta.LogFiles {
+				names[name] = true
+			}
+		}
+	}
+	s.Mutex().Unlock()
+	for _, candidate := range candidates {
+		if candidate.IsDir() || isRotatedGeneration(candidate.Name(), names) {
 			continue
 		}
 		for _, logType := range logTypes {
@@ -305,7 +297,7 @@ func (s *service) readLogFiles(context *endly.Context, fs afs.Service, source *l
 			}
 			_, name := toolbox.URLSplit(candidate.URL())
 			if maskExpression.MatchString(name) {
-				logTypeMeta, err := s.readLogFile(context, source, fs, candidate, logType)
+				logTypeMeta, err := s.readLogFile(context, source, fs, candidate, candidates, logType)
 				if err != nil {
 					return nil, err
 				}
@@ -351,11 +343,18 @@ func (s *service) listen(context *endly.Context, request *ListenRequest) (*Liste
 	}
 	var state = s.State()
 	for _, logType := range request.Types {
-		if state.Has(logTypeMetaKey(logType.Name)) {
-			return nil, fmt.Errorf("listener has been already register for %v", logType.Name)
+		if !state.Has(logTypeMetaKey(logType.Name)) {
+			continue
+		}
+		if logMeta, ok := state.Get(logTypeMetaKey(logType.Name)).(*TypeMeta); ok && isStreamSource(source.URL) && logMeta.Source.URL == source.URL {
+			continue //re-listened stream replaces previous follower
 		}
+		return nil, fmt.Errorf("listener has been already register for %v", logType.Name)
 	}
 
+	if isStreamSource(source.URL) {
+		return s.listenForStream(context, source, request)
+	}
 	fs, err := estorage.StorageService(context, source)
 	if err != nil {
 		return nil, err
@@ -386,6 +385,39 @@ func (s *service) listen(context *endly.Context, request *ListenRequest) (*Liste
 	return response, err
 }
 
+// listenForStream follows docker container or journal unit logs, each log type is backed by one stream file
+func (s *service) listenForStream(context *endly.Context, source *location.Resource, request *ListenRequest) (*ListenResponse, error) {
+	logStream, err := openStream(context, source.URL)
+	if err != nil {
+		return nil, err
+	}
+	var response = &ListenResponse{
+		Meta: make(map[string]*TypeMeta),
+	}
+	var files = make([]*File, 0)
+	s.Mutex().Lock()
+	var state = s.State()
+	if previous, ok := state.Get(logStreamKey(source.URL)).(*stream); ok {
+		_ = previous.Close()
+	}
+	state.Put(logStreamKey(source.URL), logStream)
+	for _, logType := range request.Types {
+		logMeta := NewTypeMeta(source, logType)
+		logFile := newFile(context, logType, logStream.name, source.URL)
+		logMeta.LogFiles[logFile.Name] = logFile
+		state.Put(logTypeMetaKey(logType.Name), logMeta)
+		response.Meta[logType.Name] = logMeta
+		files = append(files, logFile)
+	}
+	s.Mutex().Unlock()
+	frequency := time.Duration(request.FrequencyMs) * time.Millisecond
+	if request.FrequencyMs <= 0 {
+		frequency = 400 * time.Millisecond
+	}
+	go follow(context, logStream, frequency, files)
+	return response, nil
+}
+
 const (
 	logValidatorExample = `{
   "FrequencyMs": 500,
@@ -2,8 +2,10 @@ package log
 
 // ProcessingState represents log processing state
 type ProcessingState struct {
-	Line     int
-	Position int
+	Line            int
+	Position        int
+	Fingerprint     string `json:",omitempty" description:"hash of the first processed log bytes, used to detect rotation"`
+	FingerprintSize int    `json:",omitempty"`
 }
 
 // Update updates processed position and line number
@@ -17,4 +19,6 @@ func (s *ProcessingState) Update(position, lineNumber int) (string, int) {
 func (s *ProcessingState) Reset() {
 	s.Line = 0
 	s.Position = 0
+	s.Fingerprint = ""
+	s.FingerprintSize = 0
 }
@@ -0,0 +1,218 @@
+package log
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	neturl "net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/url"
+	"github.com/viant/endly"
+	"github.com/viant/endly/service/system/docker"
+)
+
+const (
+	// DockerScheme represents docker container logs source scheme, i.e. docker://myapp
+	DockerScheme = "docker"
+	// JournaldScheme represents systemd journal source scheme, i.e. journald://nginx.service
+	JournaldScheme = "journald"
+	// defaultJournalOutput represents default journalctl output mode, message only
+	defaultJournalOutput = "cat"
+)
+
+// isStreamSource returns true if source is followed log stream rather than log files location
+func isStreamSource(URL string) bool {
+	switch url.Scheme(URL, file.Scheme) {
+	case DockerScheme, JournaldScheme:
+		return true
+	}
+	return false
+}
+
+// stream represents followed log stream, i.e. container output or journal unit
+type stream struct {
+	io.ReadCloser
+	name   string
+	cancel context.CancelFunc
+	once   sync.Once
+	err    error
+}
+
+// Close stops following log stream, subsequent calls are no-op
+func (s *stream) Close() error {
+	s.once.Do(func() {
+		s.cancel()
+		s.err = s.ReadCloser.Close()
+	})
+	return s.err
+}
+
+type closerFunc func() error
+
+// Close calls closer function
+func (f closerFunc) Close() error {
+	return f()
+}
+
+// streamContext returns cancelable context used to follow log stream
+func streamContext() (context.Context, context.CancelFunc) {
+	return context.WithCancel(context.Background())
+}
+
+// openStream starts following docker container or journal unit logs, source URL query since option limits initial records
+func openStream(context *endly.Context, URL string) (*stream, error) {
+	source, err := neturl.Parse(URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log source %v, %w", URL, err)
+	}
+	name := strings.Trim(source.Host+source.Path, "/")
+	ctx, cancel := streamContext()
+	var reader io.ReadCloser
+	switch source.Scheme {
+	case DockerScheme:
+		reader, err = openContainerLogs(ctx, context, name, source.Query().Get("since"))
+	case JournaldScheme:
+		reader, err = openJournal(ctx, name, source.Query().Get("since"), source.Query().Get("output"))
+		if name == "" {
+			name = "journal"
+		}
+	default:
+		err = fmt.Errorf("unsupported log stream source: %v", URL)
+	}
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &stream{ReadCloser: reader, name: name, cancel: cancel}, nil
+}
+
+// openContainerLogs follows container stdout and stderr, multiplexed output is demultiplexed unless container uses TTY
+func openContainerLogs(ctx context.Context, context *endly.Context, name, since string) (io.ReadCloser, error) {
+	if name == "" {
+		return nil, fmt.Errorf("container was empty, expected docker://<container>")
+	}
+	ctxClient, err := docker.GetCtxClient(context)
+	if err != nil {
+		return nil, err
+	}
+	info, err := ctxClient.Client.ContainerInspect(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container %v, %w", name, err)
+	}
+	reader, err := ctxClient.Client.ContainerLogs(ctx, name, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Since:      since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow container %v logs, %w", name, err)
+	}
+	if info.Config != nil && info.Config.Tty {
+		return reader, nil
+	}
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(pipeWriter, pipeWriter, reader)
+		_ = pipeWriter.CloseWithError(err)
+	}()
+	return &readCloser{Reader: pipeReader, closers: []io.Closer{reader, pipeReader}}, nil
+}
+
+// openJournal follows systemd journal unit with journalctl, by default only records logged after listen are read
+func openJournal(ctx context.Context, unit, since, output string) (io.ReadCloser, error) {
+	if output == "" {
+		output = defaultJournalOutput
+	}
+	var args = []string{"--follow", "--no-pager", "--output", output}
+	if since != "" {
+		args = append(args, "--since", since)
+	} else {
+		args = append(args, "--lines", "0")
+	}
+	if unit != "" {
+		args = append(args, "--unit", unit)
+	}
+	command := exec.CommandContext(ctx, "journalctl", args...)
+	reader, err := command.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = command.Start(); err != nil {
+		return nil, fmt.Errorf("failed to follow journal %v, %w", unit, err)
+	}
+	return &readCloser{Reader: reader, closers: []io.Closer{closerFunc(func() error {
+		_ = command.Process.Kill()
+		_ = command.Wait()
+		return nil
+	})}}, nil
+}
+
+// follow reads stream lines into each log type file, pending multi line records are pushed when no line was read within frequency
+func follow(context *endly.Context, reader io.ReadCloser, frequency time.Duration, files []*File) {
+	defer reader.Close()
+	var lines = make(chan string)
+	var done = make(chan struct{})
+	defer close(done)
+	var readErr error
+	go func() {
+		defer close(lines)
+		r := bufio.NewReaderSize(reader, 64*1024)
+		for {
+			line, err := r.ReadString('\n')
+			if line != "" {
+				select {
+				case lines <- line:
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+		}
+	}()
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+	var idle = true
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				for _, logFile := range files {
+					logFile.flushPending()
+				}
+				if readErr != nil && !context.IsClosed() {
+					log.Printf("failed to follow %v logs: %v", files[0].URL, readErr)
+				}
+				return
+			}
+			idle = false
+			for _, logFile := range files {
+				logFile.readLine(line)
+			}
+		case <-ticker.C:
+			if context.IsClosed() {
+				return
+			}
+			if idle {
+				for _, logFile := range files {
+					logFile.flushPending()
+				}
+			}
+			idle = true
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package log
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+)
+
+func TestIsStreamSource(t *testing.T) {
+	var useCases = []struct {
+		URL    string
+		expect bool
+	}{
+		{URL: "docker://myapp", expect: true},
+		{URL: "journald://nginx.service", expect: true},
+		{URL: "/tmp/logs", expect: false},
+		{URL: "scp://127.0.0.1/tmp/logs", expect: false},
+	}
+	for _, useCase := range useCases {
+		assert.EqualValues(t, useCase.expect, isStreamSource(useCase.URL), useCase.URL)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	context := endly.New().NewContext(toolbox.NewContext())
+	defer context.Close()
+	reader, writer := io.Pipe()
+	errors := newFile(context, &Type{Name: "errors", Inclusion: "ERROR", RecordStartRegExpr: `^\d{2}:`}, "myapp", "docker://myapp")
+	all := newFile(context, &Type{Name: "all"}, "myapp", "docker://myapp")
+	finished := make(chan bool)
+	go func() {
+		follow(context, reader, 50*time.Millisecond, []*File{errors, all})
+		finished <- true
+	}()
+
+	_, _ = writer.Write([]byte("10:00 INFO started\n10:01 ERROR failed\n"))
+	_, _ = writer.Write([]byte("\tat app.Main.run(Main.java:10)\n"))
+	time.Sleep(200 * time.Millisecond)
+	assert.EqualValues(t, []string{"10:01 ERROR failed\n\tat app.Main.run(Main.java:10)"}, recordLines(errors), "idle flush")
+
+	_, _ = writer.Write([]byte("10:02 ERROR closed"))
+	_ = writer.Close()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		assert.Fail(t, "follow did not finish on closed stream")
+	}
+	assert.EqualValues(t, []string{"10:01 ERROR failed\n\tat app.Main.run(Main.java:10)", "10:02 ERROR closed"}, recordLines(errors), "closed stream")
+	assert.EqualValues(t, []string{"10:00 INFO started", "10:01 ERROR failed", "at app.Main.run(Main.java:10)", "10:02 ERROR closed"}, recordLines(all), "single line")
+	assert.EqualValues(t, 4, all.Records[3].Number)
+}
+
+func TestFollow_Reset(t *testing.T) {
+	context := endly.New().NewContext(toolbox.NewContext())
+	defer context.Close()
+	srv := New().(*service)
+	reader, writer := io.Pipe()
+	logFile := newFile(context, &Type{Name: "app", RecordStartRegExpr: `^\d{2}:`}, "myapp", "docker://myapp")
+	logMeta := NewTypeMeta(nil, logFile.Type)
+	logMeta.LogFiles[logFile.Name] = logFile
+	state := srv.State()
+	state.Put(logTypeMetaKey("app"), logMeta)
+	finished := make(chan bool)
+	go func() {
+		follow(context, reader, 5*time.Millisecond, []*File{logFile})
+		finished <- true
+	}()
+	go func() {
+		for i := 0; i < 100; i++ {
+			_, _ = writer.Write([]byte("10:00 INFO started\n\tdetails\n"))
+		}
+		_ = writer.Close()
+	}()
+	for i := 0; i < 20; i++ {
+		_, err := srv.reset(context, &ResetRequest{LogTypes: []string{"app"}})
+		assert.Nil(t, err)
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		assert.Fail(t, "follow did not finish on closed stream")
+	}
+}
+
+func TestStream_Close(t *testing.T) {
+	var canceled, closed int
+	logStream := &stream{
+		ReadCloser: &readCloser{closers: []io.Closer{closerFunc(func() error {
+			closed++
+			return nil
+		})}},
+		cancel: func() { canceled++ },
+	}
+	assert.Nil(t, logStream.Close())
+	assert.Nil(t, logStream.Close())
+	assert.EqualValues(t, 1, canceled)
+	assert.EqualValues(t, 1, closed)
+}
+
+func recordLines(logFile *File) []string {
+	logFile.Mutex.Lock()
+	defer logFile.Mutex.Unlock()
+	var result = make([]string, 0)
+	for _, record := range logFile.Records {
+		result = append(result, record.Line)
+	}
+	return result
+}
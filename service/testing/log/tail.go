@@ -0,0 +1,236 @@
+package log
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/viant/afs"
+	"github.com/viant/afs/file"
+	"github.com/viant/afs/storage"
+	"github.com/viant/afs/url"
+)
+
+// fingerprintSize represents max number of the leading log bytes used to identify log file
+const fingerprintSize = 1024
+
+// rotationSuffix matches rotated log file name suffix, i.e. app.log.1, app.log.1.gz, app.log-20240101.gz
+var rotationSuffix = regexp.MustCompile(`^[.-][A-Za-z0-9._-]+$`)
+
+// isRotated returns true if name is rotated generation of base log file name
+func isRotated(base, name string) bool {
+	return strings.HasPrefix(name, base) && rotationSuffix.MatchString(name[len(base):])
+}
+
+// isRotatedGeneration returns true if name is rotated generation of other listed log file, rotated files are read by base log file tailer
+func isRotatedGeneration(name string, names map[string]bool) bool {
+	for index := strings.IndexAny(name, ".-"); index > 0; {
+		if names[name[:index]] {
+			return isRotated(name[:index], name)
+		}
+		next := strings.IndexAny(name[index+1:], ".-")
+		if next == -1 {
+			break
+		}
+		index += next + 1
+	}
+	return false
+}
+
+func isGzip(name string) bool {
+	return strings.HasSuffix(name, ".gz")
+}
+
+// localPath returns local file path for file scheme URL
+func localPath(URL string) (string, bool) {
+	if url.Scheme(URL, file.Scheme) != file.Scheme {
+		return "", false
+	}
+	return url.Path(URL), true
+}
+
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close closes all underlying readers
+func (r *readCloser) Close() error {
+	var err error
+	for _, closer := range r.closers {
+		if e := closer.Close(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// openAt returns log content reader starting at supplied offset, local files are seeked,
+// other storages are streamed from the beginning, gzip content is decompressed.
+func openAt(ctx context.Context, fs afs.Service, object storage.Object, offset int64) (io.ReadCloser, error) {
+	if location, ok := localPath(object.URL()); ok && !isGzip(object.Name()) {
+		localFile, err := os.Open(location)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = localFile.Seek(offset, io.SeekStart); err != nil {
+			_ = localFile.Close()
+			return nil, err
+		}
+		return localFile, nil
+	}
+	reader, err := fs.Open(ctx, object)
+	if err != nil {
+		return nil, err
+	}
+	var result = &readCloser{Reader: reader, closers: []io.Closer{reader}}
+	if isGzip(object.Name()) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			_ = reader.Close()
+			return nil, err
+		}
+		result.Reader = gzipReader
+		result.closers = append([]io.Closer{gzipReader}, result.closers...)
+	}
+	if offset > 0 {
+		if _, err = io.CopyN(io.Discard, result.Reader, offset); err != nil && err != io.EOF {
+			_ = result.Close()
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// fingerprint returns hash of the first size bytes, false if content is shorter than size
+func fingerprint(reader io.Reader, size int) (string, bool) {
+	var head = make([]byte, size)
+	if _, err := io.ReadFull(reader, head); err != nil {
+		return "", false
+	}
+	hash := sha1.Sum(head)
+	return hex.EncodeToString(hash[:]), true
+}
+
+// objectFingerprint returns hash of the first size object bytes
+func objectFingerprint(ctx context.Context, fs afs.Service, object storage.Object, size int) (string, bool) {
+	reader, err := openAt(ctx, fs, object, 0)
+	if err != nil {
+		return "", false
+	}
+	defer reader.Close()
+	return fingerprint(reader, size)
+}
+
+// tail reads log records appended since the last processed position, truncated log is read from the beginning,
+// rotated log previous generation is read till the end before the new log file is processed.
+func (f *File) tail(ctx context.Context, fs afs.Service, object storage.Object, siblings []storage.Object) error {
+	var state = f.ProcessingState
+	var size = int(object.Size())
+	var info os.FileInfo
+	if location, ok := localPath(object.URL()); ok {
+		info, _ = os.Stat(location)
+	}
+	if state.FingerprintSize > 0 {
+		replaced := info != nil && f.info != nil && !os.SameFile(info, f.info)
+		if !replaced {
+			current, ok := objectFingerprint(ctx, fs, object, state.FingerprintSize)
+			replaced = !ok || current != state.Fingerprint
+		}
+		switch {
+		case replaced:
+			if err := f.drainRotated(ctx, fs, siblings); err != nil {
+				return err
+			}
+			f.flushPending()
+			state.Reset()
+		case size < state.Position: //truncated in place, i.e. copytruncate
+			f.flushPending()
+			state.Reset()
+		}
+	}
+	f.info = info
+	if size > state.Position {
+		reader, err := openAt(ctx, fs, object, int64(state.Position))
+		if err != nil {
+			return err
+		}
+		err = f.readLogRecords(reader, false)
+		_ = reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+	f.updateFingerprint(func(size int) (string, bool) {
+		return objectFingerprint(ctx, fs, object, size)
+	})
+	return nil
+}
+
+// drainRotated reads remaining records from rotated log generation matching processed fingerprint
+func (f *File) drainRotated(ctx context.Context, fs afs.Service, siblings []storage.Object) error {
+	var candidates = make([]storage.Object, 0)
+	for _, candidate := range siblings {
+		if isRotated(f.Name, candidate.Name()) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ModTime().After(candidates[j].ModTime())
+	})
+	for _, candidate := range candidates {
+		if current, ok := objectFingerprint(ctx, fs, candidate, f.ProcessingState.FingerprintSize); !ok || current != f.ProcessingState.Fingerprint {
+			continue
+		}
+		reader, err := openAt(ctx, fs, candidate, int64(f.ProcessingState.Position))
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		return f.readLogRecords(reader, true)
+	}
+	return nil
+}
+
+// tailContent reads log records from transformed content, i.e. by UDF
+func (f *File) tailContent(content []byte) error {
+	var state = f.ProcessingState
+	if state.FingerprintSize > 0 {
+		current, ok := fingerprint(bytes.NewReader(content), state.FingerprintSize)
+		if !ok || current != state.Fingerprint || len(content) < state.Position {
+			f.flushPending()
+			state.Reset()
+		}
+	}
+	if len(content) > state.Position {
+		if err := f.readLogRecords(bytes.NewReader(content[state.Position:]), false); err != nil {
+			return err
+		}
+	}
+	f.updateFingerprint(func(size int) (string, bool) {
+		return fingerprint(bytes.NewReader(content), size)
+	})
+	return nil
+}
+
+// updateFingerprint extends fingerprint with processed bytes up to fingerprintSize
+func (f *File) updateFingerprint(compute func(size int) (string, bool)) {
+	var state = f.ProcessingState
+	if state.FingerprintSize >= fingerprintSize || state.Position <= state.FingerprintSize {
+		return
+	}
+	size := state.Position
+	if size > fingerprintSize {
+		size = fingerprintSize
+	}
+	if value, ok := compute(size); ok {
+		state.Fingerprint, state.FingerprintSize = value, size
+	}
+}
@@ -0,0 +1,129 @@
+package log
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/afs"
+)
+
+func TestFile_Tail(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "endly_log_tail")
+	_ = os.RemoveAll(dir)
+	if !assert.Nil(t, os.MkdirAll(dir, 0755)) {
+		return
+	}
+	logPath := filepath.Join(dir, "app.log")
+	appendLog := func(location, content string) {
+		file, err := os.OpenFile(location, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if assert.Nil(t, err) {
+			_, _ = file.WriteString(content)
+			_ = file.Close()
+		}
+	}
+	gzipLog := func(source, dest, content string) {
+		data, _ := os.ReadFile(source)
+		file, err := os.Create(dest)
+		if assert.Nil(t, err) {
+			writer := gzip.NewWriter(file)
+			_, _ = writer.Write(append(data, []byte(content)...))
+			_ = writer.Close()
+			_ = file.Close()
+		}
+		_ = os.Remove(source)
+	}
+
+	fs := afs.New()
+	ctx := context.Background()
+	logFile := &File{
+		Name:            "app.log",
+		Type:            &Type{},
+		ProcessingState: &ProcessingState{},
+		Mutex:           &sync.RWMutex{},
+	}
+
+	var useCases = []struct {
+		description string
+		change      func()
+		expect      []string
+	}{
+		{
+			description: "initial content with incomplete line",
+			change:      func() { appendLog(logPath, "line 1\nline 2\nline") },
+			expect:      []string{"line 1", "line 2"},
+		},
+		{
+			description: "appended content",
+			change:      func() { appendLog(logPath, " 3\nline 4\n") },
+			expect:      []string{"line 3", "line 4"},
+		},
+		{
+			description: "renamed rotation",
+			change: func() {
+				appendLog(logPath, "line 5\nline 6")
+				_ = os.Rename(logPath, logPath+".1")
+				appendLog(logPath, "new 1\n")
+			},
+			expect: []string{"line 5", "line 6", "new 1"},
+		},
+		{
+			description: "gzip rotation",
+			change: func() {
+				gzipLog(logPath, logPath+".2.gz", "new 2\n")
+				appendLog(logPath, "newer 1\n")
+			},
+			expect: []string{"new 2", "newer 1"},
+		},
+		{
+			description: "copy truncate",
+			change: func() {
+				_ = os.Truncate(logPath, 0)
+				appendLog(logPath, "truncated 1\n")
+			},
+			expect: []string{"truncated 1"},
+		},
+	}
+
+	for _, useCase := range useCases {
+		useCase.change()
+		objects, err := fs.List(ctx, dir)
+		if !assert.Nil(t, err, useCase.description) {
+			return
+		}
+		object, err := fs.Object(ctx, logPath)
+		if !assert.Nil(t, err, useCase.description) {
+			return
+		}
+		err = logFile.tail(ctx, fs, object, objects)
+		assert.Nil(t, err, useCase.description)
+		var actual = make([]string, 0)
+		for record := logFile.ShiftLogRecord(); record != nil; record = logFile.ShiftLogRecord() {
+			actual = append(actual, record.Line)
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
+
+func TestIsRotatedGeneration(t *testing.T) {
+	var names = map[string]bool{"app.log": true, "access.log": true}
+	var useCases = []struct {
+		name   string
+		expect bool
+	}{
+		{name: "app.log", expect: false},
+		{name: "app.log.1", expect: true},
+		{name: "app.log.1.gz", expect: true},
+		{name: "app.log-20240101.gz", expect: true},
+		{name: "access.log.2", expect: true},
+		{name: "error.log.1", expect: false},
+		{name: "app.logger", expect: false},
+	}
+	for _, useCase := range useCases {
+		assert.EqualValues(t, useCase.expect, isRotatedGeneration(useCase.name, names), useCase.name)
+	}
+}
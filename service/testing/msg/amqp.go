@@ -0,0 +1,242 @@
+package msg
+
+import (
+	"context"
+	"fmt"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/viant/scy/cred"
+	"github.com/viant/toolbox"
+	"strings"
+	"time"
+)
+
+const (
+	// routingKeyAttribute represents message routing key attribute, or comma separated subscription binding keys config attribute
+	routingKeyAttribute = "routingKey"
+	// exchangeKindAttribute represents topic exchange kind config attribute: direct, fanout, topic (default) or headers
+	exchangeKindAttribute = "kind"
+	amqpPollInterval      = 50 * time.Millisecond
+)
+
+// amqpClient represents AMQP 0.9.1 client, topic is an exchange, subscription is a queue bound to topic exchange, queue is a queue published with default exchange
+type amqpClient struct {
+	conn    *amqp.Connection
+	timeout time.Duration
+}
+
+// withChannel runs fn with a new channel, AMQP closes channel on any failed operation
+func (c *amqpClient) withChannel(fn func(channel *amqp.Channel) error) error {
+	channel, err := c.conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+	return fn(channel)
+}
+
+func (c *amqpClient) Push(ctx context.Context, dest *Resource, message *Message) (Result, error) {
+	data, err := messagePayload(message.Data)
+	if err != nil {
+		return nil, err
+	}
+	publishing := amqp.Publishing{Body: []byte(data), Timestamp: time.Now()}
+	var routingKey string
+	for k, v := range textAttributes(message.Attributes) {
+		switch k {
+		case routingKeyAttribute:
+			routingKey = v
+		case idAttribute:
+			publishing.MessageId = v
+		default:
+			if publishing.Headers == nil {
+				publishing.Headers = amqp.Table{}
+			}
+			publishing.Headers[k] = v
+		}
+	}
+	if toolbox.IsStructuredJSON(data) {
+		publishing.ContentType = "application/json"
+	}
+	exchange := dest.Name
+	resourceType := dest.Type
+	if resourceType == "" {
+		resourceType = ResourceTypeTopic
+		if c.queueExists(dest.Name) {
+			resourceType = ResourceTypeQueue
+		}
+	}
+	if resourceType != ResourceTypeTopic {
+		exchange, routingKey = "", dest.Name
+	}
+	err = c.withChannel(func(channel *amqp.Channel) error {
+		if err := channel.Confirm(false); err != nil {
+			return err
+		}
+		confirmation, err := channel.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, false, false, publishing)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+		acked, err := confirmation.WaitContext(ctx)
+		if err == nil && !acked {
+			err = fmt.Errorf("message was not confirmed")
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish to %v %v, %w", resourceType, dest.Name, err)
+	}
+	return publishing.MessageId, nil
+}
+
+// queueExists returns true if queue exists
+func (c *amqpClient) queueExists(name string) bool {
+	return c.withChannel(func(channel *amqp.Channel) error {
+		_, err := channel.QueueDeclarePassive(name, true, false, false, false, nil)
+		return err
+	}) == nil
+}
+
+func (c *amqpClient) PullN(ctx context.Context, source *Resource, count int, nack bool) ([]*Message, error) {
+	if count <= 0 {
+		count = 1
+	}
+	var result = make([]*Message, 0)
+	err := c.withChannel(func(channel *amqp.Channel) error {
+		var deliveries = make([]amqp.Delivery, 0)
+		var deadline = time.Now().Add(c.timeout)
+		for len(deliveries) < count {
+			delivery, ok, err := channel.Get(source.Name, false)
+			if err != nil {
+				return err
+			}
+			if ok {
+				deliveries = append(deliveries, delivery)
+				result = append(result, asAmqpMessage(&delivery))
+				continue
+			}
+			if time.Now().Add(amqpPollInterval).After(deadline) {
+				break
+			}
+			select {
+			case <-time.After(amqpPollInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		for _, delivery := range deliveries {
+			var err error
+			if nack {
+				err = delivery.Nack(false, true)
+			} else {
+				err = delivery.Ack(false)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull from %v, %w", source.Name, err)
+	}
+	return result, nil
+}
+
+func (c *amqpClient) SetupResource(resource *ResourceSetup) (*Resource, error) {
+	if resource.Recreate {
+		if err := c.DeleteResource(&resource.Resource); err != nil {
+			return nil, err
+		}
+	}
+	var attributes map[string]string
+	var args amqp.Table
+	if resource.Config != nil {
+		attributes = resource.Config.Attributes
+		if resource.Config.RetentionDuration > 0 {
+			args = amqp.Table{"x-message-ttl": resource.Config.RetentionDuration.Milliseconds()}
+		}
+	}
+	err := c.withChannel(func(channel *amqp.Channel) error {
+		switch resource.Type {
+		case ResourceTypeTopic:
+			kind := attributes[exchangeKindAttribute]
+			if kind == "" {
+				kind = amqp.ExchangeTopic
+			}
+			return channel.ExchangeDeclare(resource.Name, kind, true, false, false, false, nil)
+		case ResourceTypeQueue:
+			_, err := channel.QueueDeclare(resource.Name, true, false, false, false, args)
+			return err
+		case ResourceTypeSubscription:
+			if resource.Config == nil || resource.Config.Topic == nil {
+				return fmt.Errorf("config.topic was empty")
+			}
+			if _, err := channel.QueueDeclare(resource.Name, true, false, false, false, args); err != nil {
+				return err
+			}
+			routingKeys := attributes[routingKeyAttribute]
+			if routingKeys == "" {
+				routingKeys = "#"
+			}
+			for _, routingKey := range strings.Split(routingKeys, ",") {
+				if err := channel.QueueBind(resource.Name, strings.TrimSpace(routingKey), resource.Config.Topic.Name, false, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return fmt.Errorf("unsupported resource type: %v", resource.Type)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup %v %v, %w", resource.Type, resource.Name, err)
+	}
+	return &resource.Resource, nil
+}
+
+func (c *amqpClient) DeleteResource(resource *Resource) error {
+	return c.withChannel(func(channel *amqp.Channel) error {
+		if resource.Type == ResourceTypeTopic {
+			return channel.ExchangeDelete(resource.Name, false, false)
+		}
+		_, err := channel.QueueDelete(resource.Name, false, false, false)
+		return err
+	})
+}
+
+func (c *amqpClient) Close() error {
+	return c.conn.Close()
+}
+
+// asAmqpMessage returns pulled message, headers, routing key and exchange are returned as attributes
+func asAmqpMessage(delivery *amqp.Delivery) *Message {
+	var attributes = make(map[string]string)
+	for k, v := range delivery.Headers {
+		attributes[k] = toolbox.AsString(v)
+	}
+	attributes[routingKeyAttribute] = delivery.RoutingKey
+	if delivery.Exchange != "" {
+		attributes["exchange"] = delivery.Exchange
+	}
+	ID := delivery.MessageId
+	if ID != "" {
+		attributes[idAttribute] = ID
+	}
+	return newPulledMessage(ID, string(delivery.Body), attributes)
+}
+
+func newAmqpClient(credConfig *cred.Generic, resource *Resource, timeout time.Duration) (Client, error) {
+	URL, err := serverURL(resource, credConfig, ResourceVendorAMQP)
+	if err != nil {
+		return nil, err
+	}
+	if vhost := URL.Query().Get("vhost"); vhost != "" {
+		URL.Path, URL.RawQuery = "/"+vhost, ""
+	}
+	conn, err := amqp.DialConfig(URL.String(), amqp.Config{Dial: amqp.DefaultDial(timeout)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %v, %w", URL.Redacted(), err)
+	}
+	return &amqpClient{conn: conn, timeout: timeout}, nil
+}
@@ -12,8 +12,22 @@ const (
 	ResourceVendorGoogleCloudPlatform = "gcp"
 	ResourceVendorAmazonWebService    = "aws"
 	ResourceVendorKafka               = "kafka"
+	ResourceVendorMemory              = "mem"
+	ResourceVendorNATS                = "nats"
+	ResourceVendorAMQP                = "amqp"
+	ResourceVendorRedis               = "redis"
 )
 
+// vendorSchemes maps resource URL scheme to vendor
+var vendorSchemes = map[string]string{
+	"mem":    ResourceVendorMemory,
+	"nats":   ResourceVendorNATS,
+	"amqp":   ResourceVendorAMQP,
+	"amqps":  ResourceVendorAMQP,
+	"redis":  ResourceVendorRedis,
+	"rediss": ResourceVendorRedis,
+}
+
 type Client interface {
 	Push(ctx context.Context, dest *Resource, message *Message) (Result, error)
 
@@ -37,11 +51,15 @@ func NewPubSubClient(context *endly.Context, dest *Resource, timeout time.Durati
 	if err != nil {
 		return nil, err
 	}
-	if len(dest.Brokers) > 0 {
+	if len(dest.Brokers) > 0 && (dest.Vendor == "" || dest.Vendor == ResourceVendorKafka) {
 		dest.Vendor = ResourceVendorKafka
 		dest.Type = ResourceTypeTopic
 	}
 
+	if dest.Vendor == "" {
+		dest.Vendor = inferVendorFromURL(dest.URL)
+	}
+
 	if dest.Vendor == "" {
 		dest.Vendor = inferResourceTypeFromCredentialConfig(credConfig)
 	}
@@ -58,6 +76,14 @@ func NewPubSubClient(context *endly.Context, dest *Resource, timeout time.Durati
 		return newAwsSqsClient(credConfig, timeout)
 	case ResourceVendorKafka:
 		return newKafkaClient(timeout)
+	case ResourceVendorMemory:
+		return newMemClient(timeout)
+	case ResourceVendorNATS:
+		return newNatsClient(credConfig, dest, timeout)
+	case ResourceVendorAMQP:
+		return newAmqpClient(credConfig, dest, timeout)
+	case ResourceVendorRedis:
+		return newRedisStreamClient(credConfig, dest, timeout)
 	}
 	return nil, fmt.Errorf("unsupported vendor: '%v'", dest.Vendor)
 
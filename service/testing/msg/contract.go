@@ -173,3 +173,28 @@ func (m *Message) Expand(state data.Map) *Message {
 }
 
 type Result interface{}
+
+// ServeRequest represents in-memory mem vendor broker HTTP exposure request
+type ServeRequest struct {
+	Port int `required:"true" description:"port exposing mem vendor topics, subscriptions and queues HTTP API"`
+}
+
+func (r *ServeRequest) Validate() error {
+	if r.Port == 0 {
+		return fmt.Errorf("port was empty")
+	}
+	return nil
+}
+
+// ServeResponse represents serve response
+type ServeResponse struct {
+	URL string
+}
+
+// ShutdownRequest represents in-memory broker HTTP exposure shutdown request
+type ShutdownRequest struct {
+	Port int
+}
+
+// ShutdownResponse represents shutdown response
+type ShutdownResponse struct{}
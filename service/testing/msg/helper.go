@@ -3,12 +3,14 @@ package msg
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/viant/endly"
 	"github.com/viant/scy/cred"
 	"github.com/viant/toolbox"
+	"net/url"
 	"strings"
 )
 
@@ -62,6 +64,15 @@ func inferResourceTypeFromCredentialConfig(credConfig *cred.Generic) string {
 	return ""
 }
 
+// inferVendorFromURL returns vendor for resource URL scheme, i.e. nats://localhost:4222/orders
+func inferVendorFromURL(URL string) string {
+	index := strings.Index(URL, "://")
+	if index == -1 {
+		return ""
+	}
+	return vendorSchemes[strings.ToLower(URL[:index])]
+}
+
 func expandResource(context *endly.Context, resource *Resource) *Resource {
 	state := context.State()
 	return &Resource{
@@ -74,6 +85,7 @@ func expandResource(context *endly.Context, resource *Resource) *Resource {
 		Partitions:        resource.Partitions,
 		Partition:         resource.Partition,
 		Offset:            resource.Offset,
+		GroupID:           state.ExpandAsText(resource.GroupID),
 		ReplicationFactor: resource.ReplicationFactor,
 	}
 }
@@ -111,3 +123,69 @@ func putSnsMessageAttributes(attributes map[string]interface{}, target map[strin
 		}
 	}
 }
+
+// textAttributes returns message attributes as text
+func textAttributes(attributes map[string]interface{}) map[string]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+	var result = make(map[string]string)
+	for k, v := range attributes {
+		if v == nil {
+			continue
+		}
+		result[k] = toolbox.AsString(v)
+	}
+	return result
+}
+
+// messagePayload returns message data as text, non text data is JSON encoded
+func messagePayload(data interface{}) (string, error) {
+	switch value := data.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	}
+	text, err := toolbox.AsJSONText(data)
+	return strings.TrimRight(text, "\n"), err
+}
+
+// newPulledMessage creates pulled message, JSON data is also provided as transformed map
+func newPulledMessage(ID string, data string, attributes map[string]string) *Message {
+	var result = &Message{
+		ID:   ID,
+		Data: data,
+	}
+	if aMap, err := toolbox.JSONToMap(data); err == nil && len(aMap) > 0 {
+		result.Transformed = aMap
+	}
+	if len(attributes) > 0 {
+		result.Attributes = make(map[string]interface{})
+		for k, v := range attributes {
+			result.Attributes[k] = v
+		}
+	}
+	return result
+}
+
+// serverURL returns vendor server URL with credentials, resource URL path is used as resource name,
+// if resource URL has no scheme the first broker is used as server address
+func serverURL(resource *Resource, credConfig *cred.Generic, defaultScheme string) (*url.URL, error) {
+	var result = &url.URL{Scheme: defaultScheme, Host: "localhost"}
+	if strings.Contains(resource.URL, "://") {
+		parsed, err := url.Parse(resource.URL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v URL: %v, %w", resource.Vendor, resource.URL, err)
+		}
+		result = &url.URL{Scheme: parsed.Scheme, Host: parsed.Host, User: parsed.User, RawQuery: parsed.RawQuery}
+	} else if len(resource.Brokers) > 0 {
+		result.Host = resource.Brokers[0]
+	}
+	if result.User == nil && credConfig != nil && credConfig.Username != "" {
+		result.User = url.UserPassword(credConfig.Username, credConfig.Password)
+	}
+	return result, nil
+}
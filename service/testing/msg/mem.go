@@ -0,0 +1,401 @@
+package msg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/viant/toolbox"
+)
+
+const (
+	// orderingKeyAttribute represents message attribute, messages with the same ordering key are delivered one lease at a time in publish order
+	orderingKeyAttribute = "orderingKey"
+	defaultAckDeadline   = 10 * time.Second
+)
+
+// memBroker represents in-memory message broker shared by mem vendor clients and served endpoints
+var memBroker = newMemoryBroker()
+
+// memMessage represents published message
+type memMessage struct {
+	ID          string            `json:"id"`
+	Data        string            `json:"data"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	PublishTime time.Time         `json:"publishTime"`
+	sequence    int
+}
+
+// memDelivery represents message delivery to a subscription or queue
+type memDelivery struct {
+	*memMessage
+	AckID           string `json:"ackId"`
+	DeliveryAttempt int    `json:"deliveryAttempt"`
+	deadline        time.Time
+}
+
+// memQueue represents queue or topic subscription with pending and leased (pulled but not acknowledged) deliveries
+type memQueue struct {
+	Name        string
+	Type        string
+	Topic       string
+	AckDeadline time.Duration
+	pending     []*memDelivery
+	leased      map[string]*memDelivery
+}
+
+// push appends or reinserts delivery keeping publish order
+func (q *memQueue) push(delivery *memDelivery) {
+	index := sort.Search(len(q.pending), func(i int) bool {
+		return q.pending[i].sequence > delivery.sequence
+	})
+	q.pending = append(q.pending, nil)
+	copy(q.pending[index+1:], q.pending[index:])
+	q.pending[index] = delivery
+}
+
+// expire returns leased deliveries past ack deadline to pending deliveries
+func (q *memQueue) expire(now time.Time) {
+	for ackID, delivery := range q.leased {
+		if now.After(delivery.deadline) {
+			delete(q.leased, ackID)
+			q.push(delivery)
+		}
+	}
+}
+
+// lease takes up to max pending deliveries, delivery is skipped while earlier message with the same ordering key is leased
+func (q *memQueue) lease(max int, now time.Time, nextAckID func() string) []*memDelivery {
+	var blocked = make(map[string]bool)
+	for _, delivery := range q.leased {
+		if key := delivery.Attributes[orderingKeyAttribute]; key != "" {
+			blocked[key] = true
+		}
+	}
+	var result = make([]*memDelivery, 0)
+	var pending = make([]*memDelivery, 0, len(q.pending))
+	for _, delivery := range q.pending {
+		key := delivery.Attributes[orderingKeyAttribute]
+		if len(result) >= max || (key != "" && blocked[key]) {
+			if key != "" {
+				blocked[key] = true
+			}
+			pending = append(pending, delivery)
+			continue
+		}
+		delivery.AckID = nextAckID()
+		delivery.DeliveryAttempt++
+		delivery.deadline = now.Add(q.AckDeadline)
+		q.leased[delivery.AckID] = delivery
+		result = append(result, delivery)
+		if key != "" {
+			blocked[key] = true
+		}
+	}
+	q.pending = pending
+	return result
+}
+
+// memoryBroker represents in-memory topics, subscriptions and queues
+type memoryBroker struct {
+	mux      sync.Mutex
+	sequence int
+	ackIDs   int
+	topics   map[string]map[string]bool
+	queues   map[string]*memQueue
+	notify   chan struct{}
+}
+
+func newMemoryBroker() *memoryBroker {
+	return &memoryBroker{
+		topics: make(map[string]map[string]bool),
+		queues: make(map[string]*memQueue),
+		notify: make(chan struct{}),
+	}
+}
+
+// broadcast wakes up waiting pullers, has to be called with lock held
+func (b *memoryBroker) broadcast() {
+	close(b.notify)
+	b.notify = make(chan struct{})
+}
+
+// CreateTopic creates a topic if it does not exist
+func (b *memoryBroker) CreateTopic(name string) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if _, ok := b.topics[name]; !ok {
+		b.topics[name] = make(map[string]bool)
+	}
+}
+
+// CreateQueue creates queue or topic subscription, existing queue ack deadline is updated, detached subscription is re-attached
+func (b *memoryBroker) CreateQueue(resourceType, name, topic string, ackDeadline time.Duration) error {
+	if ackDeadline <= 0 {
+		ackDeadline = defaultAckDeadline
+	}
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	queue, ok := b.queues[name]
+	if ok && (queue.Type != resourceType || (queue.Topic != topic && queue.Topic != "")) {
+		return fmt.Errorf("%v %v already exists", queue.Type, name)
+	}
+	if resourceType == ResourceTypeSubscription {
+		subscriptions, ok := b.topics[topic]
+		if !ok {
+			return fmt.Errorf("topic %v does not exist", topic)
+		}
+		subscriptions[name] = true
+	}
+	if ok {
+		queue.Topic = topic
+		queue.AckDeadline = ackDeadline
+		return nil
+	}
+	b.queues[name] = &memQueue{Name: name, Type: resourceType, Topic: topic, AckDeadline: ackDeadline, leased: make(map[string]*memDelivery)}
+	return nil
+}
+
+// Delete removes topic, subscription or queue, topic subscriptions are detached
+func (b *memoryBroker) Delete(resourceType, name string) error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if resourceType == ResourceTypeTopic {
+		subscriptions, ok := b.topics[name]
+		if !ok {
+			return fmt.Errorf("topic %v does not exist", name)
+		}
+		for subscription := range subscriptions {
+			if queue, ok := b.queues[subscription]; ok {
+				queue.Topic = "" //detached, re-attached once created again for a topic
+			}
+		}
+		delete(b.topics, name)
+		return nil
+	}
+	queue, ok := b.queues[name]
+	if !ok {
+		return fmt.Errorf("%v %v does not exist", resourceType, name)
+	}
+	if subscriptions, ok := b.topics[queue.Topic]; ok {
+		delete(subscriptions, name)
+	}
+	delete(b.queues, name)
+	return nil
+}
+
+// Publish publishes message to topic subscriptions or queue, resource type is inferred from existing resources if empty, missing topic is created
+func (b *memoryBroker) Publish(resourceType, name string, data string, attributes map[string]string) (string, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if resourceType == "" {
+		resourceType = ResourceTypeTopic
+		if queue, ok := b.queues[name]; ok && queue.Type == ResourceTypeQueue {
+			resourceType = ResourceTypeQueue
+		}
+	}
+	b.sequence++
+	message := &memMessage{ID: strconv.Itoa(b.sequence), Data: data, Attributes: attributes, PublishTime: time.Now(), sequence: b.sequence}
+	switch resourceType {
+	case ResourceTypeTopic:
+		subscriptions, ok := b.topics[name]
+		if !ok {
+			subscriptions = make(map[string]bool)
+			b.topics[name] = subscriptions
+		}
+		for subscription := range subscriptions {
+			b.queues[subscription].push(&memDelivery{memMessage: message})
+		}
+	case ResourceTypeQueue:
+		queue, ok := b.queues[name]
+		if !ok {
+			queue = &memQueue{Name: name, Type: ResourceTypeQueue, AckDeadline: defaultAckDeadline, leased: make(map[string]*memDelivery)}
+			b.queues[name] = queue
+		}
+		queue.push(&memDelivery{memMessage: message})
+	default:
+		return "", fmt.Errorf("unsupported resource type: %v", resourceType)
+	}
+	b.broadcast()
+	return message.ID, nil
+}
+
+// Pull leases up to max messages from subscription or queue, waits till any message is available or wait time elapses
+func (b *memoryBroker) Pull(ctx context.Context, name string, max int, wait time.Duration) ([]*memDelivery, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		b.mux.Lock()
+		queue, ok := b.queues[name]
+		if !ok {
+			b.mux.Unlock()
+			return nil, fmt.Errorf("subscription or queue %v does not exist", name)
+		}
+		now := time.Now()
+		queue.expire(now)
+		result := queue.lease(max, now, func() string {
+			b.ackIDs++
+			return name + "-" + strconv.Itoa(b.ackIDs)
+		})
+		notify := b.notify
+		var nextDeadline time.Duration
+		for _, delivery := range queue.leased {
+			if remaining := delivery.deadline.Sub(now); nextDeadline == 0 || remaining < nextDeadline {
+				nextDeadline = remaining + time.Millisecond
+			}
+		}
+		b.mux.Unlock()
+		if len(result) > 0 {
+			return result, nil
+		}
+		var expired <-chan time.Time
+		if nextDeadline > 0 {
+			expired = time.After(nextDeadline)
+		}
+		select {
+		case <-notify:
+		case <-expired:
+		case <-timer.C:
+			return result, nil
+		case <-ctx.Done():
+			return result, nil
+		}
+	}
+}
+
+// Ack acknowledges leased messages
+func (b *memoryBroker) Ack(name string, ackIDs ...string) error {
+	return b.release(name, false, ackIDs)
+}
+
+// Nack returns leased messages for immediate redelivery
+func (b *memoryBroker) Nack(name string, ackIDs ...string) error {
+	return b.release(name, true, ackIDs)
+}
+
+func (b *memoryBroker) release(name string, redeliver bool, ackIDs []string) error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	queue, ok := b.queues[name]
+	if !ok {
+		return fmt.Errorf("subscription or queue %v does not exist", name)
+	}
+	for _, ackID := range ackIDs {
+		delivery, ok := queue.leased[ackID]
+		if !ok {
+			continue //already acknowledged or lease expired
+		}
+		delete(queue.leased, ackID)
+		if redeliver {
+			queue.push(delivery)
+		}
+	}
+	b.broadcast()
+	return nil
+}
+
+// Resources returns topics and queues with pending and leased message counts
+func (b *memoryBroker) Resources() map[string]interface{} {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	var topics = make(map[string]interface{})
+	for name, subscriptions := range b.topics {
+		topics[name] = toolbox.MapKeysToStringSlice(subscriptions)
+	}
+	var queues = make(map[string]interface{})
+	for name, queue := range b.queues {
+		queues[name] = map[string]interface{}{
+			"type":    queue.Type,
+			"topic":   queue.Topic,
+			"pending": len(queue.pending),
+			"leased":  len(queue.leased),
+		}
+	}
+	return map[string]interface{}{"topics": topics, "queues": queues}
+}
+
+// memClient represents mem vendor client
+type memClient struct {
+	broker  *memoryBroker
+	timeout time.Duration
+}
+
+func (c *memClient) Push(ctx context.Context, dest *Resource, message *Message) (Result, error) {
+	data, err := messagePayload(message.Data)
+	if err != nil {
+		return nil, err
+	}
+	return c.broker.Publish(dest.Type, dest.Name, data, textAttributes(message.Attributes))
+}
+
+func (c *memClient) PullN(ctx context.Context, source *Resource, count int, nack bool) ([]*Message, error) {
+	if count <= 0 {
+		count = 1
+	}
+	var deadline = time.Now().Add(c.timeout)
+	var result = make([]*Message, 0)
+	var ackIDs = make([]string, 0)
+	for len(result) < count {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			break
+		}
+		deliveries, err := c.broker.Pull(ctx, source.Name, count-len(result), wait)
+		if err != nil {
+			return nil, err
+		}
+		for _, delivery := range deliveries {
+			ackIDs = append(ackIDs, delivery.AckID)
+			result = append(result, delivery.asMessage())
+		}
+	}
+	if nack {
+		return result, c.broker.Nack(source.Name, ackIDs...)
+	}
+	return result, c.broker.Ack(source.Name, ackIDs...)
+}
+
+func (c *memClient) SetupResource(resource *ResourceSetup) (*Resource, error) {
+	if resource.Recreate {
+		_ = c.broker.Delete(resource.Type, resource.Name)
+	}
+	switch resource.Type {
+	case ResourceTypeTopic:
+		c.broker.CreateTopic(resource.Name)
+	case ResourceTypeSubscription, ResourceTypeQueue:
+		var topic string
+		var ackDeadline time.Duration
+		if resource.Config != nil {
+			if resource.Config.Topic != nil {
+				topic = resource.Config.Topic.Name
+			}
+			ackDeadline = resource.Config.AckDeadline
+		}
+		if err := c.broker.CreateQueue(resource.Type, resource.Name, topic, ackDeadline); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported resource type: %v, %v", resource.Type, resource.Name)
+	}
+	return &resource.Resource, nil
+}
+
+func (c *memClient) DeleteResource(resource *Resource) error {
+	return c.broker.Delete(resource.Type, resource.Name)
+}
+
+func (c *memClient) Close() error {
+	return nil
+}
+
+// asMessage returns delivery as pulled message
+func (d *memDelivery) asMessage() *Message {
+	return newPulledMessage(d.ID, d.Data, d.Attributes)
+}
+
+func newMemClient(timeout time.Duration) (Client, error) {
+	return &memClient{broker: memBroker, timeout: timeout}, nil
+}
@@ -0,0 +1,182 @@
+package msg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var memServers = struct {
+	mux     sync.Mutex
+	servers map[int]*http.Server
+}{servers: make(map[int]*http.Server)}
+
+// memPublishMessage represents HTTP published message, data can be any JSON value, non string value is published as JSON text
+type memPublishMessage struct {
+	Data       json.RawMessage   `json:"data"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// memQueueSetup represents HTTP subscription or queue setup
+type memQueueSetup struct {
+	Topic         string `json:"topic"`
+	AckDeadlineMs int    `json:"ackDeadlineMs"`
+}
+
+// memAcknowledgement represents HTTP ack or nack request
+type memAcknowledgement struct {
+	AckIDs []string `json:"ackIds"`
+}
+
+// newMemHandler returns HTTP API exposing broker topics, subscriptions and queues
+func newMemHandler(broker *memoryBroker) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /resources", func(writer http.ResponseWriter, request *http.Request) {
+		writeMemJSON(writer, http.StatusOK, broker.Resources())
+	})
+	mux.HandleFunc("PUT /topics/{name}", func(writer http.ResponseWriter, request *http.Request) {
+		broker.CreateTopic(request.PathValue("name"))
+		writer.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("DELETE /topics/{name}", func(writer http.ResponseWriter, request *http.Request) {
+		writeMemError(writer, broker.Delete(ResourceTypeTopic, request.PathValue("name")))
+	})
+	for kind, resourceType := range map[string]string{"topics": ResourceTypeTopic, "queues": ResourceTypeQueue} {
+		mux.HandleFunc("POST /"+kind+"/{name}/messages", func(writer http.ResponseWriter, request *http.Request) {
+			messages, err := readMemMessages(request)
+			if err != nil {
+				writeMemJSON(writer, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+			var ids = make([]string, 0, len(messages))
+			for _, message := range messages {
+				id, err := broker.Publish(resourceType, request.PathValue("name"), message.text(), message.Attributes)
+				if err != nil {
+					writeMemError(writer, err)
+					return
+				}
+				ids = append(ids, id)
+			}
+			writeMemJSON(writer, http.StatusOK, map[string][]string{"ids": ids})
+		})
+	}
+	for kind, resourceType := range map[string]string{"subscriptions": ResourceTypeSubscription, "queues": ResourceTypeQueue} {
+		mux.HandleFunc("PUT /"+kind+"/{name}", func(writer http.ResponseWriter, request *http.Request) {
+			setup := &memQueueSetup{}
+			if request.ContentLength != 0 {
+				if err := json.NewDecoder(request.Body).Decode(setup); err != nil {
+					writeMemJSON(writer, http.StatusBadRequest, map[string]string{"error": err.Error()})
+					return
+				}
+			}
+			err := broker.CreateQueue(resourceType, request.PathValue("name"), setup.Topic, time.Duration(setup.AckDeadlineMs)*time.Millisecond)
+			writeMemError(writer, err)
+		})
+		mux.HandleFunc("DELETE /"+kind+"/{name}", func(writer http.ResponseWriter, request *http.Request) {
+			writeMemError(writer, broker.Delete(resourceType, request.PathValue("name")))
+		})
+		mux.HandleFunc("GET /"+kind+"/{name}/messages", func(writer http.ResponseWriter, request *http.Request) {
+			max, _ := strconv.Atoi(request.URL.Query().Get("max"))
+			if max <= 0 {
+				max = 1
+			}
+			waitMs, _ := strconv.Atoi(request.URL.Query().Get("waitMs"))
+			deliveries, err := broker.Pull(request.Context(), request.PathValue("name"), max, time.Duration(waitMs)*time.Millisecond)
+			if err != nil {
+				writeMemError(writer, err)
+				return
+			}
+			writeMemJSON(writer, http.StatusOK, deliveries)
+		})
+		for action, redeliver := range map[string]bool{"ack": false, "nack": true} {
+			mux.HandleFunc("POST /"+kind+"/{name}/"+action, func(writer http.ResponseWriter, request *http.Request) {
+				acknowledgement := &memAcknowledgement{}
+				if err := json.NewDecoder(request.Body).Decode(acknowledgement); err != nil {
+					writeMemJSON(writer, http.StatusBadRequest, map[string]string{"error": err.Error()})
+					return
+				}
+				writeMemError(writer, broker.release(request.PathValue("name"), redeliver, acknowledgement.AckIDs))
+			})
+		}
+	}
+	return mux
+}
+
+// text returns published message data as text
+func (m *memPublishMessage) text() string {
+	var text string
+	if err := json.Unmarshal(m.Data, &text); err == nil {
+		return text
+	}
+	return string(m.Data)
+}
+
+// readMemMessages reads single message or messages array
+func readMemMessages(request *http.Request) ([]*memPublishMessage, error) {
+	var payload json.RawMessage
+	if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+		return nil, err
+	}
+	var messages = make([]*memPublishMessage, 0)
+	if err := json.Unmarshal(payload, &messages); err == nil {
+		return messages, nil
+	}
+	message := &memPublishMessage{}
+	if err := json.Unmarshal(payload, message); err != nil {
+		return nil, err
+	}
+	return append(messages, message), nil
+}
+
+func writeMemJSON(writer http.ResponseWriter, status int, value interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_ = json.NewEncoder(writer).Encode(value)
+}
+
+func writeMemError(writer http.ResponseWriter, err error) {
+	if err != nil {
+		writeMemJSON(writer, http.StatusNotFound, map[string]string{"error": err.Error()})
+		return
+	}
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// startMemServer exposes in-memory broker on supplied port, already started server is reused
+func startMemServer(port int) (string, error) {
+	URL := fmt.Sprintf("http://127.0.0.1:%d", port)
+	memServers.mux.Lock()
+	defer memServers.mux.Unlock()
+	if _, ok := memServers.servers[port]; ok {
+		return URL, nil
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return "", fmt.Errorf("failed to listen on %v, %w", port, err)
+	}
+	server := &http.Server{Handler: newMemHandler(memBroker)}
+	memServers.servers[port] = server
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	return URL, nil
+}
+
+// stopMemServer shuts down server exposing in-memory broker
+func stopMemServer(port int) error {
+	memServers.mux.Lock()
+	server, ok := memServers.servers[port]
+	delete(memServers.servers, port)
+	memServers.mux.Unlock()
+	if !ok {
+		return fmt.Errorf("mem broker was not served on port %v", port)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return server.Shutdown(ctx)
+}
@@ -0,0 +1,201 @@
+package msg
+
+import (
+	"bytes"
+	gocontext "context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+)
+
+func TestService_MemPushPull(t *testing.T) {
+	var resources = []*ResourceSetup{
+		{Resource: Resource{Vendor: ResourceVendorMemory, Name: "memTopic", Type: ResourceTypeTopic}, Recreate: true},
+		{Resource: Resource{Vendor: ResourceVendorMemory, Name: "memSub1", Type: ResourceTypeSubscription}, Recreate: true, Config: NewConfig("memTopic")},
+		{Resource: Resource{Vendor: ResourceVendorMemory, Name: "memSub2", Type: ResourceTypeSubscription}, Recreate: true, Config: NewConfig("memTopic")},
+		{Resource: Resource{Vendor: ResourceVendorMemory, Name: "memQueue", Type: ResourceTypeQueue}, Recreate: true},
+	}
+	if !createResources(t, resources...) {
+		return
+	}
+	defer deleteResource(t, resources...)
+
+	push := func(dest string, messages ...*Message) {
+		err := endly.Run(nil, &PushRequest{Dest: &Resource{URL: "mem://" + dest}, Messages: messages}, &PushResponse{})
+		assert.Nil(t, err)
+	}
+	pull := func(source string, count int, nack bool) []interface{} {
+		var response = &PullResponse{}
+		err := endly.Run(nil, &PullRequest{Source: &Resource{URL: "mem://" + source}, Count: count, Nack: nack, TimeoutMs: 200}, response)
+		assert.Nil(t, err)
+		var result = make([]interface{}, 0)
+		for _, message := range response.Messages {
+			result = append(result, message.Data)
+		}
+		return result
+	}
+
+	push("memTopic", &Message{Data: "m1"}, &Message{Data: map[string]interface{}{"k": 1}})
+	push("memQueue", &Message{Data: "q1"}, &Message{Data: "q2"})
+
+	var useCases = []struct {
+		description string
+		source      string
+		count       int
+		nack        bool
+		expect      []interface{}
+	}{
+		{description: "fan-out nack", source: "memSub1", count: 1, nack: true, expect: []interface{}{"m1"}},
+		{description: "nacked redelivered in order", source: "memSub1", count: 2, expect: []interface{}{"m1", `{"k":1}`}},
+		{description: "acked", source: "memSub1", count: 1, expect: []interface{}{}},
+		{description: "fan-out second subscription", source: "memSub2", count: 2, expect: []interface{}{"m1", `{"k":1}`}},
+		{description: "queue", source: "memQueue", count: 3, expect: []interface{}{"q1", "q2"}},
+	}
+	for _, useCase := range useCases {
+		assert.EqualValues(t, useCase.expect, pull(useCase.source, useCase.count, useCase.nack), useCase.description)
+	}
+
+	err := endly.Run(nil, &PullRequest{Source: &Resource{URL: "mem://missing"}, Count: 1, TimeoutMs: 100}, &PullResponse{})
+	assert.NotNil(t, err)
+}
+
+func TestMemoryBroker_Ordering(t *testing.T) {
+	broker := newMemoryBroker()
+	if !assert.Nil(t, broker.CreateQueue(ResourceTypeQueue, "orders", "", 50*time.Millisecond)) {
+		return
+	}
+	for _, data := range []string{"a1", "b1", "a2"} {
+		_, _ = broker.Publish(ResourceTypeQueue, "orders", data, map[string]string{orderingKeyAttribute: data[:1]})
+	}
+	ctx := gocontext.Background()
+	first, _ := broker.Pull(ctx, "orders", 1, 0)
+	second, _ := broker.Pull(ctx, "orders", 2, 0)
+	if assert.Len(t, first, 1) && assert.Len(t, second, 1) {
+		assert.EqualValues(t, "a1", first[0].Data)
+		assert.EqualValues(t, "b1", second[0].Data, "a2 is held till a1 is acknowledged")
+	}
+	expired, _ := broker.Pull(ctx, "orders", 3, time.Second)
+	if assert.Len(t, expired, 2, "expired leases redelivered, a2 is still held") {
+		assert.EqualValues(t, "a1", expired[0].Data)
+		assert.EqualValues(t, 2, expired[0].DeliveryAttempt)
+		assert.EqualValues(t, "b1", expired[1].Data)
+		assert.Nil(t, broker.Ack("orders", expired[0].AckID))
+	}
+	next, _ := broker.Pull(ctx, "orders", 3, 0)
+	if assert.Len(t, next, 1) {
+		assert.EqualValues(t, "a2", next[0].Data)
+		assert.EqualValues(t, 1, next[0].DeliveryAttempt)
+	}
+}
+
+func TestMemoryBroker_OrderingNack(t *testing.T) {
+	broker := newMemoryBroker()
+	if !assert.Nil(t, broker.CreateQueue(ResourceTypeQueue, "orders", "", time.Minute)) {
+		return
+	}
+	for _, data := range []string{"a1", "a2"} {
+		_, _ = broker.Publish(ResourceTypeQueue, "orders", data, map[string]string{orderingKeyAttribute: "a"})
+	}
+	ctx := gocontext.Background()
+	first, _ := broker.Pull(ctx, "orders", 2, 0)
+	if !assert.Len(t, first, 1, "one lease per ordering key") {
+		return
+	}
+	assert.EqualValues(t, "a1", first[0].Data)
+	held, _ := broker.Pull(ctx, "orders", 2, 0)
+	assert.Len(t, held, 0, "a2 is held till a1 is acknowledged")
+
+	assert.Nil(t, broker.Nack("orders", first[0].AckID))
+	redelivered, _ := broker.Pull(ctx, "orders", 2, 0)
+	if assert.Len(t, redelivered, 1) {
+		assert.EqualValues(t, "a1", redelivered[0].Data, "nacked message is redelivered before later ones")
+		assert.EqualValues(t, 2, redelivered[0].DeliveryAttempt)
+		assert.Nil(t, broker.Ack("orders", redelivered[0].AckID))
+	}
+	next, _ := broker.Pull(ctx, "orders", 2, 0)
+	if assert.Len(t, next, 1) {
+		assert.EqualValues(t, "a2", next[0].Data)
+	}
+}
+
+func TestMemoryBroker_DeleteTopic(t *testing.T) {
+	broker := newMemoryBroker()
+	broker.CreateTopic("events")
+	for _, name := range []string{"detached", "recreated"} {
+		if !assert.Nil(t, broker.CreateQueue(ResourceTypeSubscription, name, "events", 0)) {
+			return
+		}
+	}
+	assert.Nil(t, broker.Delete(ResourceTypeTopic, "events"))
+	broker.CreateTopic("events")
+	assert.Nil(t, broker.CreateQueue(ResourceTypeSubscription, "detached", "events", 0), "detached subscription is re-attached")
+	assert.Nil(t, broker.Delete(ResourceTypeSubscription, "recreated"), "detached subscription can be deleted")
+	assert.Nil(t, broker.CreateQueue(ResourceTypeSubscription, "recreated", "events", 0))
+
+	_, _ = broker.Publish(ResourceTypeTopic, "events", "e1", nil)
+	for _, name := range []string{"detached", "recreated"} {
+		messages, _ := broker.Pull(gocontext.Background(), name, 1, 0)
+		if assert.Len(t, messages, 1, name) {
+			assert.EqualValues(t, "e1", messages[0].Data, name)
+		}
+	}
+}
+
+func TestService_MemServe(t *testing.T) {
+	manager := endly.New()
+	context := manager.NewContext(toolbox.NewContext())
+	defer context.Close()
+	var response = &ServeResponse{}
+	if !assert.Nil(t, endly.Run(context, &ServeRequest{Port: 8971}, response)) {
+		return
+	}
+	call := func(method, path string, body interface{}, result interface{}) int {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		request, _ := http.NewRequest(method, response.URL+path, bytes.NewReader(payload))
+		httpResponse, err := http.DefaultClient.Do(request)
+		if !assert.Nil(t, err) {
+			return 0
+		}
+		defer httpResponse.Body.Close()
+		if result != nil {
+			_ = json.NewDecoder(httpResponse.Body).Decode(result)
+		}
+		return httpResponse.StatusCode
+	}
+
+	assert.EqualValues(t, http.StatusNoContent, call("PUT", "/topics/httpTopic", nil, nil))
+	assert.EqualValues(t, http.StatusNoContent, call("PUT", "/subscriptions/httpSub", map[string]interface{}{"topic": "httpTopic"}, nil))
+	assert.EqualValues(t, http.StatusNotFound, call("PUT", "/subscriptions/orphan", map[string]interface{}{"topic": "missing"}, nil))
+	assert.EqualValues(t, http.StatusOK, call("POST", "/topics/httpTopic/messages", []interface{}{
+		map[string]interface{}{"data": "hello", "attributes": map[string]string{"attr1": "abc"}},
+		map[string]interface{}{"data": map[string]interface{}{"id": 1}},
+	}, nil))
+
+	var deliveries = make([]*struct {
+		Data       string
+		Attributes map[string]string
+		AckID      string
+	}, 0)
+	assert.EqualValues(t, http.StatusOK, call("GET", "/subscriptions/httpSub/messages?max=10", nil, &deliveries))
+	if assert.Len(t, deliveries, 2) {
+		assert.EqualValues(t, "hello", deliveries[0].Data)
+		assert.EqualValues(t, "abc", deliveries[0].Attributes["attr1"])
+		assert.EqualValues(t, `{"id":1}`, deliveries[1].Data)
+		assert.EqualValues(t, http.StatusNoContent, call("POST", "/subscriptions/httpSub/ack", map[string]interface{}{"ackIds": []string{deliveries[0].AckID}}, nil))
+		assert.EqualValues(t, http.StatusNoContent, call("POST", "/subscriptions/httpSub/nack", map[string]interface{}{"ackIds": []string{deliveries[1].AckID}}, nil))
+	}
+
+	var pullResponse = &PullResponse{}
+	err := endly.Run(nil, &PullRequest{Source: &Resource{URL: "mem://httpSub"}, Count: 1, TimeoutMs: 200}, pullResponse)
+	if assert.Nil(t, err) && assert.Len(t, pullResponse.Messages, 1) {
+		assert.EqualValues(t, map[string]interface{}{"id": float64(1)}, pullResponse.Messages[0].Transformed)
+	}
+}
@@ -0,0 +1,233 @@
+package msg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/viant/scy/cred"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// subjectsAttribute represents comma separated stream subjects config attribute, resource name by default
+	subjectsAttribute = "subjects"
+	// filterSubjectAttribute represents subscription consumer filter subject config attribute
+	filterSubjectAttribute = "filterSubject"
+)
+
+// natsClient represents NATS JetStream client, topic and queue are streams, subscription is a durable pull consumer
+type natsClient struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	timeout time.Duration
+}
+
+func (c *natsClient) Push(ctx context.Context, dest *Resource, message *Message) (Result, error) {
+	data, err := messagePayload(message.Data)
+	if err != nil {
+		return nil, err
+	}
+	msg := nats.NewMsg(dest.Name)
+	msg.Data = []byte(data)
+	for k, v := range textAttributes(message.Attributes) {
+		msg.Header.Set(k, v)
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	ack, err := c.js.PublishMsg(ctx, msg)
+	if errors.Is(err, jetstream.ErrNoStreamResponse) { //no stream captures subject, publish to core subscribers
+		if err = c.conn.PublishMsg(msg); err == nil {
+			err = c.conn.FlushWithContext(ctx)
+		}
+		return "", err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish to %v, %w", dest.Name, err)
+	}
+	return strconv.FormatUint(ack.Sequence, 10), nil
+}
+
+func (c *natsClient) PullN(ctx context.Context, source *Resource, count int, nack bool) ([]*Message, error) {
+	if count <= 0 {
+		count = 1
+	}
+	consumer, err := c.consumer(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	var deadline = time.Now().Add(c.timeout)
+	var result = make([]*Message, 0)
+	var pulled = make([]jetstream.Msg, 0)
+	for len(result) < count {
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			break
+		}
+		batch, err := consumer.Fetch(count-len(result), jetstream.FetchMaxWait(wait))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch from %v, %w", source.Name, err)
+		}
+		for msg := range batch.Messages() {
+			pulled = append(pulled, msg)
+			result = append(result, asNatsMessage(msg))
+		}
+		if err = batch.Error(); err != nil && !errors.Is(err, nats.ErrTimeout) {
+			return nil, fmt.Errorf("failed to fetch from %v, %w", source.Name, err)
+		}
+	}
+	for _, msg := range pulled {
+		if nack {
+			err = msg.Nak()
+		} else {
+			err = msg.Ack()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// consumer returns source consumer: GroupID consumer on source stream, otherwise consumer named as source in any stream
+func (c *natsClient) consumer(ctx context.Context, source *Resource) (jetstream.Consumer, error) {
+	if source.GroupID != "" {
+		return c.js.Consumer(ctx, source.Name, source.GroupID)
+	}
+	streams := c.js.ListStreams(ctx)
+	for info := range streams.Info() {
+		consumer, err := c.js.Consumer(ctx, info.Config.Name, source.Name)
+		if err == nil {
+			return consumer, nil
+		}
+		if !errors.Is(err, jetstream.ErrConsumerNotFound) {
+			return nil, err
+		}
+	}
+	if err := streams.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("consumer %v does not exist", source.Name)
+}
+
+func (c *natsClient) SetupResource(resource *ResourceSetup) (*Resource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if resource.Recreate {
+		if err := c.delete(ctx, &resource.Resource); err != nil && !isNatsNotFound(err) {
+			return nil, err
+		}
+	}
+	var attributes map[string]string
+	var ackDeadline time.Duration
+	if resource.Config != nil {
+		attributes = resource.Config.Attributes
+		ackDeadline = resource.Config.AckDeadline
+	}
+	switch resource.Type {
+	case ResourceTypeTopic, ResourceTypeQueue:
+		subjects := []string{resource.Name}
+		if value := attributes[subjectsAttribute]; value != "" {
+			subjects = strings.Split(value, ",")
+		}
+		config := jetstream.StreamConfig{Name: resource.Name, Subjects: subjects}
+		if resource.Config != nil && resource.Config.RetentionDuration > 0 {
+			config.MaxAge = resource.Config.RetentionDuration
+		}
+		if resource.Type == ResourceTypeQueue {
+			config.Retention = jetstream.WorkQueuePolicy
+		}
+		if _, err := c.js.CreateOrUpdateStream(ctx, config); err != nil {
+			return nil, fmt.Errorf("failed to create stream %v, %w", resource.Name, err)
+		}
+		if resource.Type == ResourceTypeQueue {
+			consumer := jetstream.ConsumerConfig{Durable: resource.Name, AckPolicy: jetstream.AckExplicitPolicy, AckWait: ackDeadline}
+			if _, err := c.js.CreateOrUpdateConsumer(ctx, resource.Name, consumer); err != nil {
+				return nil, fmt.Errorf("failed to create queue consumer %v, %w", resource.Name, err)
+			}
+		}
+	case ResourceTypeSubscription:
+		if resource.Config == nil || resource.Config.Topic == nil {
+			return nil, fmt.Errorf("subscription %v config.topic was empty", resource.Name)
+		}
+		consumer := jetstream.ConsumerConfig{
+			Durable:       resource.Name,
+			AckPolicy:     jetstream.AckExplicitPolicy,
+			AckWait:       ackDeadline,
+			DeliverPolicy: jetstream.DeliverNewPolicy,
+			FilterSubject: attributes[filterSubjectAttribute],
+		}
+		if _, err := c.js.CreateOrUpdateConsumer(ctx, resource.Config.Topic.Name, consumer); err != nil {
+			return nil, fmt.Errorf("failed to create consumer %v on %v, %w", resource.Name, resource.Config.Topic.Name, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported resource type: %v, %v", resource.Type, resource.Name)
+	}
+	return &resource.Resource, nil
+}
+
+func (c *natsClient) DeleteResource(resource *Resource) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	return c.delete(ctx, resource)
+}
+
+// delete deletes topic or queue stream, or subscription consumer
+func (c *natsClient) delete(ctx context.Context, resource *Resource) error {
+	if resource.Type != ResourceTypeSubscription {
+		return c.js.DeleteStream(ctx, resource.Name)
+	}
+	consumer, err := c.consumer(ctx, resource)
+	if err != nil {
+		return err
+	}
+	info := consumer.CachedInfo()
+	return c.js.DeleteConsumer(ctx, info.Stream, info.Name)
+}
+
+func (c *natsClient) Close() error {
+	c.conn.Close()
+	return nil
+}
+
+func isNatsNotFound(err error) bool {
+	return errors.Is(err, jetstream.ErrStreamNotFound) || errors.Is(err, jetstream.ErrConsumerNotFound) || strings.Contains(err.Error(), "does not exist")
+}
+
+// asNatsMessage returns pulled message, stream sequence is used as message ID, headers as attributes
+func asNatsMessage(msg jetstream.Msg) *Message {
+	var attributes = make(map[string]string)
+	for k := range msg.Headers() {
+		attributes[k] = msg.Headers().Get(k)
+	}
+	var ID string
+	if metadata, err := msg.Metadata(); err == nil {
+		ID = strconv.FormatUint(metadata.Sequence.Stream, 10)
+	}
+	result := newPulledMessage(ID, string(msg.Data()), attributes)
+	result.Subject = msg.Subject()
+	return result
+}
+
+func newNatsClient(credConfig *cred.Generic, resource *Resource, timeout time.Duration) (Client, error) {
+	URL, err := serverURL(resource, credConfig, ResourceVendorNATS)
+	if err != nil {
+		return nil, err
+	}
+	if URL.Port() == "" {
+		URL.Host += ":4222"
+	}
+	conn, err := nats.Connect(URL.String(), nats.Timeout(timeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %v, %w", URL.Redacted(), err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &natsClient{conn: conn, js: js, timeout: timeout}, nil
+}
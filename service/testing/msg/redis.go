@@ -0,0 +1,232 @@
+package msg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"github.com/viant/scy/cred"
+	"github.com/viant/toolbox"
+	"strings"
+	"time"
+)
+
+const (
+	// redisDataField represents stream entry field holding message data, other fields are message attributes
+	redisDataField = "data"
+	// redisConsumer represents consumer name used to read consumer group entries
+	redisConsumer = "endly"
+	// redisSetupGroup represents temporary consumer group used to create an empty stream
+	redisSetupGroup = "endly-setup"
+)
+
+// redisStreamClient represents Redis Streams client, topic and queue are streams, subscription is a topic stream consumer group,
+// queue is read with consumer group named after the stream
+type redisStreamClient struct {
+	client  *redis.Client
+	timeout time.Duration
+}
+
+func (c *redisStreamClient) Push(ctx context.Context, dest *Resource, message *Message) (Result, error) {
+	data, err := messagePayload(message.Data)
+	if err != nil {
+		return nil, err
+	}
+	var values = []interface{}{redisDataField, data}
+	for k, v := range textAttributes(message.Attributes) {
+		if k != redisDataField {
+			values = append(values, k, v)
+		}
+	}
+	ID, err := c.client.XAdd(ctx, &redis.XAddArgs{Stream: dest.Name, Values: values}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to add entry to %v, %w", dest.Name, err)
+	}
+	return ID, nil
+}
+
+func (c *redisStreamClient) PullN(ctx context.Context, source *Resource, count int, nack bool) ([]*Message, error) {
+	if count <= 0 {
+		count = 1
+	}
+	stream, group, err := c.consumerGroup(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	var result = make([]*Message, 0)
+	var IDs = make([]string, 0)
+	var deadline = time.Now().Add(c.timeout)
+	var offset = "0" //entries delivered but not acknowledged (i.e. nacked) are read first
+	for len(result) < count {
+		args := &redis.XReadGroupArgs{Group: group, Consumer: redisConsumer, Streams: []string{stream, offset}, Count: int64(count - len(result)), Block: -1}
+		if offset == ">" {
+			wait := time.Until(deadline)
+			if wait < time.Millisecond {
+				break
+			}
+			args.Block = wait
+		}
+		streams, err := c.client.XReadGroup(ctx, args).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("failed to read %v group %v, %w", stream, group, err)
+		}
+		var read int
+		for _, item := range streams {
+			for _, entry := range item.Messages {
+				read++
+				IDs = append(IDs, entry.ID)
+				result = append(result, asRedisMessage(&entry))
+			}
+		}
+		if offset == "0" && read < int(args.Count) {
+			offset = ">"
+		}
+	}
+	if !nack && len(IDs) > 0 {
+		if err = c.client.XAck(ctx, stream, group, IDs...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to ack %v group %v, %w", stream, group, err)
+		}
+	}
+	return result, nil
+}
+
+// consumerGroup returns source stream and consumer group: GroupID group on source stream, a group named after source stream,
+// otherwise a group named as source on any stream
+func (c *redisStreamClient) consumerGroup(ctx context.Context, source *Resource) (string, string, error) {
+	if source.GroupID != "" {
+		return source.Name, source.GroupID, nil
+	}
+	keyType, err := c.client.Type(ctx, source.Name).Result()
+	if err != nil {
+		return "", "", err
+	}
+	if keyType == "stream" {
+		if err = c.createGroup(ctx, source.Name, source.Name, "0"); err != nil {
+			return "", "", err
+		}
+		return source.Name, source.Name, nil
+	}
+	stream, err := c.groupStream(ctx, source.Name)
+	if err != nil {
+		return "", "", err
+	}
+	return stream, source.Name, nil
+}
+
+// groupStream returns stream with consumer group
+func (c *redisStreamClient) groupStream(ctx context.Context, group string) (string, error) {
+	var cursor uint64
+	for {
+		keys, next, err := c.client.ScanType(ctx, cursor, "*", 100, "stream").Result()
+		if err != nil {
+			return "", err
+		}
+		for _, key := range keys {
+			groups, err := c.client.XInfoGroups(ctx, key).Result()
+			if err != nil {
+				return "", err
+			}
+			for _, info := range groups {
+				if info.Name == group {
+					return key, nil
+				}
+			}
+		}
+		if cursor = next; cursor == 0 {
+			return "", fmt.Errorf("stream or consumer group %v does not exist", group)
+		}
+	}
+}
+
+// createGroup creates stream consumer group starting at supplied ID if it does not exist, missing stream is created
+func (c *redisStreamClient) createGroup(ctx context.Context, stream, group, start string) error {
+	err := c.client.XGroupCreateMkStream(ctx, stream, group, start).Err()
+	if err != nil && strings.Contains(err.Error(), "BUSYGROUP") {
+		return nil
+	}
+	return err
+}
+
+func (c *redisStreamClient) SetupResource(resource *ResourceSetup) (*Resource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	if resource.Recreate {
+		if err := c.delete(ctx, &resource.Resource); err != nil && !strings.Contains(err.Error(), "does not exist") {
+			return nil, err
+		}
+	}
+	var err error
+	switch resource.Type {
+	case ResourceTypeTopic:
+		//stream is created with a temporary group, so no placeholder entry is added
+		if err = c.createGroup(ctx, resource.Name, redisSetupGroup, "$"); err == nil {
+			err = c.client.XGroupDestroy(ctx, resource.Name, redisSetupGroup).Err()
+		}
+	case ResourceTypeQueue:
+		err = c.createGroup(ctx, resource.Name, resource.Name, "0")
+	case ResourceTypeSubscription:
+		if resource.Config == nil || resource.Config.Topic == nil {
+			return nil, fmt.Errorf("subscription %v config.topic was empty", resource.Name)
+		}
+		err = c.createGroup(ctx, resource.Config.Topic.Name, resource.Name, "$")
+	default:
+		return nil, fmt.Errorf("unsupported resource type: %v, %v", resource.Type, resource.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup %v %v, %w", resource.Type, resource.Name, err)
+	}
+	return &resource.Resource, nil
+}
+
+func (c *redisStreamClient) DeleteResource(resource *Resource) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+	return c.delete(ctx, resource)
+}
+
+// delete deletes topic or queue stream, or subscription consumer group
+func (c *redisStreamClient) delete(ctx context.Context, resource *Resource) error {
+	if resource.Type != ResourceTypeSubscription {
+		return c.client.Del(ctx, resource.Name).Err()
+	}
+	stream, err := c.groupStream(ctx, resource.Name)
+	if err != nil {
+		return err
+	}
+	return c.client.XGroupDestroy(ctx, stream, resource.Name).Err()
+}
+
+func (c *redisStreamClient) Close() error {
+	return c.client.Close()
+}
+
+// asRedisMessage returns pulled message, entry fields other than data are attributes, entry without data field is returned as JSON
+func asRedisMessage(entry *redis.XMessage) *Message {
+	var attributes = make(map[string]string)
+	for k, v := range entry.Values {
+		if k != redisDataField {
+			attributes[k] = toolbox.AsString(v)
+		}
+	}
+	data, ok := entry.Values[redisDataField]
+	if !ok {
+		data, _ = messagePayload(entry.Values)
+	}
+	return newPulledMessage(entry.ID, toolbox.AsString(data), attributes)
+}
+
+func newRedisStreamClient(credConfig *cred.Generic, resource *Resource, timeout time.Duration) (Client, error) {
+	URL, err := serverURL(resource, credConfig, ResourceVendorRedis)
+	if err != nil {
+		return nil, err
+	}
+	if URL.Port() == "" {
+		URL.Host += ":6379"
+	}
+	options, err := redis.ParseURL(URL.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %v, %w", URL.Redacted(), err)
+	}
+	options.DialTimeout = timeout
+	return &redisStreamClient{client: redis.NewClient(options), timeout: timeout}, nil
+}
@@ -0,0 +1,89 @@
+package msg
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+)
+
+func TestService_RedisPushPull(t *testing.T) {
+	server := miniredis.RunT(t)
+	URL := func(name string) string {
+		return "redis://" + server.Addr() + "/" + name
+	}
+	var resources = []*ResourceSetup{
+		{Resource: Resource{URL: URL("events"), Type: ResourceTypeTopic}, Recreate: true},
+		{Resource: Resource{URL: URL("eventSub1"), Type: ResourceTypeSubscription}, Recreate: true, Config: NewConfig("events")},
+		{Resource: Resource{URL: URL("eventSub2"), Type: ResourceTypeSubscription}, Recreate: true, Config: NewConfig("events")},
+		{Resource: Resource{URL: URL("jobs"), Type: ResourceTypeQueue}, Recreate: true},
+	}
+	if !createResources(t, resources...) {
+		return
+	}
+
+	push := func(dest string, messages ...*Message) {
+		err := endly.Run(nil, &PushRequest{Dest: &Resource{URL: URL(dest)}, Messages: messages}, &PushResponse{})
+		assert.Nil(t, err)
+	}
+	pull := func(source string, count int, nack bool) []*Message {
+		var response = &PullResponse{}
+		err := endly.Run(nil, &PullRequest{Source: &Resource{URL: URL(source)}, Count: count, Nack: nack, TimeoutMs: 200}, response)
+		assert.Nil(t, err)
+		return response.Messages
+	}
+
+	push("events", &Message{Data: "m1", Attributes: map[string]interface{}{"attr1": "abc"}}, &Message{Data: map[string]interface{}{"k": 1}})
+	push("jobs", &Message{Data: "q1"}, &Message{Data: "q2"})
+	server.XAdd("jobs", "*", []string{"status", "processed"})
+
+	var useCases = []struct {
+		description string
+		source      string
+		count       int
+		nack        bool
+		expect      []interface{}
+	}{
+		{description: "fan-out nack", source: "eventSub1", count: 1, nack: true, expect: []interface{}{"m1"}},
+		{description: "nacked redelivered", source: "eventSub1", count: 2, expect: []interface{}{"m1", `{"k":1}`}},
+		{description: "acked", source: "eventSub1", count: 1, expect: []interface{}{}},
+		{description: "fan-out second subscription", source: "eventSub2", count: 2, expect: []interface{}{"m1", `{"k":1}`}},
+		{description: "queue", source: "jobs", count: 4, expect: []interface{}{"q1", "q2", `{"status":"processed"}`}},
+	}
+	for _, useCase := range useCases {
+		var actual = make([]interface{}, 0)
+		for _, message := range pull(useCase.source, useCase.count, useCase.nack) {
+			actual = append(actual, message.Data)
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+
+	push("events", &Message{Data: "m2", Attributes: map[string]interface{}{"attr1": "xyz"}})
+	if messages := pull("eventSub2", 1, false); assert.Len(t, messages, 1) {
+		assert.EqualValues(t, "xyz", messages[0].Attributes["attr1"])
+		assert.NotEmpty(t, messages[0].ID)
+	}
+
+	assert.Nil(t, endly.Run(nil, &DeleteRequest{Resources: []*Resource{{URL: URL("eventSub1"), Type: ResourceTypeSubscription}}}, &DeleteResponse{}))
+	assert.NotNil(t, endly.Run(nil, &PullRequest{Source: &Resource{URL: URL("eventSub1")}, Count: 1, TimeoutMs: 100}, &PullResponse{}))
+	deleteResource(t, resources[0], resources[3])
+	assert.False(t, server.Exists("events"))
+}
+
+func TestInferVendorFromURL(t *testing.T) {
+	var useCases = []struct {
+		URL    string
+		expect string
+	}{
+		{URL: "nats://localhost:4222/orders", expect: ResourceVendorNATS},
+		{URL: "amqps://rabbit:5671/orders", expect: ResourceVendorAMQP},
+		{URL: "redis://localhost:6379/orders", expect: ResourceVendorRedis},
+		{URL: "mem://orders", expect: ResourceVendorMemory},
+		{URL: "tcp://localhost:9092/orders", expect: ""},
+		{URL: "orders", expect: ""},
+	}
+	for _, useCase := range useCases {
+		assert.EqualValues(t, useCase.expect, inferVendorFromURL(useCase.URL), useCase.URL)
+	}
+}
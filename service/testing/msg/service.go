@@ -93,6 +93,53 @@ func (s *service) registerRoutes() {
 			return nil, fmt.Errorf("unsupported request type: %T", request)
 		},
 	})
+	s.Register(&endly.Route{
+		Action: "serve",
+		RequestInfo: &endly.ActionInfo{
+			Description: "expose in-memory mem vendor broker over HTTP port",
+		},
+		RequestProvider: func() interface{} {
+			return &ServeRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &ServeResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*ServeRequest); ok {
+				return s.serve(context, req)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+	s.Register(&endly.Route{
+		Action: "shutdown",
+		RequestInfo: &endly.ActionInfo{
+			Description: "stop exposing in-memory mem vendor broker",
+		},
+		RequestProvider: func() interface{} {
+			return &ShutdownRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &ShutdownResponse{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			if req, ok := request.(*ShutdownRequest); ok {
+				return &ShutdownResponse{}, stopMemServer(req.Port)
+			}
+			return nil, fmt.Errorf("unsupported request type: %T", request)
+		},
+	})
+}
+
+func (s *service) serve(context *endly.Context, request *ServeRequest) (*ServeResponse, error) {
+	URL, err := startMemServer(request.Port)
+	if err != nil {
+		return nil, err
+	}
+	context.Deffer(func() {
+		_ = stopMemServer(request.Port)
+	})
+	return &ServeResponse{URL: URL}, nil
 }
 
 func (s *service) push(context *endly.Context, request *PushRequest) (interface{}, error) {
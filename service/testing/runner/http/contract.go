@@ -6,6 +6,12 @@ import (
 	"github.com/viant/endly/service/testing/validator"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
+	"math"
+)
+
+const (
+	defaultLatencyMs = 200
+	maxThreadCount   = 2000
 )
 
 // SendRequest represents a send http request.
@@ -108,15 +114,42 @@ func NewSendResponseFromURL(URL string) (*SendResponse, error) {
 // LoadRequest represents a send http request.
 type LoadRequest struct {
 	*SendRequest
-	ThreadCount int    `description:"defines number of http client sending request concurrently, default 3"`
-	Repeat      int    `description:"defines how many times repeat individual request, default 1"`
-	AssertMod   int    `description:"defines modulo for assertion on repeated request (make sure you have enough memory)"`
-	Message     string `description:"reporting message during stress test, the following is available: $load.[QPS|Count|Elapsed|Timeouts|Errors|Error]"`
+	ThreadCount int          `description:"defines number of http client sending request concurrently, default 3, for open model: peak rate * latencyMs"`
+	Repeat      int          `description:"defines how many times repeat individual request, default 1"`
+	AssertMod   int          `description:"defines modulo for assertion on repeated request (make sure you have enough memory)"`
+	Message     string       `description:"reporting message during stress test, the following is available: $load.[QPS|Count|Elapsed|Timeouts|Errors|Error]"`
+	TimeSeries  string       `description:"optional per second time series output URL, .csv or .json, with throughput, errors and latency"`
+	DurationSec float64      `description:"run duration, when specified requests are sent round-robin until it elapses instead of Repeat times"`
+	Rate        float64      `description:"target requests per second for DurationSec, open model: requests are sent at constant arrival rate regardless of responses, latency is measured from scheduled time"`
+	Stages      []*LoadStage `description:"open model load profile stages, i.e. ramp-up followed by steady rate"`
+	LatencyMs   int          `description:"open model expected response time used to size threadCount, default 200"`
+	thresholds  []*Threshold
+}
+
+// hasProfile returns true if load is defined by duration or arrival rate instead of repeat count
+func (r *LoadRequest) hasProfile() bool {
+	return r.DurationSec > 0 || r.Rate > 0 || len(r.Stages) > 0
+}
+
+// peakRate returns open model max target requests per second
+func (r *LoadRequest) peakRate() float64 {
+	result := r.Rate
+	for _, stage := range r.Stages {
+		result = math.Max(result, stage.Rate)
+	}
+	return result
 }
 
 func (r *LoadRequest) Init() error {
+	if r.LatencyMs == 0 {
+		r.LatencyMs = defaultLatencyMs
+	}
 	if r.ThreadCount == 0 {
 		r.ThreadCount = 3
+		//open model needs enough clients to keep up with arrival rate (Little's law)
+		if required := int(math.Ceil(r.peakRate() * float64(r.LatencyMs) / 1000)); required > r.ThreadCount {
+			r.ThreadCount = int(math.Min(float64(required), maxThreadCount))
+		}
 	}
 	if r.Repeat == 0 {
 		r.Repeat = 1
@@ -160,8 +193,29 @@ func (r *LoadRequest) Validate() error {
 			return fmt.Errorf("scraping data is not supported in stress test mode")
 		}
 	}
-
-	return nil
+	if r.Rate < 0 {
+		return fmt.Errorf("invalid rate: %v", r.Rate)
+	}
+	if len(r.Stages) > 0 {
+		if r.Rate > 0 || r.DurationSec > 0 {
+			return fmt.Errorf("rate and durationSec can not be used with stages")
+		}
+		for _, stage := range r.Stages {
+			if err := stage.Validate(); err != nil {
+				return err
+			}
+		}
+	} else if r.Rate > 0 && r.DurationSec <= 0 {
+		return fmt.Errorf("durationSec was empty, required with rate")
+	}
+	if r.TimeSeries != "" {
+		if err := validateTimeSeriesURL(r.TimeSeries); err != nil {
+			return err
+		}
+	}
+	var err error
+	r.thresholds, err = parseThresholds(r.Expect)
+	return err
 }
 
 // LoadRequest represents a stress test response
@@ -178,4 +232,8 @@ type LoadResponse struct {
 	MinResponseTimeInMs float64
 	AvgResponseTimeInMs float64
 	MaxResponseTimeInMs float64
+	ErrorRate           float64             `description:"percentage of failed and timed out requests"`
+	Latency             *Latency            `description:"response time percentiles for all requests"`
+	RequestLatency      []*Latency          `description:"response time percentiles for each request"`
+	TimeSeries          []*TimeSeriesBucket `description:"per second throughput, errors and latency"`
 }
@@ -0,0 +1,181 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/HdrHistogram/hdrhistogram-go"
+	"github.com/viant/afs"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	minTrackableLatency = 1                                   //1 microsecond
+	maxTrackableLatency = int64(time.Hour / time.Microsecond) //1 hour in microseconds
+	significantFigures  = 3
+)
+
+// Latency represents response time distribution in ms
+type Latency struct {
+	Index  int    `json:",omitempty" description:"request index"`
+	Method string `json:",omitempty"`
+	URL    string `json:",omitempty"`
+	Count  int64
+	MinMs  float64
+	AvgMs  float64
+	MaxMs  float64
+	P50Ms  float64
+	P90Ms  float64
+	P95Ms  float64
+	P99Ms  float64
+	P999Ms float64
+}
+
+// TimeSeriesBucket represents one second load test bucket
+type TimeSeriesBucket struct {
+	Second   int
+	Time     time.Time
+	Count    int
+	Errors   int
+	Timeouts int
+	MinMs    float64
+	AvgMs    float64
+	MaxMs    float64
+	P50Ms    float64
+	P90Ms    float64
+	P99Ms    float64
+}
+
+var timeSeriesHeader = []string{"second", "time", "count", "errors", "timeouts", "minMs", "avgMs", "maxMs", "p50Ms", "p90Ms", "p99Ms"}
+
+func newHistogram() *hdrhistogram.Histogram {
+	return hdrhistogram.New(minTrackableLatency, maxTrackableLatency, significantFigures)
+}
+
+func recordLatency(histogram *hdrhistogram.Histogram, elapsed time.Duration) {
+	value := int64(elapsed / time.Microsecond)
+	if value < minTrackableLatency {
+		value = minTrackableLatency
+	} else if value > maxTrackableLatency {
+		value = maxTrackableLatency
+	}
+	_ = histogram.RecordValue(value)
+}
+
+func asMs(microseconds float64) float64 {
+	return microseconds / float64(time.Millisecond/time.Microsecond)
+}
+
+func newLatency(histogram *hdrhistogram.Histogram) *Latency {
+	result := &Latency{Count: histogram.TotalCount()}
+	if result.Count == 0 {
+		return result
+	}
+	result.MinMs = asMs(float64(histogram.Min()))
+	result.AvgMs = asMs(histogram.Mean())
+	result.MaxMs = asMs(float64(histogram.Max()))
+	result.P50Ms = asMs(float64(histogram.ValueAtQuantile(50)))
+	result.P90Ms = asMs(float64(histogram.ValueAtQuantile(90)))
+	result.P95Ms = asMs(float64(histogram.ValueAtQuantile(95)))
+	result.P99Ms = asMs(float64(histogram.ValueAtQuantile(99)))
+	result.P999Ms = asMs(float64(histogram.ValueAtQuantile(99.9)))
+	return result
+}
+
+// collectLatency computes overall, per request and per second response time distribution, failed and timed out trips are excluded from latency
+func collectLatency(trips []*stressTestTrip, request *LoadRequest, response *LoadResponse, startTime time.Time) {
+	overall := newHistogram()
+	var requests = make([]*hdrhistogram.Histogram, len(request.Requests))
+	var seconds = make([]*hdrhistogram.Histogram, 0)
+	var buckets = make([]*TimeSeriesBucket, 0)
+	for _, trip := range trips {
+		second := int(trip.requestTime.Sub(startTime) / time.Second)
+		for len(buckets) <= second {
+			buckets = append(buckets, &TimeSeriesBucket{Second: len(buckets), Time: startTime.Add(time.Duration(len(buckets)) * time.Second)})
+			seconds = append(seconds, newHistogram())
+		}
+		bucket := buckets[second]
+		bucket.Count++
+		if trip.timeout {
+			bucket.Timeouts++
+			continue
+		}
+		if trip.err != nil {
+			bucket.Errors++
+			continue
+		}
+		recordLatency(overall, trip.elapsed)
+		recordLatency(seconds[second], trip.elapsed)
+		if trip.index < len(requests) {
+			if requests[trip.index] == nil {
+				requests[trip.index] = newHistogram()
+			}
+			recordLatency(requests[trip.index], trip.elapsed)
+		}
+	}
+	response.Latency = newLatency(overall)
+	response.RequestLatency = make([]*Latency, 0, len(requests))
+	for i, histogram := range requests {
+		if histogram == nil {
+			continue
+		}
+		latency := newLatency(histogram)
+		latency.Index = i
+		latency.Method = request.Requests[i].Method
+		latency.URL = request.Requests[i].URL
+		response.RequestLatency = append(response.RequestLatency, latency)
+	}
+	for i, bucket := range buckets {
+		latency := newLatency(seconds[i])
+		bucket.MinMs, bucket.AvgMs, bucket.MaxMs = latency.MinMs, latency.AvgMs, latency.MaxMs
+		bucket.P50Ms, bucket.P90Ms, bucket.P99Ms = latency.P50Ms, latency.P90Ms, latency.P99Ms
+	}
+	response.TimeSeries = buckets
+}
+
+// validateTimeSeriesURL returns an error if time series URL has no .csv or .json extension
+func validateTimeSeriesURL(URL string) error {
+	switch strings.ToLower(path.Ext(URL)) {
+	case ".json", ".csv":
+		return nil
+	}
+	return fmt.Errorf("unsupported time series format: %v, supported: .csv, .json", URL)
+}
+
+// writeTimeSeries writes time series buckets to supplied URL, format is inferred from .csv or .json extension
+func writeTimeSeries(ctx context.Context, URL string, buckets []*TimeSeriesBucket) error {
+	if err := validateTimeSeriesURL(URL); err != nil {
+		return err
+	}
+	buffer := new(bytes.Buffer)
+	switch strings.ToLower(path.Ext(URL)) {
+	case ".json":
+		encoder := json.NewEncoder(buffer)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(buckets); err != nil {
+			return err
+		}
+	case ".csv":
+		writer := csv.NewWriter(buffer)
+		_ = writer.Write(timeSeriesHeader)
+		for _, bucket := range buckets {
+			_ = writer.Write([]string{
+				fmt.Sprint(bucket.Second), bucket.Time.Format(time.RFC3339), fmt.Sprint(bucket.Count), fmt.Sprint(bucket.Errors), fmt.Sprint(bucket.Timeouts),
+				formatMs(bucket.MinMs), formatMs(bucket.AvgMs), formatMs(bucket.MaxMs), formatMs(bucket.P50Ms), formatMs(bucket.P90Ms), formatMs(bucket.P99Ms),
+			})
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+	}
+	return afs.New().Upload(ctx, URL, 0644, buffer)
+}
+
+func formatMs(value float64) string {
+	return fmt.Sprintf("%.3f", value)
+}
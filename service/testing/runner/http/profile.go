@@ -0,0 +1,163 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/viant/endly"
+)
+
+const (
+	rampLinear = "linear"
+	rampStep   = "step"
+)
+
+// LoadStage represents load profile stage, i.e. 60 sec linear ramp-up to 2000 RPS followed by 300 sec at 2000 RPS
+type LoadStage struct {
+	DurationSec float64 `description:"stage duration"`
+	Rate        float64 `description:"target requests per second"`
+	Ramp        string  `description:"linear (default): rate changes gradually from previous stage rate to target rate, step: target rate applies from stage start"`
+}
+
+// Validate checks if stage is valid
+func (s *LoadStage) Validate() error {
+	if s.DurationSec <= 0 {
+		return fmt.Errorf("stage durationSec was empty")
+	}
+	if s.Rate < 0 {
+		return fmt.Errorf("invalid stage rate: %v", s.Rate)
+	}
+	switch strings.ToLower(s.Ramp) {
+	case "", rampLinear, rampStep:
+	default:
+		return fmt.Errorf("unsupported stage ramp: %v, supported: %v, %v", s.Ramp, rampLinear, rampStep)
+	}
+	return nil
+}
+
+// profileSegment represents stage with resolved start offset, rates and request count
+type profileSegment struct {
+	start     time.Duration
+	duration  time.Duration
+	fromRate  float64
+	toRate    float64
+	fromCount float64
+	count     float64
+}
+
+// offset returns offset from segment start when cumulative request count reaches n
+func (s *profileSegment) offset(n float64) time.Duration {
+	seconds := s.duration.Seconds()
+	acceleration := (s.toRate - s.fromRate) / (2 * seconds)
+	var result float64
+	if math.Abs(acceleration) < 1e-9 {
+		result = n / s.fromRate
+	} else {
+		discriminant := math.Max(0, s.fromRate*s.fromRate+4*acceleration*n)
+		result = (-s.fromRate + math.Sqrt(discriminant)) / (2 * acceleration)
+	}
+	return time.Duration(result * float64(time.Second))
+}
+
+// loadProfile represents open model (constant arrival rate) or duration based closed model load
+type loadProfile struct {
+	duration time.Duration
+	segments []*profileSegment
+}
+
+// isOpen returns true if requests are sent at scheduled arrival time regardless of responses
+func (p *loadProfile) isOpen() bool {
+	return len(p.segments) > 0
+}
+
+// arrival returns n-th (zero based) request scheduled offset from load start, false if profile is complete
+func (p *loadProfile) arrival(n int) (time.Duration, bool) {
+	target := float64(n)
+	for _, segment := range p.segments {
+		if segment.count == 0 || target >= segment.fromCount+segment.count {
+			continue
+		}
+		return segment.start + segment.offset(target-segment.fromCount), true
+	}
+	return 0, false
+}
+
+func newLoadProfile(request *LoadRequest) *loadProfile {
+	result := &loadProfile{}
+	stages := request.Stages
+	if len(stages) == 0 && request.Rate > 0 {
+		stages = []*LoadStage{{DurationSec: request.DurationSec, Rate: request.Rate, Ramp: rampStep}}
+	}
+	var rate, count float64
+	for _, stage := range stages {
+		segment := &profileSegment{
+			start:     result.duration,
+			duration:  time.Duration(stage.DurationSec * float64(time.Second)),
+			fromRate:  rate,
+			toRate:    stage.Rate,
+			fromCount: count,
+		}
+		if strings.ToLower(stage.Ramp) == rampStep {
+			segment.fromRate = stage.Rate
+		}
+		segment.count = (segment.fromRate + segment.toRate) / 2 * stage.DurationSec
+		result.segments = append(result.segments, segment)
+		result.duration += segment.duration
+		rate = stage.Rate
+		count += segment.count
+	}
+	if len(result.segments) == 0 {
+		result.duration = time.Duration(request.DurationSec * float64(time.Second))
+	}
+	return result
+}
+
+// generateStressTestTrips sends requests round-robin following load profile, trips are built as they are sent
+func generateStressTestTrips(context *endly.Context, request *LoadRequest, profile *loadProfile, sendChannel chan *stressTestTrip, waitGroup *sync.WaitGroup) ([]*stressTestTrip, error) {
+	var sessionCookies = []*http.Cookie{}
+	var trips = make([]*stressTestTrip, 0)
+	var expectedCount = expectedResponseCount(request)
+	var state = context.State()
+	for _, req := range request.Requests {
+		req.Expand(state)
+	}
+	var repeated = make([]int, len(request.Requests))
+	startTime := time.Now()
+	for n := 0; !context.IsClosed(); n++ {
+		var scheduled time.Time
+		if profile.isOpen() {
+			offset, ok := profile.arrival(n)
+			if !ok {
+				break
+			}
+			scheduled = startTime.Add(offset)
+			if wait := time.Until(scheduled); wait > 0 {
+				time.Sleep(wait)
+			}
+		} else if time.Since(startTime) >= profile.duration {
+			break
+		}
+		index := n % len(request.Requests)
+		trip := &stressTestTrip{
+			waitGroup:     waitGroup,
+			index:         index,
+			scheduledTime: scheduled,
+		}
+		if repeated[index]%request.AssertMod == 0 && index < expectedCount {
+			trip.expected = true
+		}
+		repeated[index]++
+		var err error
+		if trip.request, trip.expectBinary, err = request.Requests[index].Build(context, sessionCookies); err != nil {
+			return nil, err
+		}
+		trips = append(trips, trip)
+		waitGroup.Add(1)
+		sendChannel <- trip
+	}
+	return trips, nil
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+)
+
+func TestLoadProfile_Arrival(t *testing.T) {
+	var useCases = []struct {
+		description string
+		request     *LoadRequest
+		arrivals    map[int]time.Duration
+		completeAt  int
+	}{
+		{
+			description: "constant arrival rate",
+			request:     &LoadRequest{Rate: 100, DurationSec: 2},
+			arrivals:    map[int]time.Duration{0: 0, 1: 10 * time.Millisecond, 100: time.Second, 199: 1990 * time.Millisecond},
+			completeAt:  200,
+		},
+		{
+			description: "linear ramp-up followed by steady rate",
+			request:     &LoadRequest{Stages: []*LoadStage{{DurationSec: 2, Rate: 100}, {DurationSec: 1, Rate: 100}}},
+			arrivals:    map[int]time.Duration{50: 1414213562 * time.Nanosecond, 100: 2 * time.Second, 150: 2500 * time.Millisecond},
+			completeAt:  200,
+		},
+		{
+			description: "step stages with pause",
+			request:     &LoadRequest{Stages: []*LoadStage{{DurationSec: 1, Rate: 10, Ramp: "step"}, {DurationSec: 5, Rate: 0, Ramp: "step"}, {DurationSec: 1, Rate: 20, Ramp: "step"}}},
+			arrivals:    map[int]time.Duration{9: 900 * time.Millisecond, 10: 6 * time.Second, 20: 6500 * time.Millisecond},
+			completeAt:  30,
+		},
+	}
+
+	for _, useCase := range useCases {
+		useCase.request.SendRequest = &SendRequest{Requests: []*Request{{Method: "GET", URL: "http://127.0.0.1/"}}}
+		assert.Nil(t, useCase.request.Init(), useCase.description)
+		assert.Nil(t, useCase.request.Validate(), useCase.description)
+		profile := newLoadProfile(useCase.request)
+		assert.True(t, profile.isOpen(), useCase.description)
+		for n, expected := range useCase.arrivals {
+			actual, ok := profile.arrival(n)
+			assert.True(t, ok, useCase.description)
+			assert.InDelta(t, float64(expected), float64(actual), float64(time.Millisecond), useCase.description)
+		}
+		_, ok := profile.arrival(useCase.completeAt)
+		assert.False(t, ok, useCase.description)
+	}
+
+	profile := newLoadProfile(&LoadRequest{DurationSec: 3})
+	assert.False(t, profile.isOpen())
+	assert.EqualValues(t, 3*time.Second, profile.duration)
+}
+
+func TestLoadRequest_ThreadCount(t *testing.T) {
+	var useCases = []struct {
+		description string
+		request     *LoadRequest
+		expect      int
+	}{
+		{description: "closed model default", request: &LoadRequest{DurationSec: 1}, expect: 3},
+		{description: "low rate default", request: &LoadRequest{Rate: 10, DurationSec: 1}, expect: 3},
+		{description: "rate sized", request: &LoadRequest{Rate: 100, DurationSec: 1}, expect: 20},
+		{description: "peak stage rate sized", request: &LoadRequest{Stages: []*LoadStage{{DurationSec: 1, Rate: 2000}, {DurationSec: 1, Rate: 500}}, LatencyMs: 50}, expect: 100},
+		{description: "sized up to limit", request: &LoadRequest{Rate: 100000, DurationSec: 1}, expect: maxThreadCount},
+		{description: "explicit", request: &LoadRequest{Rate: 100, DurationSec: 1, ThreadCount: 5}, expect: 5},
+		{description: "explicit over auto sizing limit", request: &LoadRequest{Rate: 100, DurationSec: 1, ThreadCount: maxThreadCount + 1}, expect: maxThreadCount + 1},
+	}
+	for _, useCase := range useCases {
+		useCase.request.SendRequest = &SendRequest{Requests: []*Request{{Method: "GET", URL: "http://127.0.0.1/"}}}
+		assert.Nil(t, useCase.request.Init(), useCase.description)
+		assert.EqualValues(t, useCase.expect, useCase.request.ThreadCount, useCase.description)
+		assert.Nil(t, useCase.request.Validate(), useCase.description)
+	}
+}
+
+func TestGenerateStressTestTrips(t *testing.T) {
+	request := &LoadRequest{
+		SendRequest: &SendRequest{Requests: []*Request{{Method: "GET", URL: "http://127.0.0.1/a"}, {Method: "GET", URL: "http://127.0.0.1/b"}}},
+		Stages:      []*LoadStage{{DurationSec: 0.5, Rate: 40}, {DurationSec: 0.25, Rate: 40}},
+	}
+	if !assert.Nil(t, request.Init()) || !assert.Nil(t, request.Validate()) {
+		return
+	}
+	profile := newLoadProfile(request)
+	context := endly.New().NewContext(toolbox.NewContext())
+	defer context.Close()
+	sendChannel := make(chan *stressTestTrip, 1)
+	waitGroup := &sync.WaitGroup{}
+	go func() {
+		for trip := range sendChannel {
+			trip.waitGroup.Done()
+		}
+	}()
+	trips, err := generateStressTestTrips(context, request, profile, sendChannel, waitGroup)
+	close(sendChannel)
+	waitGroup.Wait()
+	if !assert.Nil(t, err) || !assert.Len(t, trips, 20, "10 requests during ramp-up, 10 at steady rate") {
+		return
+	}
+	start := trips[0].scheduledTime
+	for n, trip := range trips {
+		expected, ok := profile.arrival(n)
+		assert.True(t, ok)
+		assert.InDelta(t, float64(expected), float64(trip.scheduledTime.Sub(start)), float64(time.Microsecond), "trip %v", n)
+		assert.EqualValues(t, n%2, trip.index, "requests are sent round-robin")
+		if n > 0 {
+			assert.True(t, trip.scheduledTime.After(trips[n-1].scheduledTime), "trip %v", n)
+		}
+	}
+	rampInterval := trips[1].scheduledTime.Sub(trips[0].scheduledTime)
+	steadyInterval := trips[19].scheduledTime.Sub(trips[18].scheduledTime)
+	assert.True(t, rampInterval > steadyInterval, "arrival interval shrinks during ramp-up")
+	assert.InDelta(t, float64(25*time.Millisecond), float64(steadyInterval), float64(time.Millisecond), "40 RPS at steady rate")
+}
+
+func TestLoadRequest_ValidateTimeSeries(t *testing.T) {
+	var useCases = []struct {
+		URL      string
+		hasError bool
+	}{
+		{URL: ""},
+		{URL: "/tmp/load/series.csv"},
+		{URL: "${appPath}/series.JSON"},
+		{URL: "/tmp/load/series.txt", hasError: true},
+		{URL: "/tmp/load/series", hasError: true},
+	}
+	for _, useCase := range useCases {
+		request := &LoadRequest{SendRequest: &SendRequest{Requests: []*Request{{Method: "GET", URL: "http://127.0.0.1/"}}}, TimeSeries: useCase.URL}
+		assert.Nil(t, request.Init(), useCase.URL)
+		assert.EqualValues(t, useCase.hasError, request.Validate() != nil, useCase.URL)
+	}
+}
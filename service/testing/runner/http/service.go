@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"github.com/viant/assertly"
 	"github.com/viant/endly"
+	"github.com/viant/endly/internal/tracing"
 	"github.com/viant/endly/model/criteria"
 	"github.com/viant/endly/model/msg"
 	exml "github.com/viant/endly/service/testing/runner/http/xml"
 	"github.com/viant/endly/service/testing/validator"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
+	"go.opentelemetry.io/otel/attribute"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -80,6 +82,9 @@ func (s *service) sendRequest(context *endly.Context, client *http.Client, reque
 	repeater := request.Repeater.Init()
 	var response *Response
 	bodyProvider, err := getRequestBodyReader(httpRequest, repeater.Repeat)
+	endSpan := context.StartSpan("HTTP "+httpRequest.Method, attribute.String("http.method", httpRequest.Method), attribute.String("http.url", httpRequest.URL.String()))
+	defer func() { endSpan(err) }()
+	tracing.Inject(context.Background(), httpRequest.Header)
 
 	handler := func() (interface{}, error) {
 		httpRequest.Body = bodyProvider()
@@ -104,6 +109,7 @@ func (s *service) sendRequest(context *endly.Context, client *http.Client, reque
 	if err != nil {
 		return err
 	}
+	tracing.SetAttributes(context.Background(), attribute.Int("http.status_code", response.Code))
 
 	if strings.HasPrefix(strings.TrimSpace(response.Body), "<?xml") {
 		node := &exml.Node{}
@@ -172,6 +178,9 @@ func (s *service) handleRequest(client *http.Client, metric *runtimeMetric, trip
 	response, err = client.Do(trip.request)
 	trip.responseTime = time.Now()
 	trip.elapsed = trip.responseTime.Sub(trip.requestTime)
+	if !trip.scheduledTime.IsZero() { //open model latency includes time waiting for available client (coordinated omission)
+		trip.elapsed = trip.responseTime.Sub(trip.scheduledTime)
+	}
 	atomic.AddUint32(&metric.count, 1)
 
 	if err, ok := err.(net.Error); ok && err.Timeout() {
@@ -218,7 +227,10 @@ func (s *service) handleRequests(client *http.Client, sendChannel chan *stressTe
 		case trip := <-sendChannel:
 			s.handleRequest(client, metric, trip)
 		case <-time.After(15 * time.Second):
-			return
+			if atomic.LoadUint32(done) == 1 {
+				return
+			}
+			continue
 		}
 		if atomic.LoadUint32(done) == 1 {
 			return
@@ -237,21 +249,39 @@ type runtimeMetric struct {
 func (s *service) stressTest(context *endly.Context, request *LoadRequest) (*LoadResponse, error) {
 	var waitGroup = &sync.WaitGroup{}
 	capacity := 1024 * request.ThreadCount
+	var profile *loadProfile
+	if request.hasProfile() {
+		profile = newLoadProfile(request)
+		if !profile.isOpen() { //closed model keeps clients busy without queuing requests beyond duration
+			capacity = request.ThreadCount
+		}
+	}
 	var sendChannel = make(chan *stressTestTrip, capacity)
 	var done uint32 = 0
+	defer atomic.StoreUint32(&done, 1)
 	metrics := &runtimeMetric{}
 
-	go s.emitMetrics(context, metrics, &done, request.Message)
+	state := context.State()
+	go s.emitMetrics(context, state.Clone(), metrics, &done, request.Message) //state is cloned before trips expand it
 	if _, err := s.initClients(request, sendChannel, metrics, &done); err != nil {
 		return nil, err
 	}
-	partialTrips := newPartialStressTrips(capacity, sendChannel, waitGroup)
-	trips, err := buildStressTestTrip(request, context, partialTrips)
+	var trips []*stressTestTrip
+	var err error
+	if profile != nil {
+		trips, err = generateStressTestTrips(context, request, profile, sendChannel, waitGroup)
+	} else {
+		partialTrips := newPartialStressTrips(capacity, sendChannel, waitGroup)
+		trips, err = buildStressTestTrip(request, context, partialTrips)
+	}
 	if err != nil {
 		return nil, err
 	}
 	waitGroup.Wait()
 	atomic.StoreUint32(&done, 1)
+	if len(trips) == 0 {
+		return nil, fmt.Errorf("no requests were sent")
+	}
 	var response = &LoadResponse{
 		Status: "ok",
 	}
@@ -259,6 +289,11 @@ func (s *service) stressTest(context *endly.Context, request *LoadRequest) (*Loa
 	if err = collectTripResponses(trips, response, request); err != nil {
 		return nil, err
 	}
+	if request.TimeSeries != "" {
+		if err = writeTimeSeries(context.Background(), context.Expand(request.TimeSeries), response.TimeSeries); err != nil {
+			return nil, err
+		}
+	}
 
 	response.Assert = &validator.AssertResponse{Validation: &assertly.Validation{}}
 	var actual = make([]interface{}, 0)
@@ -293,9 +328,23 @@ func (s *service) stressTest(context *endly.Context, request *LoadRequest) (*Loa
 			expected = append(expected, expect)
 
 		}
-		response.Assert, err = validator.Assert(context, request, expected, actual, "HTTP.Responses", "assert http responses")
+		if len(expected) > 0 { //expect may only define thresholds
+			response.Assert, err = validator.Assert(context, request, expected, actual, "HTTP.Responses", "assert http responses")
+		}
 	}
-	return response, err
+	if err != nil {
+		return response, err
+	}
+	var validation *assertly.Validation
+	if response.Assert != nil {
+		validation = response.Assert.Validation
+	}
+	if err = checkThresholds(request.thresholds, response, validation); err != nil {
+		response.Status = "error"
+		response.Error = err.Error()
+		return response, err //threshold breach fails the action
+	}
+	return response, nil
 }
 
 func collectTripResponses(trips []*stressTestTrip, response *LoadResponse, request *LoadRequest) error {
@@ -341,39 +390,31 @@ func collectTripResponses(trips []*stressTestTrip, response *LoadResponse, reque
 	response.TestDurationSec = float64(testDuration) / float64(time.Second)
 	response.RequestCount = len(trips)
 	response.QPS = float64(len(trips)) / response.TestDurationSec
+	response.ErrorRate = 100 * float64(response.ErrorCount+response.TimeoutCount) / float64(len(trips))
+	collectLatency(trips, request, response, startTime)
 	return nil
 }
 
 type stressTestTrip struct {
-	index        int
-	err          error
-	timeout      bool
-	expectBinary bool
-	request      *http.Request
-	response     *http.Response
-	expected     bool
-	waitGroup    *sync.WaitGroup
-	requestTime  time.Time
-	responseTime time.Time
-	elapsed      time.Duration
+	index         int
+	err           error
+	timeout       bool
+	expectBinary  bool
+	request       *http.Request
+	response      *http.Response
+	expected      bool
+	waitGroup     *sync.WaitGroup
+	scheduledTime time.Time
+	requestTime   time.Time
+	responseTime  time.Time
+	elapsed       time.Duration
 }
 
 func buildStressTestTrip(request *LoadRequest, context *endly.Context, partials *partialStressTrips) ([]*stressTestTrip, error) {
 	var sessionCookies = []*http.Cookie{}
 	var err error
 	var trips = make([]*stressTestTrip, 0)
-
-	var expectedResponses []interface{}
-	if len(request.Expect) > 0 {
-		responses, ok := request.Expect["Responses"]
-		if !ok {
-			responses, ok = request.Expect["responses"]
-		}
-		if ok {
-			expectedResponses = toolbox.AsSlice(responses)
-		}
-	}
-
+	var expectedCount = expectedResponseCount(request)
 	for index, req := range request.Requests {
 		var state = context.State()
 		req.Expand(state)
@@ -382,7 +423,7 @@ func buildStressTestTrip(request *LoadRequest, context *endly.Context, partials
 				waitGroup: partials.WaitGroup,
 				index:     index,
 			}
-			if (i == 0 || (i%request.AssertMod) == 0) && index < len(expectedResponses) { //add validation to the first response from repeated
+			if (i == 0 || (i%request.AssertMod) == 0) && index < expectedCount { //add validation to the first response from repeated
 				trip.expected = true
 			}
 			if trip.request, trip.expectBinary, err = req.Build(context, sessionCookies); err != nil {
@@ -400,6 +441,21 @@ func buildStressTestTrip(request *LoadRequest, context *endly.Context, partials
 	return trips, nil
 }
 
+// expectedResponseCount returns number of expected responses
+func expectedResponseCount(request *LoadRequest) int {
+	if len(request.Expect) == 0 {
+		return 0
+	}
+	responses, ok := request.Expect["Responses"]
+	if !ok {
+		responses, ok = request.Expect["responses"]
+	}
+	if !ok {
+		return 0
+	}
+	return len(toolbox.AsSlice(responses))
+}
+
 func (s *service) initClients(request *LoadRequest, sendChannel chan *stressTestTrip, metric *runtimeMetric, done *uint32) ([]*http.Client, error) {
 	var clients = make([]*http.Client, request.ThreadCount)
 	var err error
@@ -514,12 +570,10 @@ func (s *service) registerRoutes() {
 
 }
 
-func (s *service) emitMetrics(context *endly.Context, metric *runtimeMetric, done *uint32, message string) {
+func (s *service) emitMetrics(context *endly.Context, private data.Map, metric *runtimeMetric, done *uint32, message string) {
 	if message == "" {
 		return
 	}
-	state := context.State()
-	private := state.Clone()
 	for atomic.LoadUint32(done) == 0 {
 		count := atomic.LoadUint32(&metric.count)
 		if count == 0 {
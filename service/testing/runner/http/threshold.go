@@ -0,0 +1,124 @@
+package http
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/viant/assertly"
+	"github.com/viant/toolbox"
+)
+
+// Threshold represents load test threshold, i.e. p99: '< 50ms' or errorRate: '< 0.1%', latency is expressed in ms, error rate in percent
+type Threshold struct {
+	Metric     string
+	Operator   string
+	Value      float64
+	Expression string
+}
+
+var thresholdExpression = regexp.MustCompile(`^\s*(<=|>=|<|>)\s*([0-9]*\.?[0-9]+)\s*(ms|us|s|%)?\s*$`)
+
+var thresholdMetrics = map[string]func(response *LoadResponse) float64{
+	"min":       func(response *LoadResponse) float64 { return response.Latency.MinMs },
+	"avg":       func(response *LoadResponse) float64 { return response.Latency.AvgMs },
+	"max":       func(response *LoadResponse) float64 { return response.Latency.MaxMs },
+	"p50":       func(response *LoadResponse) float64 { return response.Latency.P50Ms },
+	"p90":       func(response *LoadResponse) float64 { return response.Latency.P90Ms },
+	"p95":       func(response *LoadResponse) float64 { return response.Latency.P95Ms },
+	"p99":       func(response *LoadResponse) float64 { return response.Latency.P99Ms },
+	"p99.9":     func(response *LoadResponse) float64 { return response.Latency.P999Ms },
+	"p999":      func(response *LoadResponse) float64 { return response.Latency.P999Ms },
+	"errorrate": func(response *LoadResponse) float64 { return response.ErrorRate },
+	"qps":       func(response *LoadResponse) float64 { return response.QPS },
+}
+
+// Check returns true if actual value meets threshold
+func (t *Threshold) Check(actual float64) bool {
+	switch t.Operator {
+	case "<":
+		return actual < t.Value
+	case "<=":
+		return actual <= t.Value
+	case ">":
+		return actual > t.Value
+	case ">=":
+		return actual >= t.Value
+	}
+	return false
+}
+
+func newThreshold(metric string, expression string) (*Threshold, error) {
+	matched := thresholdExpression.FindStringSubmatch(expression)
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("invalid %v threshold: '%v', expected i.e. '< 50ms'", metric, expression)
+	}
+	value, err := strconv.ParseFloat(matched[2], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v threshold: '%v', %w", metric, expression, err)
+	}
+	unit := matched[3]
+	switch strings.ToLower(metric) {
+	case "errorrate":
+		if unit != "%" && unit != "" {
+			return nil, fmt.Errorf("invalid %v threshold unit: '%v', expected %%", metric, expression)
+		}
+		if unit == "" {
+			value *= 100
+		}
+	case "qps":
+		if unit != "" {
+			return nil, fmt.Errorf("invalid %v threshold unit: '%v'", metric, expression)
+		}
+	default:
+		switch unit {
+		case "s":
+			value *= 1000
+		case "us":
+			value /= 1000
+		case "%":
+			return nil, fmt.Errorf("invalid %v threshold unit: '%v', expected ms, s or us", metric, expression)
+		}
+	}
+	return &Threshold{Metric: metric, Operator: matched[1], Value: value, Expression: strings.TrimSpace(expression)}, nil
+}
+
+// parseThresholds returns thresholds defined in expect with metric key (min, avg, max, p50, p90, p95, p99, p99.9, errorRate, qps), other keys are ignored
+func parseThresholds(expect map[string]interface{}) ([]*Threshold, error) {
+	var keys = make([]string, 0)
+	for key := range expect {
+		if _, ok := thresholdMetrics[strings.ToLower(key)]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys) //stable violation order
+	var result = make([]*Threshold, 0)
+	for _, key := range keys {
+		threshold, err := newThreshold(key, toolbox.AsString(expect[key]))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, threshold)
+	}
+	return result, nil
+}
+
+// checkThresholds returns error listing all violated thresholds, each violation is also added to validation failures if validation is not nil
+func checkThresholds(thresholds []*Threshold, response *LoadResponse, validation *assertly.Validation) error {
+	var violations = make([]string, 0)
+	for _, threshold := range thresholds {
+		actual := thresholdMetrics[strings.ToLower(threshold.Metric)](response)
+		if !threshold.Check(actual) {
+			violations = append(violations, fmt.Sprintf("%v: %.3f, expected %v", threshold.Metric, actual, threshold.Expression))
+			if validation != nil {
+				validation.AddFailure(assertly.NewFailure("load", "Thresholds."+threshold.Metric, "threshold not met", threshold.Expression, actual))
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("load thresholds not met: %v", strings.Join(violations, ", "))
+}
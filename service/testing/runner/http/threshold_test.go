@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+)
+
+func TestCheckThresholds(t *testing.T) {
+	startTime := time.Now()
+	var trips = make([]*stressTestTrip, 0)
+	for i := 1; i <= 100; i++ {
+		trip := &stressTestTrip{index: i % 2, requestTime: startTime.Add(time.Duration(i*15) * time.Millisecond), elapsed: time.Duration(i) * time.Millisecond}
+		if i == 100 {
+			trip.err = errors.New("connection reset")
+		}
+		trips = append(trips, trip)
+	}
+	request := &LoadRequest{SendRequest: &SendRequest{Requests: []*Request{{Method: "GET", URL: "http://127.0.0.1/a"}, {Method: "GET", URL: "http://127.0.0.1/b"}}}}
+	response := &LoadResponse{ErrorRate: 1, QPS: 66}
+	collectLatency(trips, request, response, startTime)
+	assert.EqualValues(t, 99, response.Latency.Count)
+	assert.InDelta(t, 50, response.Latency.P50Ms, 0.1)
+	assert.InDelta(t, 99, response.Latency.P99Ms, 1)
+	assert.Len(t, response.RequestLatency, 2)
+	assert.Len(t, response.TimeSeries, 2)
+	assert.EqualValues(t, 66, response.TimeSeries[0].Count)
+	assert.EqualValues(t, 1, response.TimeSeries[1].Errors)
+
+	var useCases = []struct {
+		description string
+		expect      map[string]interface{}
+		hasError    bool
+		violated    bool
+	}{
+		{
+			description: "met thresholds",
+			expect:      map[string]interface{}{"p99": "< 100ms", "errorRate": "<= 1%", "qps": "> 50", "Responses": []interface{}{}},
+		},
+		{
+			description: "unit conversion",
+			expect:      map[string]interface{}{"p50": "< 0.06s", "max": ">= 90000us", "errorRate": "< 0.02"},
+		},
+		{
+			description: "violated p90",
+			expect:      map[string]interface{}{"p90": "< 50ms"},
+			violated:    true,
+		},
+		{
+			description: "violated error rate",
+			expect:      map[string]interface{}{"errorRate": "< 0.1%"},
+			violated:    true,
+		},
+		{
+			description: "invalid expression",
+			expect:      map[string]interface{}{"p99": "50ms"},
+			hasError:    true,
+		},
+		{
+			description: "invalid unit",
+			expect:      map[string]interface{}{"p99": "< 5%"},
+			hasError:    true,
+		},
+	}
+
+	for _, useCase := range useCases {
+		thresholds, err := parseThresholds(useCase.expect)
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		err = checkThresholds(thresholds, response, nil)
+		assert.EqualValues(t, useCase.violated, err != nil, useCase.description)
+	}
+}
+
+func TestService_LoadThresholds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		time.Sleep(2 * time.Millisecond)
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var useCases = []struct {
+		description string
+		expect      map[string]interface{}
+		expectError string
+	}{
+		{
+			description: "met thresholds",
+			expect:      map[string]interface{}{"p99": "< 1s", "errorRate": "< 1%"},
+		},
+		{
+			description: "violations in metric order",
+			expect:      map[string]interface{}{"qps": "> 1000000", "p99": "< 1us", "avg": "< 1us", "errorRate": "< 1%"},
+			expectError: "load thresholds not met: avg",
+		},
+	}
+	context := endly.New().NewContext(nil)
+	defer context.Close()
+	service, err := context.Service(ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, useCase := range useCases {
+		request := &LoadRequest{
+			SendRequest: &SendRequest{Requests: []*Request{{Method: "GET", URL: server.URL}}, Expect: useCase.expect},
+			ThreadCount: 2,
+			Repeat:      10,
+		}
+		serviceResponse := service.Run(context, request)
+		response, ok := serviceResponse.Response.(*LoadResponse)
+		if !assert.True(t, ok, useCase.description) {
+			continue
+		}
+		if useCase.expectError == "" {
+			assert.Nil(t, serviceResponse.Err, useCase.description)
+			assert.EqualValues(t, 0, response.Assert.FailedCount, useCase.description)
+			continue
+		}
+		if assert.NotNil(t, serviceResponse.Err, useCase.description) {
+			assert.Contains(t, serviceResponse.Err.Error(), useCase.expectError, useCase.description)
+			assert.Regexp(t, "avg: .+, p99: .+, qps: ", serviceResponse.Err.Error(), useCase.description)
+		}
+		assert.EqualValues(t, "error", response.Status, useCase.description)
+		assert.EqualValues(t, 3, response.Assert.FailedCount, useCase.description)
+		assert.NotNil(t, endly.Run(context, request, &LoadResponse{}), "action fails on threshold breach")
+	}
+}
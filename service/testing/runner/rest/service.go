@@ -1,10 +1,18 @@
 package rest
 
 import (
+	"bytes"
+	stdcontext "context"
+	"errors"
 	"fmt"
 	"github.com/viant/endly"
+	"github.com/viant/endly/internal/tracing"
 	"github.com/viant/endly/service/testing/validator"
 	"github.com/viant/toolbox"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"net/http"
+	"strings"
 )
 
 // ServiceID represents rest service id.
@@ -30,7 +38,7 @@ func (s *restService) sendRequest(context *endly.Context, request *Request) (*Re
 	handler := func() (interface{}, error) {
 
 		var JSONResponse = make(map[string]interface{})
-		err := toolbox.RouteToService(request.Method, context.Expand(request.URL), req, &JSONResponse, request.httpOptions...)
+		err := s.send(context, request, req, &JSONResponse)
 		if err != nil {
 			return nil, err
 		}
@@ -47,6 +55,91 @@ func (s *restService) sendRequest(context *endly.Context, request *Request) (*Re
 	return response, err
 }
 
+// send routes JSON request to the service, traced call context is propagated with traceparent header
+func (s *restService) send(context *endly.Context, request *Request, body, response interface{}) error {
+	URL := context.Expand(request.URL)
+	if !trace.SpanFromContext(context.Background()).SpanContext().IsValid() {
+		return toolbox.RouteToService(request.Method, URL, body, response, request.httpOptions...)
+	}
+	client, err := toolbox.NewHttpClient(request.httpOptions...)
+	if err != nil {
+		return err
+	}
+	traced := *client
+	traced.Transport = &traceTransport{ctx: context.Background(), transport: client.Transport}
+	return routeToService(&traced, request.Method, URL, body, response)
+}
+
+// traceTransport sets W3C traceparent header of the call context span
+type traceTransport struct {
+	ctx       stdcontext.Context
+	transport http.RoundTripper
+}
+
+// RoundTrip sends request with trace context header
+func (t *traceTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	tracing.Inject(t.ctx, request.Header)
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(request)
+}
+
+// routeToService sends request as toolbox.RouteToService does, using supplied client
+func routeToService(client *http.Client, method, URL string, request, response interface{}) error {
+	httpMethod := strings.ToUpper(method)
+	var body io.Reader
+	if request != nil {
+		buffer := new(bytes.Buffer)
+		if toolbox.IsString(request) {
+			buffer.WriteString(toolbox.AsString(request))
+		} else if err := toolbox.NewJSONEncoderFactory().Create(buffer).Encode(&request); err != nil {
+			return fmt.Errorf("failed to encode request: %v due to ", err)
+		}
+		body = buffer
+	}
+	httpRequest, err := http.NewRequest(httpMethod, URL, body)
+	if err != nil {
+		return err
+	}
+	if request != nil {
+		httpRequest.Header.Set("Content-Type", "application/json")
+	}
+	httpResponse, err := client.Do(httpRequest)
+	if httpResponse != nil {
+		defer httpResponse.Body.Close()
+	}
+	if err != nil && httpResponse != nil {
+		return fmt.Errorf("failed to get response %v %v", err, httpResponse.Header.Get("error"))
+	}
+	if response == nil {
+		return nil
+	}
+	if httpResponse == nil {
+		return fmt.Errorf("failed to receive response %v", err)
+	}
+	var errorPrefix = fmt.Sprintf("failed to process response: %v, ", httpResponse.StatusCode)
+	data, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("%v unable read body %v", errorPrefix, err)
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("%v response body was empty", errorPrefix)
+	}
+	if httpResponse.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if httpResponse.StatusCode/100 == 5 {
+		return errors.New(string(data))
+	}
+	if err = toolbox.NewJSONDecoderFactory().Create(bytes.NewReader(data)).Decode(response); err != nil {
+		return fmt.Errorf("%v. unable decode response as %T: body: %v: %v", errorPrefix, response, string(data), err)
+	}
+	return nil
+}
+
 const restSendExample = `
 {
 		"URL": "http://127.0.0.1:8085/v1/reporter/register/",
@@ -6,16 +6,20 @@ import (
 	endpoint "github.com/viant/endly/service/testing/endpoint/http"
 	runner "github.com/viant/endly/service/testing/runner/rest"
 	"github.com/viant/toolbox"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"net/http"
+	"net/http/httptest"
 	"path"
 	"testing"
 )
 
 func StartRestTestServer(port int) error {
 	baseDir := toolbox.CallerDirectory(3)
-	return endpoint.StartServer(port, &endpoint.HTTPServerTrips{
+	_, err := endpoint.StartServer(port, &endpoint.HTTPServerTrips{
 		IndexKeys:     []string{endpoint.MethodKey, endpoint.URLKey, endpoint.BodyKey, endpoint.CookieKey, endpoint.ContentTypeKey},
 		BaseDirectory: path.Join(baseDir, "test/send"),
-	})
+	}, "bridge.HttpRequest-%d.json", "bridge.HttpResponse-%d.json")
+	return err
 }
 
 func TestResetRunnerService_Run(t *testing.T) {
@@ -45,3 +49,42 @@ func TestResetRunnerService_Run(t *testing.T) {
 	}
 
 }
+
+func TestRestRunnerService_Traceparent(t *testing.T) {
+	headers := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		headers <- request.Header.Get("traceparent")
+		_, _ = response.Write([]byte(`{"text":"cba","count":3}`))
+	}))
+	defer server.Close()
+	manager := endly.New()
+	service, err := manager.Service(runner.ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	var responses = make([]interface{}, 0)
+	for _, traced := range []bool{false, true} {
+		context := manager.NewContext(toolbox.NewContext())
+		if traced {
+			context.EnableTracing(sdktrace.NewTracerProvider())
+		}
+		serviceResponse := service.Run(context, &runner.Request{URL: server.URL, Method: "POST", Request: `{"text":"abc"}`})
+		context.Close()
+		if !assert.Equal(t, "", serviceResponse.Error, traced) {
+			return
+		}
+		response, ok := serviceResponse.Response.(*runner.Response)
+		if !assert.True(t, ok, traced) {
+			return
+		}
+		responses = append(responses, response.Response)
+		traceparent := <-headers
+		if traced {
+			assert.Regexp(t, "^00-[0-9a-f]{32}-[0-9a-f]{16}-01$", traceparent)
+		} else {
+			assert.Empty(t, traceparent)
+		}
+	}
+	assert.EqualValues(t, responses[0], responses[1], "traced response decodes as untraced one")
+	assert.EqualValues(t, "cba", toolbox.AsMap(responses[1])["text"])
+}
@@ -0,0 +1,123 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"strings"
+	"sync"
+)
+
+var errAsyncCanceled = errors.New("canceled")
+
+// AsyncError represents async action error
+type AsyncError struct {
+	TagID   string
+	Service string
+	Action  string
+	Error   string
+	err     error
+}
+
+// AsyncErrors represents errors of all failed async actions
+type AsyncErrors struct {
+	mux    sync.Mutex
+	Errors []*AsyncError
+}
+
+// Add adds supplied action error
+func (e *AsyncErrors) Add(action *model.Action, err error) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	e.Errors = append(e.Errors, &AsyncError{TagID: action.TagID, Service: action.Service, Action: action.Action, Error: err.Error(), err: err})
+}
+
+// HasErrors returns true if any async action failed
+func (e *AsyncErrors) HasErrors() bool {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return len(e.Errors) > 0
+}
+
+// Error returns all async action errors
+func (e *AsyncErrors) Error() string {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	var messages = make([]string, 0, len(e.Errors))
+	for _, item := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%v %v.%v: %v", item.TagID, item.Service, item.Action, item.Error))
+	}
+	return fmt.Sprintf("%d async action(s) failed: %v", len(messages), strings.Join(messages, "; "))
+}
+
+// Unwrap returns underlying action errors
+func (e *AsyncErrors) Unwrap() []error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	var result = make([]error, 0, len(e.Errors))
+	for _, item := range e.Errors {
+		result = append(result, item.err)
+	}
+	return result
+}
+
+// NewAsyncErrors creates async actions errors
+func NewAsyncErrors() *AsyncErrors {
+	return &AsyncErrors{Errors: make([]*AsyncError, 0)}
+}
+
+// runAsyncActions starts async actions, each action runs with cloned context sharing group cancellation,
+// action errors are collected once the group completes
+func (s *Service) runAsyncActions(parent *endly.Context, process *model.Process, asyncActions []*model.Action, group *sync.WaitGroup, asyncErrors *AsyncErrors) {
+	if len(asyncActions) == 0 {
+		return
+	}
+	groupContext, cancel := parent.WithCancel()
+	groupContext.Debugger = nil
+	actionGroup := &sync.WaitGroup{}
+	group.Add(len(asyncActions))
+	actionGroup.Add(len(asyncActions))
+	for i := range asyncActions {
+		parent.Publish(NewAsyncEvent(asyncActions[i]))
+		go func(action *model.Action, actionContext *endly.Context) {
+			defer group.Done()
+			defer actionGroup.Done()
+			if err := s.runAsyncAction(parent, actionContext, process, action); err != nil {
+				asyncErrors.Add(action, err)
+				if action.FailFast {
+					cancel()
+				}
+			}
+		}(asyncActions[i], groupContext.Clone())
+	}
+	go func() {
+		actionGroup.Wait()
+		cancel()
+	}()
+}
+
+// runAsyncAction runs async action, it returns error if action failed, timed out or was canceled by failing sibling,
+// canceled action stops through its context: service calls get canceled and no further repeat or retry is attempted
+func (s *Service) runAsyncAction(parent, actionContext *endly.Context, process *model.Process, action *model.Action) error {
+	events := actionContext.MakeAsyncSafe()
+	defer func() {
+		for _, event := range events.Drain() {
+			parent.Publish(event)
+		}
+	}()
+	var handler = func(action *model.Action) func() (interface{}, error) {
+		return func() (interface{}, error) {
+			if actionContext.IsClosed() {
+				return nil, errAsyncCanceled
+			}
+			return s.runActionWithPolicy(actionContext, action, process)
+		}
+	}
+	var extractable = make(map[string]interface{})
+	err := action.Repeater.Run(actionContext, "action", s.AbstractService, handler(action), extractable)
+	if actionContext.Background().Err() != nil {
+		return errAsyncCanceled
+	}
+	return err
+}
@@ -0,0 +1,147 @@
+package workflow
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAsyncErrors_Error(t *testing.T) {
+	errTimeout := errors.New("timed out after 500 ms")
+	asyncErrors := NewAsyncErrors()
+	assert.False(t, asyncErrors.HasErrors())
+	asyncErrors.Add(&model.Action{MetaTag: &model.MetaTag{TagID: "t1"}, ServiceRequest: &model.ServiceRequest{Service: "http/runner", Action: "send"}}, errors.New("connection refused"))
+	asyncErrors.Add(&model.Action{MetaTag: &model.MetaTag{TagID: "t2"}, ServiceRequest: &model.ServiceRequest{Service: "exec", Action: "run"}}, errTimeout)
+	assert.True(t, asyncErrors.HasErrors())
+	assert.EqualValues(t, "2 async action(s) failed: t1 http/runner.send: connection refused; t2 exec.run: timed out after 500 ms", asyncErrors.Error())
+	assert.True(t, errors.Is(asyncErrors, errTimeout))
+	assert.EqualValues(t, "exec", asyncErrors.Errors[1].Service)
+}
+
+type asyncTestRequest struct {
+	SleepMs int
+	Error   string
+}
+
+func TestService_RunAsyncActions(t *testing.T) {
+	manager := endly.New()
+	asyncService := endly.NewAbstractService("asyncTest")
+	asyncService.Register(&endly.Route{
+		Action: "run",
+		RequestProvider: func() interface{} {
+			return &asyncTestRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &asyncTestRequest{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			asyncRequest := request.(*asyncTestRequest)
+			context.Publish(msg.NewStdoutEvent("asyncTest", asyncRequest.Error))
+			select {
+			case <-time.After(time.Duration(asyncRequest.SleepMs) * time.Millisecond):
+			case <-context.Background().Done():
+				return nil, context.Background().Err()
+			}
+			if asyncRequest.Error != "" {
+				return nil, errors.New(asyncRequest.Error)
+			}
+			return request, nil
+		},
+	})
+	manager.Register(asyncService)
+
+	type asyncAction struct {
+		tagID    string
+		sleepMs  int
+		error    string
+		failFast bool
+	}
+	var useCases = []struct {
+		description string
+		actions     []asyncAction
+		expect      map[string]string
+		maxElapsed  time.Duration
+	}{
+		{
+			description: "failures aggregated",
+			actions: []asyncAction{
+				{tagID: "ok", sleepMs: 10},
+				{tagID: "fail1", sleepMs: 20, error: "connection refused"},
+				{tagID: "fail2", sleepMs: 30, error: "not found"},
+			},
+			expect:     map[string]string{"fail1": "connection refused", "fail2": "not found"},
+			maxElapsed: time.Second,
+		},
+		{
+			description: "fail fast cancels siblings",
+			actions: []asyncAction{
+				{tagID: "fail", sleepMs: 20, error: "connection refused", failFast: true},
+				{tagID: "long1", sleepMs: 5000},
+				{tagID: "long2", sleepMs: 5000},
+			},
+			expect:     map[string]string{"fail": "connection refused", "long1": "canceled", "long2": "canceled"},
+			maxElapsed: 2 * time.Second,
+		},
+	}
+	for _, useCase := range useCases {
+		context := manager.NewContext(toolbox.NewContext())
+		service, err := context.Service(ServiceID)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		var published = make([]msg.Event, 0)
+		var mux sync.Mutex
+		context.Listener = func(event msg.Event) {
+			mux.Lock()
+			defer mux.Unlock()
+			published = append(published, event)
+		}
+		process := model.NewProcess(nil, &model.Workflow{AbstractNode: &model.AbstractNode{Name: "async"}}, nil)
+		process.State = data.NewMap()
+		Push(context, process)
+		var actions = make([]*model.Action, 0)
+		for _, item := range useCase.actions {
+			action := &model.Action{
+				ServiceRequest: &model.ServiceRequest{Service: "asyncTest", Action: "run", Request: map[string]interface{}{"SleepMs": item.sleepMs, "Error": item.error}},
+				MetaTag:        &model.MetaTag{TagID: item.tagID},
+				Async:          true,
+				FailFast:       item.failFast,
+			}
+			if !assert.Nil(t, action.Init(), useCase.description) {
+				return
+			}
+			actions = append(actions, action)
+		}
+		group := &sync.WaitGroup{}
+		asyncErrors := NewAsyncErrors()
+		started := time.Now()
+		service.(*Service).runAsyncActions(context, process, actions, group, asyncErrors)
+		group.Wait()
+		assert.True(t, time.Since(started) < useCase.maxElapsed, useCase.description)
+
+		assert.EqualValues(t, len(useCase.expect), len(asyncErrors.Errors), useCase.description)
+		for _, item := range asyncErrors.Errors {
+			expect, ok := useCase.expect[item.TagID]
+			if assert.True(t, ok, useCase.description+" "+item.TagID) {
+				assert.Contains(t, item.Error, expect, useCase.description+" "+item.TagID)
+			}
+		}
+		mux.Lock()
+		var stdout = 0
+		for _, event := range published {
+			if _, ok := event.Value().(*msg.StdoutEvent); ok {
+				stdout++
+			}
+		}
+		mux.Unlock()
+		assert.EqualValues(t, len(useCase.actions), stdout, "action events are flushed to parent: "+useCase.description)
+		context.Close()
+	}
+}
@@ -0,0 +1,250 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sync"
+	"time"
+)
+
+var checkpointKey = (*Checkpoint)(nil)
+
+// sensitiveKey matches state keys excluded from checkpoint snapshot
+var sensitiveKey = regexp.MustCompile(`(?i)secret|password|passwd|credential|token|apikey|api_key|private`)
+
+// Checkpoint represents workflow run progress, persisted as JSON lines of completed steps with changed workflow state
+type Checkpoint struct {
+	RunID     string
+	Workflow  string
+	Completed map[string]bool
+	State     map[string]map[string]interface{}
+	Updated   time.Time
+	location  string
+	persisted map[string]map[string]string //last persisted encoded state values by workflow
+	mux       sync.Mutex
+}
+
+// checkpointRecord represents persisted completed step, state holds workflow state values changed since previous step
+type checkpointRecord struct {
+	Key      string
+	Workflow string
+	State    map[string]json.RawMessage `json:",omitempty"`
+	Updated  time.Time
+}
+
+// IsCompleted returns true if node has been completed
+func (c *Checkpoint) IsCompleted(key string) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.Completed[key]
+}
+
+// Complete flags supplied node as completed and appends it with changed workflow state to checkpoint file
+func (c *Checkpoint) Complete(key string, workflow string, state data.Map) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	record := &checkpointRecord{Key: key, Workflow: workflow, State: make(map[string]json.RawMessage), Updated: time.Now()}
+	persisted, ok := c.persisted[workflow]
+	if !ok {
+		persisted = make(map[string]string)
+		c.persisted[workflow] = persisted
+	}
+	if _, ok := c.State[workflow]; !ok {
+		c.State[workflow] = make(map[string]interface{})
+	}
+	for k, v := range state {
+		encoded, ok := snapshotValue(k, v)
+		if !ok || persisted[k] == string(encoded) {
+			continue
+		}
+		record.State[k] = encoded
+		persisted[k] = string(encoded)
+		c.State[workflow][k] = v
+	}
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(c.location, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(encoded, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	c.Completed[key] = true
+	c.Updated = record.Updated
+	return file.Close()
+}
+
+// Restore restores supplied workflow state from snapshot, existing data.Map values like process state are updated in place
+func (c *Checkpoint) Restore(workflow string, state data.Map) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	for k, v := range c.State[workflow] {
+		existing, has := state[k]
+		if has && toolbox.IsFunc(existing) {
+			continue
+		}
+		if existingMap, ok := existing.(data.Map); ok && toolbox.IsMap(v) {
+			for key, value := range toolbox.AsMap(v) {
+				existingMap.Put(key, value)
+			}
+			continue
+		}
+		state.Put(k, v)
+	}
+}
+
+// Remove removes persisted checkpoint
+func (c *Checkpoint) Remove() error {
+	return os.Remove(c.location)
+}
+
+// apply applies persisted record
+func (c *Checkpoint) apply(record *checkpointRecord) error {
+	c.Completed[record.Key] = true
+	c.Updated = record.Updated
+	if _, ok := c.State[record.Workflow]; !ok {
+		c.State[record.Workflow] = make(map[string]interface{})
+		c.persisted[record.Workflow] = make(map[string]string)
+	}
+	for k, encoded := range record.State {
+		var value interface{}
+		if err := json.Unmarshal(encoded, &value); err != nil {
+			return err
+		}
+		c.State[record.Workflow][k] = value
+		c.persisted[record.Workflow][k] = string(encoded)
+	}
+	return nil
+}
+
+// snapshotValue returns JSON encoded state value, functions, UDFs and sensitive keys are excluded
+func snapshotValue(key string, value interface{}) ([]byte, bool) {
+	if value == nil || key == data.UDFKey || toolbox.IsFunc(value) || sensitiveKey.MatchString(key) {
+		return nil, false
+	}
+	encoded, err := json.Marshal(redacted(value))
+	return encoded, err == nil
+}
+
+// redacted returns value copy without sensitive nested keys
+func redacted(value interface{}) interface{} {
+	switch actual := value.(type) {
+	case data.Map:
+		return redacted(map[string]interface{}(actual))
+	case map[string]interface{}:
+		var result = make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			if sensitiveKey.MatchString(k) || toolbox.IsFunc(v) {
+				continue
+			}
+			result[k] = redacted(v)
+		}
+		return result
+	case []interface{}:
+		var result = make([]interface{}, len(actual))
+		for i, v := range actual {
+			result[i] = redacted(v)
+		}
+		return result
+	}
+	return value
+}
+
+func taskCheckpointKey(process *model.Process, task *model.Task) string {
+	return fmt.Sprintf("%v/%v", process.Workflow.Name, task.Name)
+}
+
+func actionCheckpointKey(process *model.Process, action *model.Action) string {
+	return fmt.Sprintf("%v/%v/%v/%v", process.Workflow.Name, process.Task.Name, action.TagID, action.Name)
+}
+
+// NewCheckpoint creates a new checkpoint for supplied run
+func NewCheckpoint(directory, runID, workflow string) *Checkpoint {
+	return &Checkpoint{
+		RunID:     runID,
+		Workflow:  workflow,
+		Completed: make(map[string]bool),
+		State:     make(map[string]map[string]interface{}),
+		persisted: make(map[string]map[string]string),
+		location:  path.Join(directory, runID+".jsonl"),
+	}
+}
+
+// LoadCheckpoint loads checkpoint for supplied run
+func LoadCheckpoint(directory, runID string) (*Checkpoint, error) {
+	result := NewCheckpoint(directory, runID, "")
+	file, err := os.Open(result.location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %v, %w", runID, err)
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(file)
+	for {
+		record := &checkpointRecord{}
+		if err = decoder.Decode(record); err == io.EOF {
+			break
+		}
+		if err == nil {
+			err = result.apply(record)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode checkpoint: %v, %w", runID, err)
+		}
+	}
+	return result, nil
+}
+
+// getCheckpoint returns context checkpoint or nil
+func getCheckpoint(context *endly.Context) *Checkpoint {
+	var result *Checkpoint
+	if !context.Contains(checkpointKey) {
+		return nil
+	}
+	context.GetInto(checkpointKey, &result)
+	return result
+}
+
+// enableCheckpointIfNeeded creates or loads run checkpoint, it returns function removing checkpoint once run completes successfully
+func (s *Service) enableCheckpointIfNeeded(context *endly.Context, request *RunRequest, workflow *model.Workflow) (func(err error), error) {
+	if getCheckpoint(context) != nil || !(request.Checkpoint || request.Resume != "") {
+		return func(err error) {}, nil
+	}
+	directory := request.CheckpointDirectory
+	if directory == "" {
+		directory = defaultCheckpointDirectory
+	}
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return nil, err
+	}
+	var checkpoint *Checkpoint
+	var err error
+	if request.Resume != "" {
+		if checkpoint, err = LoadCheckpoint(directory, request.Resume); err != nil {
+			return nil, err
+		}
+		context.Publish(msg.NewStdoutEvent("checkpoint", fmt.Sprintf("resuming run: %v, completed nodes: %v", checkpoint.RunID, len(checkpoint.Completed))))
+	} else {
+		checkpoint = NewCheckpoint(directory, context.SessionID, workflow.Name)
+		context.Publish(msg.NewStdoutEvent("checkpoint", fmt.Sprintf("run ID: %v, resume failed run with -resume=%v", checkpoint.RunID, checkpoint.RunID)))
+	}
+	_ = context.Put(checkpointKey, checkpoint)
+	return func(err error) {
+		_ = context.Remove(checkpointKey)
+		if err == nil {
+			_ = checkpoint.Remove()
+		}
+	}, nil
+}
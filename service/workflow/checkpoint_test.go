@@ -0,0 +1,146 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestCheckpoint_Complete(t *testing.T) {
+	directory := path.Join(os.TempDir(), "endly_checkpoint_test")
+	_ = os.MkdirAll(directory, 0755)
+	defer os.RemoveAll(directory)
+
+	checkpoint := NewCheckpoint(directory, "run1", "regression")
+	state := data.NewMap()
+	state.Put("counter", 3)
+	state.Put("config", map[string]interface{}{"URL": "http://127.0.0.1/"})
+	state.Put("udf", func() {})
+	state.Put("dbPassword", "dev")
+	state.Put("db", map[string]interface{}{"Host": "127.0.0.1", "Credentials": "secret.json"})
+	if !assert.Nil(t, checkpoint.Complete("regression/prepare", "regression", state)) {
+		return
+	}
+	state.Put("counter", 4)
+	if !assert.Nil(t, checkpoint.Complete("regression/test", "regression", state)) {
+		return
+	}
+	info, err := os.Stat(path.Join(directory, "run1.jsonl"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, os.FileMode(0600), info.Mode().Perm())
+	encoded, _ := os.ReadFile(path.Join(directory, "run1.jsonl"))
+	lines := strings.Split(strings.TrimSpace(string(encoded)), "\n")
+	if assert.EqualValues(t, 2, len(lines)) {
+		assert.EqualValues(t, `{"counter":4}`, recordState(t, lines[1]), "only changed state is appended")
+	}
+	assert.False(t, strings.Contains(string(encoded), "dev"), "sensitive keys are not persisted")
+	assert.False(t, strings.Contains(string(encoded), "secret.json"), "sensitive nested keys are not persisted")
+
+	loaded, err := LoadCheckpoint(directory, "run1")
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, loaded.IsCompleted("regression/prepare"))
+	assert.True(t, loaded.IsCompleted("regression/test"))
+	assert.False(t, loaded.IsCompleted("regression/cleanup"))
+
+	restored := data.NewMap()
+	loaded.Restore("regression", restored)
+	assert.EqualValues(t, 4, restored.GetInt("counter"))
+	assert.False(t, restored.Has("dbPassword"))
+	db := restored.GetMap("db")
+	assert.EqualValues(t, "127.0.0.1", db.GetString("Host"))
+	assert.False(t, db.Has("Credentials"))
+	config := restored.GetMap("config")
+	assert.EqualValues(t, "http://127.0.0.1/", config.GetString("URL"))
+	assert.False(t, restored.Has("udf"))
+
+	assert.Nil(t, loaded.Remove())
+	_, err = LoadCheckpoint(directory, "run1")
+	assert.NotNil(t, err)
+}
+
+func recordState(t *testing.T, line string) string {
+	record := &checkpointRecord{}
+	if !assert.Nil(t, json.Unmarshal([]byte(line), record)) {
+		return ""
+	}
+	encoded, _ := json.Marshal(record.State)
+	return string(encoded)
+}
+
+type checkpointProbeRequest struct {
+	Label     string
+	Interrupt bool
+}
+
+func TestService_Resume(t *testing.T) {
+	directory := path.Join(os.TempDir(), "endly_checkpoint_resume_test")
+	defer os.RemoveAll(directory)
+	var calls = make([]string, 0)
+	manager := endly.New()
+	probeService := endly.NewAbstractService("checkpointProbe")
+	probeService.Register(&endly.Route{
+		Action: "call",
+		RequestProvider: func() interface{} {
+			return &checkpointProbeRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &checkpointProbeRequest{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			probeRequest := request.(*checkpointProbeRequest)
+			calls = append(calls, probeRequest.Label)
+			if probeRequest.Interrupt {
+				return nil, errors.New("interrupted")
+			}
+			return request, nil
+		},
+	})
+	manager.Register(probeService)
+
+	var runID string
+	var useCases = []struct {
+		description string
+		interrupt   bool
+		resume      bool
+		expectCalls []string
+		hasError    bool
+	}{
+		{description: "interrupted run", interrupt: true, expectCalls: []string{"prepare", "test-ready"}, hasError: true},
+		{description: "resumed run", resume: true, expectCalls: []string{"test-ready"}},
+	}
+	for _, useCase := range useCases {
+		calls = calls[:0]
+		context := manager.NewContext(toolbox.NewContext())
+		request, err := NewRunRequestFromURL("test/checkpoint/checkpoint.yaml")
+		if !assert.Nil(t, err, useCase.description) {
+			return
+		}
+		request.AssetURL = "test/checkpoint/checkpoint.yaml"
+		request.Name = "checkpoint"
+		request.Params = map[string]interface{}{"interrupt": useCase.interrupt}
+		request.CheckpointDirectory = directory
+		request.Checkpoint = true
+		if useCase.resume {
+			request.Resume = runID
+		} else {
+			runID = context.SessionID
+		}
+		err = endly.Run(context, request, &RunResponse{})
+		assert.EqualValues(t, useCase.hasError, err != nil, useCase.description)
+		assert.EqualValues(t, useCase.expectCalls, calls, useCase.description)
+		_, err = os.Stat(path.Join(directory, runID+".jsonl"))
+		assert.EqualValues(t, useCase.hasError, err == nil, "checkpoint is kept for failed run only: "+useCase.description)
+		context.Close()
+	}
+}
@@ -5,4 +5,6 @@ const (
 	paramsStateKey = "params"
 	tasksStateKey  = "tasks"
 	selfStateKey   = "self"
+
+	defaultCheckpointDirectory = "checkpoint"
 )
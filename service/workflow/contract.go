@@ -17,23 +17,33 @@ import (
 
 // RunRequest represents workflow runWorkflow request
 type RunRequest struct {
-	EnableLogging     bool                   `description:"flag to enable logging"`
-	LogDirectory      string                 `description:"log directory"`
-	FailureCount      int                    `description:"max number of failures CLI reported per validation"`
-	SummaryFormat     string                 `description:"summary format: xml|json|yaml, summary file is not produced if this is empty"`
-	EventFilter       map[string]bool        `description:"optional CLI filter option,key is either package name or package name.request/event prefix "`
-	Async             bool                   `description:"flag to runWorkflow it asynchronously. Do not set it your self runner sets the flag for the first workflow"`
-	Params            map[string]interface{} `description:"workflow parameters, accessibly by paras.[Key], if PublishParameters is set, all parameters are place in context.state"`
-	PublishParameters bool                   `default:"true" description:"flag to publish parameters directly into context state"`
-	SharedState       bool                   `description:"by default workflow uses a separate cloned context copy, if this is flag context will be shared with a caller workflow state"`
-	URL               string                 `description:"workflow URL if workflow is not found in the registry, it is loaded"`
-	Name              string                 `required:"true" description:"name defined in workflow document"`
-	StateKey          string                 `description:"if specified workflow params and data will be visible globally with this key, default is inherited from workflow name"`
-	Source            *location.Resource     `description:"run request location "`
-	AssetURL          string
-	TagIDs            string `description:"coma separated TagID list, if present in a task, only matched runs, other task runWorkflow as normal"`
-	Tasks             string `required:"true" description:"coma separated task list, if empty or '*' runs all tasks sequentially"` //tasks to runWorkflow with coma separated list or '*', or empty string for all tasks
-	Interactive       bool
+	EnableLogging       bool                   `description:"flag to enable logging"`
+	LogDirectory        string                 `description:"log directory"`
+	EventLog            string                 `description:"JSONL event log file, each published event is written as JSON line, see endly -replay"`
+	TraceEndpoint       string                 `description:"OpenTelemetry OTLP HTTP endpoint, i.e. http://localhost:4318, or file path to write spans as JSON lines"`
+	FailureCount        int                    `description:"max number of failures CLI reported per validation"`
+	SummaryFormat       string                 `description:"coma separated summary formats: xml|json|yaml|junit|html, summary file is not produced if this is empty"`
+	SummaryDirectory    string                 `description:"summary and report output directory, default current directory"`
+	EventFilter         map[string]bool        `description:"optional CLI filter option,key is either package name or package name.request/event prefix "`
+	Async               bool                   `description:"flag to runWorkflow it asynchronously. Do not set it your self runner sets the flag for the first workflow"`
+	Params              map[string]interface{} `description:"workflow parameters, accessibly by paras.[Key], if PublishParameters is set, all parameters are place in context.state"`
+	PublishParameters   bool                   `default:"true" description:"flag to publish parameters directly into context state"`
+	SharedState         bool                   `description:"by default workflow uses a separate cloned context copy, if this is flag context will be shared with a caller workflow state"`
+	URL                 string                 `description:"workflow URL if workflow is not found in the registry, it is loaded"`
+	Name                string                 `required:"true" description:"name defined in workflow document"`
+	StateKey            string                 `description:"if specified workflow params and data will be visible globally with this key, default is inherited from workflow name"`
+	Source              *location.Resource     `description:"run request location "`
+	AssetURL            string
+	TagIDs              string `description:"coma separated TagID list, if present in a task, only matched runs, other task runWorkflow as normal"`
+	Tasks               string `required:"true" description:"coma separated task list, if empty or '*' runs all tasks sequentially"` //tasks to runWorkflow with coma separated list or '*', or empty string for all tasks
+	Interactive         bool
+	Breakpoints         []string `description:"workflow/task/tagID debugger breakpoints, '*' segment matches any value"`
+	StepMode            bool     `description:"flag to pause debugger before each workflow, task and action"`
+	DebugAddress        string   `description:"debug adapter protocol server listening address, i.e :4711"`
+	DryRun              bool     `description:"flag to print planned service actions with expanded requests without running them"`
+	Checkpoint          bool     `description:"flag to persist completed tasks, actions and state snapshot after each task"`
+	CheckpointDirectory string   `description:"checkpoint directory, default: checkpoint"`
+	Resume              string   `description:"run ID of a failed run to resume from checkpoint, completed tasks and actions are skipped"`
 	*model.Inlined
 	workflow *model.Workflow //inline workflow from pipeline
 }
@@ -0,0 +1,76 @@
+package workflow
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/internal/debug"
+	"github.com/viant/endly/internal/debug/dap"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/criteria"
+	"github.com/viant/endly/model/criteria/eval"
+)
+
+// enableDebuggerIfNeeded creates context debugger for request breakpoints, step mode or debug adapter address,
+// it returns function closing created debugger
+func (s *Service) enableDebuggerIfNeeded(context *endly.Context, request *RunRequest) (func(), error) {
+	if context.Debugger != nil || !(request.StepMode || len(request.Breakpoints) > 0 || request.DebugAddress != "") {
+		return func() {}, nil
+	}
+	debugger := debug.NewDebugger()
+	debugger.EnableStepMode(request.StepMode)
+	for _, breakpoint := range request.Breakpoints {
+		debugger.SetBreakpoint(debug.ParseStep(breakpoint))
+	}
+	if request.DebugAddress != "" {
+		if err := dap.New(request.DebugAddress, debugger).Start(); err != nil {
+			return nil, fmt.Errorf("failed to start debug adapter server: %w", err)
+		}
+	}
+	context.Debugger = debugger
+	return func() {
+		_ = debugger.Close()
+		context.Debugger = nil
+	}, nil
+}
+
+// debugStep returns debugger step for supplied process, task and action
+func debugStep(process *model.Process, task *model.Task, action *model.Action) debug.Step {
+	var result = debug.Step{}
+	if process.Workflow != nil {
+		result.Workflow = process.Workflow.Name
+	}
+	if task != nil {
+		result.TaskName = task.Name
+	}
+	if action != nil && action.MetaTag != nil {
+		result.TagID = action.TagID
+	}
+	return result
+}
+
+// debugNode consults context debugger before running a node, it returns function to be called once node completes
+func debugNode(context *endly.Context, kind string, step debug.Step, request interface{}) (func(response interface{}), error) {
+	debugger := context.Debugger
+	if debugger == nil {
+		return func(response interface{}) {}, nil
+	}
+	state := context.State()
+	frame := &debug.Frame{
+		Kind:    kind,
+		Step:    step,
+		State:   state,
+		Request: request,
+		Evaluate: func(expression string) (bool, error) {
+			var compute eval.Compute
+			return criteria.Evaluate(nil, state, expression, &compute, "Debug", false)
+		},
+	}
+	if err := debugger.Before(frame); err != nil {
+		debugger.After(frame)
+		return nil, err
+	}
+	return func(response interface{}) {
+		frame.Response = response
+		debugger.After(frame)
+	}, nil
+}
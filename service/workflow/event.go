@@ -1,8 +1,12 @@
 package workflow
 
 import (
+	"fmt"
 	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
+	"time"
 )
 
 // LoadedEvent represents workflow load event
@@ -50,3 +54,99 @@ type AsyncEvent struct {
 func NewAsyncEvent(action *model.Action) *AsyncEvent {
 	return &AsyncEvent{action}
 }
+
+// DryRunEvent represents planned service action in dry run mode
+type DryRunEvent struct {
+	TagID   string
+	Service string
+	Action  string
+	Request interface{}
+}
+
+// Messages returns messages
+func (e *DryRunEvent) Messages() []*msg.Message {
+	var request = toolbox.AsString(e.Request)
+	requestMap := map[string]interface{}{}
+	if err := toolbox.DefaultConverter.AssignConverted(&requestMap, e.Request); err == nil {
+		if text, err := toolbox.AsYamlText(toolbox.DeleteEmptyKeys(requestMap)); err == nil {
+			request = text
+		}
+	}
+	return []*msg.Message{
+		msg.NewMessage(msg.NewStyled(e.TagID, msg.MessageStyleGeneric), msg.NewStyled(fmt.Sprintf("%v:%v", e.Service, e.Action), msg.MessageStyleGroup),
+			msg.NewStyled(request, msg.MessageStyleInput)),
+	}
+}
+
+// NewDryRunEvent creates a new dry run event
+func NewDryRunEvent(activity *model.Activity, request interface{}) *DryRunEvent {
+	return &DryRunEvent{
+		TagID:   activity.TagID,
+		Service: activity.Service,
+		Action:  activity.Action,
+		Request: request,
+	}
+}
+
+// RetryEvent represents action retry event
+type RetryEvent struct {
+	TagID   string
+	Service string
+	Action  string
+	Attempt int
+	DelayMs int
+	Error   string
+}
+
+// Messages returns messages
+func (e *RetryEvent) Messages() []*msg.Message {
+	info := fmt.Sprintf("attempt %v failed, retrying in %v ms", e.Attempt, e.DelayMs)
+	if e.Error != "" {
+		info += ": " + e.Error
+	}
+	return []*msg.Message{
+		msg.NewMessage(msg.NewStyled(e.TagID, msg.MessageStyleGeneric), msg.NewStyled("retry", msg.MessageStyleGroup),
+			msg.NewStyled(info, msg.MessageStyleGeneric)),
+	}
+}
+
+// NewRetryEvent creates a new retry event
+func NewRetryEvent(action *model.Action, attempt int, delay time.Duration, err error) *RetryEvent {
+	result := &RetryEvent{
+		TagID:   action.TagID,
+		Service: action.Service,
+		Action:  action.Action,
+		Attempt: attempt,
+		DelayMs: int(delay / time.Millisecond),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// TimeoutEvent represents action timeout event
+type TimeoutEvent struct {
+	TagID     string
+	Service   string
+	Action    string
+	TimeoutMs int
+}
+
+// Messages returns messages
+func (e *TimeoutEvent) Messages() []*msg.Message {
+	return []*msg.Message{
+		msg.NewMessage(msg.NewStyled(e.TagID, msg.MessageStyleGeneric), msg.NewStyled("timeout", msg.MessageStyleGroup),
+			msg.NewStyled(fmt.Sprintf("%v:%v timed out after %v ms", e.Service, e.Action, e.TimeoutMs), msg.MessageStyleError)),
+	}
+}
+
+// NewTimeoutEvent creates a new timeout event
+func NewTimeoutEvent(action *model.Action) *TimeoutEvent {
+	return &TimeoutEvent{
+		TagID:     action.TagID,
+		Service:   action.Service,
+		Action:    action.Action,
+		TimeoutMs: action.TimeoutMs,
+	}
+}
@@ -0,0 +1,211 @@
+package workflow
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/assertly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+var eventLogKey = (*EventLog)(nil)
+
+// EventRecord represents JSONL event log record
+type EventRecord struct {
+	Seq         int                    `json:"seq"`
+	InitSeq     int                    `json:"initSeq,omitempty"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Package     string                 `json:"package"`
+	Type        string                 `json:"type"`
+	TagID       string                 `json:"tagID,omitempty"`
+	Path        string                 `json:"path,omitempty"`
+	Loggable    bool                   `json:"loggable,omitempty"`
+	Input       bool                   `json:"input,omitempty"`
+	Output      bool                   `json:"output,omitempty"`
+	Value       interface{}            `json:"value,omitempty"`
+	Validations []*assertly.Validation `json:"validations,omitempty"`
+	Messages    []*msg.Message         `json:"messages,omitempty"`
+	Repeated    *msg.Message           `json:"repeated,omitempty"`
+}
+
+// EventLog represents event listener writing each event as JSON line
+type EventLog struct {
+	Listener msg.Listener
+	writer   io.Writer
+	mutex    *sync.Mutex
+	seq      int
+	inits    map[msg.Event]*openActivity
+	active   []msg.Event //started activity events, the last one is the current activity
+}
+
+// openActivity represents started activity awaiting its end event
+type openActivity struct {
+	seq      int
+	activity *model.Activity
+}
+
+func activityPath(activity *model.Activity) string {
+	if activity == nil {
+		return ""
+	}
+	var elements = make([]string, 0, 3)
+	for _, element := range []string{activity.Caller, activity.Task} {
+		if element != "" {
+			elements = append(elements, element)
+		}
+	}
+	elements = append(elements, activity.Service+":"+activity.Action)
+	return strings.Join(elements, "/")
+}
+
+// eventActivity returns activity the supplied event belongs to: started or ended activity, otherwise the current one
+func (l *EventLog) eventActivity(event msg.Event) (*model.Activity, int) {
+	if activity, ok := event.Value().(*model.Activity); ok {
+		l.inits[event] = &openActivity{seq: l.seq, activity: activity}
+		l.active = append(l.active, event)
+		return activity, 0
+	}
+	if init := event.Init(); init != nil {
+		if open, ok := l.inits[init]; ok {
+			delete(l.inits, init)
+			for i := len(l.active) - 1; i >= 0; i-- {
+				if l.active[i] == init {
+					l.active = append(l.active[:i], l.active[i+1:]...)
+					break
+				}
+			}
+			return open.activity, open.seq
+		}
+	}
+	if len(l.active) == 0 {
+		return nil, 0
+	}
+	return l.inits[l.active[len(l.active)-1]].activity, 0
+}
+
+func (l *EventLog) newRecord(event msg.Event) *EventRecord {
+	l.seq++
+	value := event.Value()
+	activity, initSeq := l.eventActivity(event)
+	record := &EventRecord{
+		Seq:       l.seq,
+		InitSeq:   initSeq,
+		Timestamp: event.Timestamp(),
+		Package:   event.Package(),
+		Type:      event.Type(),
+		Path:      activityPath(activity),
+		Loggable:  event.IsLoggable(),
+		Value:     value,
+	}
+	if activity != nil {
+		record.TagID = activity.TagID
+	}
+	if input, ok := value.(msg.RunnerInput); ok {
+		record.Input = input.IsInput()
+	}
+	if output, ok := value.(msg.RunnerOutput); ok {
+		record.Output = output.IsOutput()
+	}
+	switch actual := value.(type) {
+	case *assertly.Validation:
+		record.Validations = []*assertly.Validation{actual}
+	case interface{ Assertion() []*assertly.Validation }:
+		record.Validations = actual.Assertion()
+	}
+	if reporter, ok := value.(msg.Reporter); ok {
+		record.Messages = reporter.Messages()
+	}
+	if reporter, ok := value.(msg.RepeatedReporter); ok {
+		record.Repeated = reporter.Message(&msg.Repeated{})
+	}
+	return record
+}
+
+func (l *EventLog) encode(record *EventRecord) ([]byte, error) {
+	data, err := json.Marshal(record)
+	if err == nil {
+		return data, nil
+	}
+	value := record.Value
+	var aMap = map[string]interface{}{}
+	if err = toolbox.DefaultConverter.AssignConverted(&aMap, value); err == nil {
+		record.Value = toolbox.DeleteEmptyKeys(aMap)
+		if data, err = json.Marshal(record); err == nil {
+			return data, nil
+		}
+	}
+	record.Value = fmt.Sprintf("%v", value)
+	return json.Marshal(record)
+}
+
+// OnEvent writes supplied event as JSON line
+func (l *EventLog) OnEvent(event msg.Event) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	data, err := l.encode(l.newRecord(event))
+	if err != nil {
+		log.Print(err)
+		return
+	}
+	if _, err = l.writer.Write(append(data, '\n')); err != nil {
+		log.Print(err)
+	}
+}
+
+// AsEventListener returns an event Listener
+func (l *EventLog) AsEventListener() msg.Listener {
+	return func(event msg.Event) {
+		if l.Listener != nil {
+			l.Listener(event)
+		}
+		l.OnEvent(event)
+	}
+}
+
+// NewEventLog creates a JSONL event log writing to supplied writer
+func NewEventLog(writer io.Writer, listener msg.Listener) *EventLog {
+	return &EventLog{
+		Listener: listener,
+		writer:   writer,
+		mutex:    &sync.Mutex{},
+		inits:    make(map[msg.Event]*openActivity),
+	}
+}
+
+// ReadEventLog reads JSONL event log records
+func ReadEventLog(reader io.Reader) ([]*EventRecord, error) {
+	var result = make([]*EventRecord, 0)
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 256*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &EventRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, fmt.Errorf("invalid event log record at line %v: %w", line, err)
+		}
+		result = append(result, record)
+	}
+	return result, scanner.Err()
+}
+
+func openEventLog(URL string) (*os.File, error) {
+	if parent, _ := path.Split(URL); parent != "" {
+		if err := os.MkdirAll(parent, 0744); err != nil {
+			return nil, err
+		}
+	}
+	return os.OpenFile(URL, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+}
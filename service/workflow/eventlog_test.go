@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"testing"
+)
+
+type assertedResponse struct {
+	Validation *assertly.Validation
+	Handler    func()
+}
+
+func (r *assertedResponse) Assertion() []*assertly.Validation {
+	return []*assertly.Validation{r.Validation}
+}
+
+func TestEventLog_OnEvent(t *testing.T) {
+	buf := new(bytes.Buffer)
+	var listened = 0
+	eventLog := NewEventLog(buf, func(event msg.Event) {
+		listened++
+	})
+	listener := eventLog.AsEventListener()
+
+	start := msg.NewEvent(&model.Activity{MetaTag: &model.MetaTag{TagID: "case1"}, Caller: "regression", Task: "test", Service: "validator", Action: "assert"})
+	listener(start)
+	listener(msg.NewEvent(&assertedResponse{
+		Validation: &assertly.Validation{PassedCount: 1, FailedCount: 1, Failures: []*assertly.Failure{{Path: "/status", Expected: "ok", Actual: "error"}}},
+		Handler:    func() {},
+	}))
+	listener(msg.NewEventWithInit(model.NewActivityEndEvent(&model.Activity{Service: "validator"}), start))
+	listener(msg.NewEvent(msg.NewErrorEvent("failed")))
+
+	assert.EqualValues(t, 4, listened)
+	records, err := ReadEventLog(buf)
+	if !assert.Nil(t, err) || !assert.Len(t, records, 4) {
+		return
+	}
+	assert.EqualValues(t, "model_Activity", records[0].Type)
+	assert.EqualValues(t, "case1", records[0].TagID)
+	assert.EqualValues(t, "regression/test/validator:assert", records[0].Path)
+
+	assert.EqualValues(t, "workflow_assertedResponse", records[1].Type)
+	if assert.Len(t, records[1].Validations, 1) {
+		assert.EqualValues(t, 1, records[1].Validations[0].FailedCount)
+		assert.EqualValues(t, "/status", records[1].Validations[0].Failures[0].Path)
+	}
+	assert.EqualValues(t, 1, records[2].InitSeq)
+	assert.EqualValues(t, 3, records[2].Seq)
+	assert.EqualValues(t, "msg", records[3].Package)
+	if assert.Len(t, records[3].Messages, 1) {
+		assert.EqualValues(t, "failed\n", records[3].Messages[0].Items[0].Text)
+	}
+}
+
+func TestEventLog_NestedActivities(t *testing.T) {
+	buf := new(bytes.Buffer)
+	eventLog := NewEventLog(buf, nil)
+	listener := eventLog.AsEventListener()
+
+	outer := msg.NewEvent(&model.Activity{MetaTag: &model.MetaTag{TagID: "outer"}, Caller: "regression", Task: "test", Service: "workflow", Action: "run"})
+	inner := msg.NewEvent(&model.Activity{MetaTag: &model.MetaTag{TagID: "inner"}, Caller: "app", Task: "build", Service: "exec", Action: "run"})
+	listener(outer)
+	listener(inner)
+	listener(msg.NewEvent(msg.NewStdoutEvent("exec", "building")))
+	listener(msg.NewEventWithInit(model.NewActivityEndEvent(&model.Activity{}), inner))
+	listener(msg.NewEvent(msg.NewStdoutEvent("workflow", "built")))
+	listener(msg.NewEventWithInit(model.NewActivityEndEvent(&model.Activity{}), outer))
+	listener(msg.NewEvent(msg.NewStdoutEvent("workflow", "done")))
+
+	records, err := ReadEventLog(buf)
+	if !assert.Nil(t, err) || !assert.Len(t, records, 7) {
+		return
+	}
+	var expectPaths = []string{"regression/test/workflow:run", "app/build/exec:run", "app/build/exec:run", "app/build/exec:run", "regression/test/workflow:run", "regression/test/workflow:run", ""}
+	var expectTags = []string{"outer", "inner", "inner", "inner", "outer", "outer", ""}
+	for i, record := range records {
+		assert.EqualValues(t, expectPaths[i], record.Path, "record %v", i)
+		assert.EqualValues(t, expectTags[i], record.TagID, "record %v", i)
+	}
+	assert.EqualValues(t, 2, records[3].InitSeq)
+	assert.EqualValues(t, 1, records[5].InitSeq)
+	assert.Empty(t, eventLog.inits, "completed activities are released")
+	assert.Empty(t, eventLog.active)
+}
@@ -2,8 +2,10 @@ package workflow
 
 import (
 	"github.com/viant/endly"
+	"github.com/viant/endly/internal/tracing"
 	"github.com/viant/endly/model"
 	"github.com/viant/toolbox/data"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 var processesKey = (*model.Processes)(nil)
@@ -54,6 +56,18 @@ func isWorkflowRunAction(action *model.Action) bool {
 	return action.Action == "run" && action.Service == ServiceID
 }
 
+// isDryRunnable returns true if action runs in dry run mode, workflow flow control actions are needed to expand the plan
+func isDryRunnable(action *model.Action) bool {
+	if action.Service != ServiceID {
+		return false
+	}
+	switch action.Action {
+	case "run", "switch", "goto", "exit", "nop", "print":
+		return true
+	}
+	return false
+}
+
 func runWithoutSelfIfNeeded(process *model.Process, action *model.Action, state data.Map, handler func() error) error {
 	if !isWorkflowRunAction(action) {
 		return handler()
@@ -62,3 +76,15 @@ func runWithoutSelfIfNeeded(process *model.Process, action *model.Action, state
 	defer state.Put(selfStateKey, process.State)
 	return handler()
 }
+
+// nodeAttributes returns workflow and task span attributes for supplied process
+func nodeAttributes(process *model.Process) []attribute.KeyValue {
+	var result = make([]attribute.KeyValue, 0, 2)
+	if process.Workflow != nil {
+		result = append(result, tracing.WorkflowKey.String(process.Workflow.Name))
+	}
+	if process.Task != nil {
+		result = append(result, tracing.TaskKey.String(process.Task.Name))
+	}
+	return result
+}
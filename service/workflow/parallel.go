@@ -0,0 +1,112 @@
+package workflow
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox/data"
+	"sync"
+	"sync/atomic"
+)
+
+// useCase represents actions sharing the same TagID
+type useCase struct {
+	tagID   string
+	actions []*model.Action
+	events  *msg.Events
+	result  data.Map
+	err     error
+	done    bool
+}
+
+// orderedEvents publishes use case events in use case order, regardless of completion order
+type orderedEvents struct {
+	mux      sync.Mutex
+	useCases []*useCase
+	next     int
+	context  *endly.Context
+}
+
+// complete flags use case as completed and publishes events of all consecutive completed use cases
+func (o *orderedEvents) complete(index int) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+	o.useCases[index].done = true
+	for ; o.next < len(o.useCases) && o.useCases[o.next].done; o.next++ {
+		if events := o.useCases[o.next].events; events != nil {
+			for _, event := range events.Events {
+				o.context.Publish(event)
+			}
+		}
+	}
+}
+
+// groupUseCases groups consecutive actions by TagID
+func groupUseCases(actions []*model.Action) []*useCase {
+	var result = make([]*useCase, 0)
+	for _, action := range actions {
+		if count := len(result); count > 0 && result[count-1].tagID == action.TagID {
+			result[count-1].actions = append(result[count-1].actions, action)
+			continue
+		}
+		result = append(result, &useCase{tagID: action.TagID, actions: []*model.Action{action}})
+	}
+	return result
+}
+
+// parallelContext returns isolated use case context with its own state, process (self) state, process stack and buffered events
+func parallelContext(context *endly.Context, process *model.Process) (*endly.Context, *model.Process, *msg.Events) {
+	result := asyncContext(context)
+	stack := processes(context).Clone()
+	if stack.Last() == process {
+		stack.Pop()
+	}
+	useCaseProcess := process.Clone()
+	stack.Push(useCaseProcess)
+	_ = result.Replace(processesKey, stack)
+	state := result.State()
+	state.Put(selfStateKey, useCaseProcess.State)
+	return result, useCaseProcess, result.MakeAsyncSafe()
+}
+
+// runParallelActions runs task use cases concurrently up to task.Parallel limit, each use case runs with cloned context
+func (s *Service) runParallelActions(context *endly.Context, process *model.Process, task *model.Task, result data.Map) error {
+	useCases := groupUseCases(task.Actions)
+	events := &orderedEvents{useCases: useCases, context: context}
+	limiter := make(chan bool, task.Parallel)
+	group := &sync.WaitGroup{}
+	var failed int32
+	context.Publish(msg.NewStdoutEvent("parallel", fmt.Sprintf("running %v use cases, parallel: %v", len(useCases), task.Parallel)))
+	for i := range useCases {
+		limiter <- true
+		if atomic.LoadInt32(&failed) == 1 {
+			<-limiter
+			events.complete(i)
+			continue
+		}
+		group.Add(1)
+		//context is cloned before the use case goroutine starts, since context clone is not thread safe
+		useCaseContext, useCaseProcess, useCaseEvents := parallelContext(context, process)
+		useCases[i].events = useCaseEvents
+		useCases[i].result = data.NewMap()
+		go func(index int, useCase *useCase) {
+			defer group.Done()
+			defer func() { <-limiter }()
+			defer events.complete(index)
+			if useCase.err = s.runActions(useCaseContext, useCaseProcess, useCase.actions, useCase.result); useCase.err != nil {
+				atomic.StoreInt32(&failed, 1)
+			}
+		}(i, useCases[i])
+	}
+	group.Wait()
+	for _, useCase := range useCases {
+		if useCase.err != nil {
+			return useCase.err
+		}
+		for k, v := range useCase.result {
+			result[k] = v
+		}
+	}
+	return nil
+}
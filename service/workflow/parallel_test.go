@@ -0,0 +1,102 @@
+package workflow
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGroupUseCases(t *testing.T) {
+	var useCases = []struct {
+		description string
+		tagIDs      []string
+		expect      []int
+	}{
+		{description: "single use case", tagIDs: []string{"t1", "t1"}, expect: []int{2}},
+		{description: "multi use cases", tagIDs: []string{"t1", "t1", "t2", "t3", "t3", "t3"}, expect: []int{2, 1, 3}},
+		{description: "no actions", tagIDs: []string{}, expect: []int{}},
+	}
+	for _, useCase := range useCases {
+		var actions = make([]*model.Action, 0)
+		for _, tagID := range useCase.tagIDs {
+			actions = append(actions, &model.Action{MetaTag: &model.MetaTag{TagID: tagID}})
+		}
+		grouped := groupUseCases(actions)
+		var actual = make([]int, 0)
+		for _, item := range grouped {
+			actual = append(actual, len(item.actions))
+		}
+		assert.EqualValues(t, useCase.expect, actual, useCase.description)
+	}
+}
+
+type parallelEchoRequest struct {
+	Index interface{}
+	TagID string
+}
+
+func TestService_RunParallelActions(t *testing.T) {
+	var echoed = sync.Map{}
+	manager := endly.New()
+	echoService := endly.NewAbstractService("parallelEcho")
+	echoService.Register(&endly.Route{
+		Action: "echo",
+		RequestProvider: func() interface{} {
+			return &parallelEchoRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &parallelEchoRequest{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			time.Sleep(10 * time.Millisecond)
+			echoRequest := request.(*parallelEchoRequest)
+			echoed.Store(echoRequest.TagID, echoRequest.Index)
+			return request, nil
+		},
+	})
+	manager.Register(echoService)
+	context := manager.NewContext(toolbox.NewContext())
+	defer context.Close()
+
+	service, err := context.Service(ServiceID)
+	if !assert.Nil(t, err) {
+		return
+	}
+	process := model.NewProcess(nil, &model.Workflow{AbstractNode: &model.AbstractNode{Name: "parallel"}}, nil)
+	process.State = data.NewMap()
+	process.State.Put("index", "parent")
+	Push(context, process)
+	state := context.State()
+	state.Put(selfStateKey, process.State)
+
+	task := &model.Task{AbstractNode: &model.AbstractNode{Name: "test"}, Parallel: 3}
+	process.SetTask(task)
+	var tagIDs = []string{"t1", "t2", "t3", "t4", "t5"}
+	for i, tagID := range tagIDs {
+		action := &model.Action{
+			ServiceRequest: &model.ServiceRequest{Service: "parallelEcho", Action: "echo", Request: map[string]interface{}{"Index": "${self.index}", "TagID": tagID}},
+			MetaTag:        &model.MetaTag{TagID: tagID, TagIndex: strconv.Itoa(i + 1)},
+		}
+		if !assert.Nil(t, action.Init()) {
+			return
+		}
+		task.Actions = append(task.Actions, action)
+	}
+	result := data.NewMap()
+	if !assert.Nil(t, service.(*Service).runParallelActions(context, process, task, result)) {
+		return
+	}
+	for i, tagID := range tagIDs {
+		index, ok := echoed.Load(tagID)
+		if assert.True(t, ok, tagID) {
+			assert.EqualValues(t, strconv.Itoa(i+1), index, tagID)
+		}
+	}
+	assert.EqualValues(t, "parent", process.State.GetString("index"), "branch index is not shared with parent process")
+}
@@ -0,0 +1,117 @@
+package workflow
+
+import (
+	"fmt"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"time"
+)
+
+// runActionWithPolicy runs action applying action timeout and retry policy
+func (s *Service) runActionWithPolicy(context *endly.Context, action *model.Action, process *model.Process) (map[string]interface{}, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := s.runActionWithTimeout(context, action, process)
+		if action.Retry == nil {
+			return response, err
+		}
+		retry, retryErr := action.Retry.ShouldRetry(context, attempt, response, err)
+		if retryErr != nil {
+			return nil, retryErr
+		}
+		if !retry {
+			if err == nil && action.Retry.When != "" && attempt >= action.Retry.MaxAttempts {
+				if matched, _ := action.Retry.Matches(context, response, err); matched {
+					return response, fmt.Errorf("%v: retry criteria still met after %v attempts: %v", action.TagID, attempt, action.Retry.When)
+				}
+			}
+			return response, err
+		}
+		delay := action.Retry.Delay(attempt)
+		context.Publish(NewRetryEvent(action, attempt, delay, err))
+		s.Sleep(context, int(delay/time.Millisecond))
+		if context.IsClosed() {
+			return response, err
+		}
+	}
+}
+
+// runActionWithTimeout runs action, if action defines timeout service call context gets canceled once timeout elapsed,
+// action runs with a deep state and process copy merged back on completion, timed out action is awaited and discarded
+func (s *Service) runActionWithTimeout(context *endly.Context, action *model.Action, process *model.Process) (map[string]interface{}, error) {
+	if action.TimeoutMs <= 0 {
+		return s.runAction(context, action, process)
+	}
+	actionContext, actionProcess, cancel := timeoutContext(context, process, time.Duration(action.TimeoutMs)*time.Millisecond)
+	defer cancel()
+	type result struct {
+		response map[string]interface{}
+		err      error
+	}
+	done := make(chan *result, 1)
+	go func() {
+		response, err := s.runAction(actionContext, action, actionProcess)
+		done <- &result{response: response, err: err}
+	}()
+	select {
+	case actual := <-done:
+		mergeTimedAction(context, process, actionContext, actionProcess)
+		return actual.response, actual.err
+	case <-actionContext.Background().Done():
+		cancel()
+		<-done //abandoned action still uses shared services, wait till it returns
+		if context.IsClosed() {
+			return nil, fmt.Errorf("%v: canceled", action.TagID)
+		}
+		context.Publish(NewTimeoutEvent(action))
+		return nil, fmt.Errorf("%v: %v:%v timed out after %v ms", action.TagID, action.Service, action.Action, action.TimeoutMs)
+	}
+}
+
+// timeoutContext returns timed action context with deep cloned state, its own process (self) copy and process stack
+func timeoutContext(context *endly.Context, process *model.Process, timeout time.Duration) (*endly.Context, *model.Process, func()) {
+	result, cancel := context.WithTimeout(timeout)
+	state := context.State()
+	actionState := state.Clone()
+	actionProcess := process.Clone()
+	actionState.Put(selfStateKey, actionProcess.State)
+	result.SetState(actionState)
+	stack := processes(context).Clone()
+	if stack.Last() == process {
+		stack.Pop()
+	}
+	stack.Push(actionProcess)
+	_ = result.Replace(processesKey, stack)
+	return result, actionProcess, cancel
+}
+
+// mergeTimedAction applies completed timed action state and process changes to the caller
+func mergeTimedAction(context *endly.Context, process *model.Process, actionContext *endly.Context, actionProcess *model.Process) {
+	state := context.State()
+	actionState := actionContext.State()
+	for k := range state {
+		if _, ok := actionState[k]; !ok && k != selfStateKey {
+			delete(state, k)
+		}
+	}
+	for k, v := range actionState {
+		if k == selfStateKey {
+			continue
+		}
+		state[k] = v
+	}
+	for k := range process.State {
+		if _, ok := actionProcess.State[k]; !ok {
+			delete(process.State, k)
+		}
+	}
+	for k, v := range actionProcess.State {
+		process.State[k] = v
+	}
+	process.Scheduled = actionProcess.Scheduled
+	if actionProcess.IsTerminated() {
+		process.Terminate()
+	}
+	if actionProcess.ExecutionError.Error != "" {
+		process.ExecutionError = actionProcess.ExecutionError
+	}
+}
@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"testing"
+	"time"
+)
+
+type stateWriteRequest struct {
+	Key     string
+	SleepMs int
+}
+
+func TestService_RunActionWithTimeout(t *testing.T) {
+	manager := endly.New()
+	writerService := endly.NewAbstractService("stateWriter")
+	writerService.Register(&endly.Route{
+		Action: "write",
+		RequestProvider: func() interface{} {
+			return &stateWriteRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &stateWriteRequest{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			writeRequest := request.(*stateWriteRequest)
+			time.Sleep(time.Duration(writeRequest.SleepMs) * time.Millisecond)
+			state := context.State()
+			state.Put(writeRequest.Key, true)
+			self := state.GetMap(selfStateKey)
+			self.Put(writeRequest.Key, true)
+			return request, nil
+		},
+	})
+	manager.Register(writerService)
+
+	var useCases = []struct {
+		description string
+		sleepMs     int
+		hasError    bool
+		expectState bool
+	}{
+		{description: "completed within timeout", sleepMs: 0, expectState: true},
+		{description: "timed out", sleepMs: 200, hasError: true},
+	}
+	for _, useCase := range useCases {
+		context := manager.NewContext(toolbox.NewContext())
+		service, err := context.Service(ServiceID)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		process := model.NewProcess(nil, &model.Workflow{AbstractNode: &model.AbstractNode{Name: "timeout"}}, nil)
+		process.State = data.NewMap()
+		Push(context, process)
+		state := context.State()
+		state.Put(selfStateKey, process.State)
+		action := &model.Action{
+			ServiceRequest: &model.ServiceRequest{Service: "stateWriter", Action: "write", Request: map[string]interface{}{"Key": "written", "SleepMs": useCase.sleepMs}},
+			MetaTag:        &model.MetaTag{TagID: "write"},
+			TimeoutMs:      50,
+		}
+		if !assert.Nil(t, action.Init(), useCase.description) {
+			continue
+		}
+		_, err = service.(*Service).runActionWithTimeout(context, action, process)
+		assert.EqualValues(t, useCase.hasError, err != nil, useCase.description)
+		assert.EqualValues(t, useCase.expectState, process.State.Has("written"), useCase.description)
+		time.Sleep(300 * time.Millisecond) //abandoned action must not write after timeout
+		assert.EqualValues(t, useCase.expectState, state.Has("written"), useCase.description)
+		assert.EqualValues(t, useCase.expectState, process.State.Has("written"), useCase.description)
+		assert.True(t, Last(context) == process, useCase.description)
+		context.Close()
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/viant/afs"
 	"github.com/viant/afs/url"
 	"github.com/viant/endly"
+	"github.com/viant/endly/internal/debug"
+	"github.com/viant/endly/internal/tracing"
 	"github.com/viant/endly/model"
 	"github.com/viant/endly/model/criteria"
 	"github.com/viant/endly/model/location"
@@ -110,6 +112,7 @@ func (s *Service) runAction(context *endly.Context, action *model.Action, proces
 	var request interface{}
 	err = s.runNode(context, "action", process, action.AbstractNode, func(context *endly.Context, process *model.Process) (in, out data.Map, err error) {
 		process.Push(activity)
+		tracing.SetAttributes(context.Background(), tracing.ServiceKey.String(activity.Service), tracing.ActionKey.String(activity.Action), tracing.TagIDKey.String(activity.TagID))
 		startEvent := s.Begin(context, activity)
 		defer s.End(context)(startEvent, model.NewActivityEndEvent(activity))
 		defer process.Pop()
@@ -121,6 +124,15 @@ func (s *Service) runAction(context *endly.Context, action *model.Action, proces
 		}); err != nil {
 			return nil, nil, err
 		}
+		debugged, err := debugNode(context, debug.KindAction, debugStep(process, process.Task, action), request)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer func() { debugged(response) }()
+		if context.DryRun && !isDryRunnable(action) {
+			context.Publish(NewDryRunEvent(activity, request))
+			return nil, state, nil
+		}
 		err = endly.Run(context, request, activity.ServiceResponse)
 		if err != nil {
 			return nil, nil, err
@@ -142,55 +154,31 @@ func (s *Service) runTask(context *endly.Context, process *model.Process, task *
 	var state = context.State()
 
 	asyncGroup := &sync.WaitGroup{}
-	var asyncError error
+	asyncErrors := NewAsyncErrors()
 	asyncActions := task.AsyncActions()
 
 	err := s.runNode(context, "task", process, task.AbstractNode, func(context *endly.Context, process *model.Process) (in, out data.Map, err error) {
+		debugged, err := debugNode(context, debug.KindTask, debugStep(process, task, nil), nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer func() { debugged(result) }()
 		if task.TasksNode != nil && len(task.Tasks) > 0 {
 			if err := s.runTasks(context, process, task.TasksNode); err != nil || len(task.Actions) == 0 {
 				return state, result, err
 			}
 		}
 		if len(asyncActions) > 0 {
-			s.runAsyncActions(context, process, task, asyncActions, asyncGroup, &asyncError)
+			s.runAsyncActions(context, process, asyncActions, asyncGroup, asyncErrors)
 		}
-		for i := 0; i < len(task.Actions); i++ {
-			action := task.Actions[i]
-			if action.Async {
-				continue
-			}
-			if process.HasTagID && !process.TagIDs[action.TagID] {
-				continue
-			}
-			var handler = func(action *model.Action) func() (interface{}, error) {
-				return func() (interface{}, error) {
-					var response, err = s.runAction(context, action, process)
-					if err != nil {
-						return nil, err
-					}
-					if len(response) > 0 {
-						result[action.ID()] = response
-					}
-					return response, nil
-				}
-			}
-			moveToNextTag, err := criteria.Evaluate(context, context.State(), action.Skip, action.SkipEval(), "Skip", false)
-			if err != nil {
-				return nil, nil, err
-			}
-			if moveToNextTag {
-				for j := i + 1; j < len(task.Actions) && action.TagID == task.Actions[j].TagID; j++ {
-					i++
-				}
-				continue
-			}
-			var extractable = make(map[string]interface{})
-			err = action.Repeater.Run(context, "action", s.AbstractService, handler(task.Actions[i]), extractable)
-			if err != nil {
-				return nil, nil, err
-			}
+		if task.Parallel > 1 {
+			err = s.runParallelActions(context, process, task, result)
+		} else {
+			err = s.runActions(context, process, task.Actions, result)
+		}
+		if err != nil {
+			return nil, nil, err
 		}
-
 		return state, result, nil
 	})
 
@@ -200,60 +188,69 @@ func (s *Service) runTask(context *endly.Context, process *model.Process, task *
 			asyncGroup.Wait()
 			return nil
 		})
-		if err == nil && asyncError != nil {
-			err = asyncError
+		if err == nil && asyncErrors.HasErrors() {
+			err = asyncErrors
 		}
 	}
 	state.Apply(result)
 	return result, err
 }
 
-func (s *Service) runAsyncAction(parent, context *endly.Context, process *model.Process, action *model.Action, group *sync.WaitGroup) error {
-	defer group.Done()
-	events := context.MakeAsyncSafe()
-	defer func() {
-		for _, event := range events.Events {
-			parent.Publish(event)
+// runActions runs supplied actions sequentially, action responses are stored in result keyed by action ID
+func (s *Service) runActions(context *endly.Context, process *model.Process, actions []*model.Action, result data.Map) error {
+	for i := 0; i < len(actions); i++ {
+		action := actions[i]
+		if action.Async {
+			continue
 		}
-	}()
-	var result = make(map[string]interface{})
-	var handler = func(action *model.Action) func() (interface{}, error) {
-		return func() (interface{}, error) {
-			var response, err = s.runAction(context, action, process)
-			if err != nil {
-				return nil, err
+		if process.HasTagID && !process.TagIDs[action.TagID] {
+			continue
+		}
+		checkpoint := getCheckpoint(context)
+		if checkpoint != nil && checkpoint.IsCompleted(actionCheckpointKey(process, action)) {
+			continue
+		}
+		var handler = func(action *model.Action) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				var response, err = s.runActionWithPolicy(context, action, process)
+				if err != nil {
+					return nil, err
+				}
+				if len(response) > 0 {
+					result[action.ID()] = response
+				}
+				return response, nil
 			}
-			if len(response) > 0 {
-				result[action.ID()] = response
+		}
+		moveToNextTag, err := criteria.Evaluate(context, context.State(), action.Skip, action.SkipEval(), "Skip", false)
+		if err != nil {
+			return err
+		}
+		if moveToNextTag {
+			for j := i + 1; j < len(actions) && action.TagID == actions[j].TagID; j++ {
+				i++
+			}
+			continue
+		}
+		var extractable = make(map[string]interface{})
+		err = action.Repeater.Run(context, "action", s.AbstractService, handler(actions[i]), extractable)
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			if err = checkpoint.Complete(actionCheckpointKey(process, action), process.Workflow.Name, context.State()); err != nil {
+				return err
 			}
-			return response, nil
 		}
-	}
-
-	var extractable = make(map[string]interface{})
-	err := action.Repeater.Run(context, "action", s.AbstractService, handler(action), extractable)
-	if err != nil {
-		return err
 	}
 	return nil
 }
 
-func (s *Service) runAsyncActions(context *endly.Context, process *model.Process, task *model.Task, asyncAction []*model.Action, group *sync.WaitGroup, asyncError *error) {
-	if len(asyncAction) > 0 {
-		group.Add(len(asyncAction))
-		var groupErr error
-		for i := range asyncAction {
-			context.Publish(NewAsyncEvent(asyncAction[i]))
-			go func(action *model.Action, actionContext *endly.Context) {
-				if err := s.runAsyncAction(context, actionContext, process, action, group); err != nil {
-					groupErr = err
-				}
-			}(asyncAction[i], context.Clone())
-		}
-		if groupErr != nil {
-			*asyncError = groupErr
-		}
-	}
+// asyncContext returns cloned context for async action, async actions are not debugged
+func asyncContext(context *endly.Context) *endly.Context {
+	result := context.Clone()
+	result.Debugger = nil
+	return result
 }
 
 func (s *Service) applyVariables(candidates interface{}, process *model.Process, in data.Map, context *endly.Context) error {
@@ -291,6 +288,31 @@ func (s *Service) enableLoggingIfNeeded(context *endly.Context, request *RunRequ
 		logger := NewLogger(logDirectory, context.Listener)
 		context.Listener = logger.AsEventListener()
 	}
+	if request.EventLog != "" && !context.Contains(eventLogKey) {
+		file, err := openEventLog(request.EventLog)
+		if err != nil {
+			log.Printf("failed to open event log: %v", err)
+			return
+		}
+		eventLog := NewEventLog(file, context.Listener)
+		_ = context.Put(eventLogKey, eventLog)
+		context.Listener = eventLog.AsEventListener()
+		context.Deffer(func() {
+			_ = file.Close()
+		})
+	}
+}
+
+func (s *Service) enableTracingIfNeeded(context *endly.Context, request *RunRequest) error {
+	if request.TraceEndpoint == "" || tracing.HasProvider(context.Background()) {
+		return nil
+	}
+	provider, err := tracing.NewProvider(context.Background(), request.TraceEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create tracer provider: %w", err)
+	}
+	context.EnableTracing(provider)
+	return nil
 }
 
 // NewRepoResource returns new woorkflow repo resource, it takes context map and resource URI
@@ -339,11 +361,28 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 	}
 
 	s.enableLoggingIfNeeded(upstreamContext, request)
+	if err = s.enableTracingIfNeeded(upstreamContext, request); err != nil {
+		return nil, err
+	}
+	if request.DryRun && !upstreamContext.DryRun {
+		upstreamContext.DryRun = true
+		defer func() { upstreamContext.DryRun = false }()
+	}
+	closeDebugger, err := s.enableDebuggerIfNeeded(upstreamContext, request)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDebugger()
 	workflow, err := s.getWorkflow(upstreamContext, request)
 	if err != nil {
 		return nil, err
 	}
 
+	completeCheckpoint, err := s.enableCheckpointIfNeeded(upstreamContext, request, workflow)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { completeCheckpoint(err) }()
 	defer Pop(upstreamContext)
 
 	upstreamProcess := Last(upstreamContext)
@@ -378,6 +417,9 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 		defer state.Put(selfStateKey, origSelfState)
 	}
 
+	if checkpoint := getCheckpoint(context); checkpoint != nil {
+		checkpoint.Restore(workflow.Name, state) //restored before parameters, so that resumed run parameters take precedence
+	}
 	params := s.publishParameters(request, context)
 	process.State.Put(paramsStateKey, params)
 	if len(workflow.Data) > 0 {
@@ -410,6 +452,11 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 	}
 	filteredTasks := workflow.TasksNode.Select(taskSelector)
 	err = s.runNode(context, "workflow", process, workflow.AbstractNode, func(context *endly.Context, process *model.Process) (in, out data.Map, err error) {
+		debugged, err := debugNode(context, debug.KindWorkflow, debugStep(process, nil, nil), request)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer func() { debugged(response.Data) }()
 		err = s.runTasks(context, process, filteredTasks)
 		return state, response.Data, err
 	})
@@ -422,10 +469,13 @@ func (s *Service) runWorkflow(upstreamContext *endly.Context, request *RunReques
 	return response, err
 }
 
-func (s *Service) runNode(context *endly.Context, nodeType string, process *model.Process, node *model.AbstractNode, runHandler func(context *endly.Context, process *model.Process) (in, out data.Map, err error)) error {
+func (s *Service) runNode(context *endly.Context, nodeType string, process *model.Process, node *model.AbstractNode, runHandler func(context *endly.Context, process *model.Process) (in, out data.Map, err error)) (err error) {
 	if !process.CanRun() {
 		return nil
 	}
+	if context.IsClosed() {
+		return fmt.Errorf("%v was canceled", nodeType)
+	}
 	original := context.Logging
 	context.Logging = node.Logging
 	defer func() {
@@ -436,6 +486,8 @@ func (s *Service) runNode(context *endly.Context, nodeType string, process *mode
 	if err != nil || !canRun {
 		return err
 	}
+	endSpan := context.StartSpan(strings.TrimSpace(nodeType+" "+node.Name), nodeAttributes(process)...)
+	defer func() { endSpan(err) }()
 	err = node.Init.Apply(state, state)
 	s.addVariableEvent(fmt.Sprintf("%v.Init", nodeType), node.Init, context, state, state)
 	if err != nil {
@@ -512,12 +564,21 @@ func (s *Service) runTasks(context *endly.Context, process *model.Process, tasks
 		if process.IsTerminated() {
 			break
 		}
+		checkpoint := getCheckpoint(context)
+		if checkpoint != nil && checkpoint.IsCompleted(taskCheckpointKey(process, task)) {
+			continue
+		}
 		if _, err = s.runTask(context, process, task); err != nil {
 			err = s.runOnErrorTask(context, process, tasks, err)
 		}
 		if err != nil {
 			return err
 		}
+		if checkpoint != nil {
+			if err = checkpoint.Complete(taskCheckpointKey(process, task), process.Workflow.Name, context.State()); err != nil {
+				return err
+			}
+		}
 	}
 	var scheduledTask = process.Scheduled
 	if scheduledTask != nil {
@@ -0,0 +1,84 @@
+package workflow
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/endly"
+	"github.com/viant/endly/model/msg"
+	"github.com/viant/toolbox"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type dryRunProbeRequest struct {
+	Target string
+}
+
+func TestService_DryRun(t *testing.T) {
+	var touched int32
+	manager := endly.New()
+	probeService := endly.NewAbstractService("dryRunProbe")
+	probeService.Register(&endly.Route{
+		Action: "touch",
+		RequestProvider: func() interface{} {
+			return &dryRunProbeRequest{}
+		},
+		ResponseProvider: func() interface{} {
+			return &dryRunProbeRequest{}
+		},
+		Handler: func(context *endly.Context, request interface{}) (interface{}, error) {
+			atomic.AddInt32(&touched, 1)
+			return request, nil
+		},
+	})
+	manager.Register(probeService)
+
+	var useCases = []struct {
+		description   string
+		dryRun        bool
+		expectTouched int32
+		expectPlanned []string
+	}{
+		{description: "dry run", dryRun: true, expectTouched: 0, expectPlanned: []string{"dryRunProbe.touch"}},
+		{description: "regular run", dryRun: false, expectTouched: 1},
+	}
+	for _, useCase := range useCases {
+		atomic.StoreInt32(&touched, 0)
+		context := manager.NewContext(toolbox.NewContext())
+		var planned = make([]string, 0)
+		var mux sync.Mutex
+		context.Listener = func(event msg.Event) {
+			if dryRunEvent, ok := event.Value().(*DryRunEvent); ok {
+				mux.Lock()
+				planned = append(planned, dryRunEvent.Service+"."+dryRunEvent.Action)
+				mux.Unlock()
+			}
+		}
+		request, err := NewRunRequestFromURL("test/dryrun/dryrun.yaml")
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		request.AssetURL = "test/dryrun/dryrun.yaml"
+		request.Name = "dryrun"
+		request.Params = map[string]interface{}{"name": "endly"}
+		request.PublishParameters = true
+		request.DryRun = useCase.dryRun
+		response := &RunResponse{}
+		err = endly.Run(context, request, response)
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expectTouched, atomic.LoadInt32(&touched), useCase.description)
+		mux.Lock()
+		assert.EqualValues(t, len(useCase.expectPlanned), len(planned), useCase.description)
+		for i := range useCase.expectPlanned {
+			if i < len(planned) {
+				assert.EqualValues(t, useCase.expectPlanned[i], planned[i], useCase.description)
+			}
+		}
+		mux.Unlock()
+		assert.EqualValues(t, "hello endly", response.Data["greeting"], "dry runnable actions run: "+useCase.description)
+		assert.False(t, context.DryRun, "dry run is scoped to workflow run: "+useCase.description)
+		context.Close()
+	}
+}